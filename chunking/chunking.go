@@ -0,0 +1,282 @@
+// Package chunking splits long documents into chunks and turns them into records for Pinecone indexes with
+// integrated embedding.
+//
+// Indexes created with [pinecone.Client.CreateIndexForModel] embed the field named in
+// [pinecone.CreateIndexForModelEmbed.FieldMap] on upsert, but each record must fit within the model's input
+// limit. A [Splitter] breaks a document's text into chunks, and [ToRecords] converts the chunks into
+// [pinecone.IntegratedRecord] values with deterministic "_id" values of the form "<document id>#<chunk number>",
+// ready for [pinecone.IndexConnection.UpsertRecords]. [DeleteDocument] removes every chunk of a document.
+//
+// Four strategies are provided:
+//   - [FixedSize]: Chunks of a fixed number of characters.
+//   - [Sentence]: Whole sentences, grouped up to a maximum number of characters.
+//   - [RecursiveSeparator]: Splits on paragraphs, then lines, then words, then characters, keeping the largest
+//     pieces that fit.
+//   - [TokenEstimate]: Whole words, grouped up to an estimated number of model tokens.
+package chunking
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// [Splitter] splits text into chunks. Implementations return no chunks for empty or whitespace-only text.
+type Splitter interface {
+	Split(text string) ([]string, error)
+}
+
+// [FixedSize] splits text into chunks of Size characters (runes), each starting Overlap characters before the
+// end of the previous chunk.
+//
+// Fields:
+//   - Size: (Required) The number of characters per chunk.
+//   - Overlap: (Optional) The number of characters shared by consecutive chunks. Must be less than Size.
+type FixedSize struct {
+	Size    int
+	Overlap int
+}
+
+// [FixedSize.Split] implements [Splitter].
+func (s FixedSize) Split(text string) ([]string, error) {
+	if err := validateSizes(s.Size, s.Overlap); err != nil {
+		return nil, err
+	}
+	if strings.TrimSpace(text) == "" {
+		return nil, nil
+	}
+	runes := []rune(text)
+	var chunks []string
+	for start := 0; ; start += s.Size - s.Overlap {
+		end := min(start+s.Size, len(runes))
+		chunks = append(chunks, string(runes[start:end]))
+		if end == len(runes) {
+			return chunks, nil
+		}
+	}
+}
+
+// [Sentence] splits text into sentences, ending at ".", "!", or "?" followed by whitespace, and groups
+// consecutive sentences into chunks of at most MaxSize characters. A sentence longer than MaxSize is split
+// with [FixedSize].
+//
+// Fields:
+//   - MaxSize: (Required) The maximum number of characters (runes) per chunk.
+//   - Overlap: (Optional) The number of trailing sentences of each chunk repeated at the start of the next one.
+type Sentence struct {
+	MaxSize int
+	Overlap int
+}
+
+// [Sentence.Split] implements [Splitter].
+func (s Sentence) Split(text string) ([]string, error) {
+	if s.MaxSize <= 0 {
+		return nil, fmt.Errorf("MaxSize must be greater than 0")
+	}
+	if s.Overlap < 0 {
+		return nil, fmt.Errorf("Overlap cannot be negative")
+	}
+
+	var sentences []string
+	for _, sentence := range splitSentences(text) {
+		if utf8.RuneCountInString(sentence) <= s.MaxSize {
+			sentences = append(sentences, sentence)
+			continue
+		}
+		pieces, _ := FixedSize{Size: s.MaxSize}.Split(sentence)
+		for _, piece := range pieces {
+			if piece = strings.TrimSpace(piece); piece != "" {
+				sentences = append(sentences, piece)
+			}
+		}
+	}
+	return groupUnits(sentences, " ", s.MaxSize, s.Overlap, utf8.RuneCountInString), nil
+}
+
+// [RecursiveSeparator] splits text on the first separator, recursively splitting any piece longer than Size
+// with the next separator, then merges adjacent pieces back together into chunks of at most Size characters.
+// This keeps paragraphs, then lines, then words together whenever they fit.
+//
+// Fields:
+//   - Size: (Required) The maximum number of characters (runes) per chunk.
+//   - Overlap: (Optional) The approximate number of characters shared by consecutive chunks. Must be less than Size.
+//   - Separators: (Optional) The separators to try, in order. Defaults to paragraph breaks, line breaks, and
+//     spaces. Pieces that are still too long after the last separator are split by character.
+type RecursiveSeparator struct {
+	Size       int
+	Overlap    int
+	Separators []string
+}
+
+var defaultSeparators = []string{"\n\n", "\n", " "}
+
+// [RecursiveSeparator.Split] implements [Splitter].
+func (s RecursiveSeparator) Split(text string) ([]string, error) {
+	if err := validateSizes(s.Size, s.Overlap); err != nil {
+		return nil, err
+	}
+	separators := s.Separators
+	if len(separators) == 0 {
+		separators = defaultSeparators
+	}
+	if strings.TrimSpace(text) == "" {
+		return nil, nil
+	}
+	return s.split(text, separators), nil
+}
+
+func (s RecursiveSeparator) split(text string, separators []string) []string {
+	if utf8.RuneCountInString(text) <= s.Size {
+		return []string{text}
+	}
+	if len(separators) == 0 {
+		chunks, _ := FixedSize{Size: s.Size, Overlap: s.Overlap}.Split(text)
+		return chunks
+	}
+
+	separator := separators[0]
+	var pieces []string
+	for _, piece := range strings.Split(text, separator) {
+		if strings.TrimSpace(piece) == "" {
+			continue
+		}
+		if utf8.RuneCountInString(piece) > s.Size {
+			pieces = append(pieces, s.split(piece, separators[1:])...)
+		} else {
+			pieces = append(pieces, piece)
+		}
+	}
+	return mergeWithOverlap(pieces, separator, s.Size, s.Overlap)
+}
+
+// [TokenEstimate] groups whole words into chunks of at most MaxTokens estimated tokens. Tokens are estimated
+// per word as the number of characters divided by CharsPerToken, rounded up, which is a reasonable
+// approximation for English text and subword tokenizers.
+//
+// Fields:
+//   - MaxTokens: (Required) The maximum estimated number of tokens per chunk, e.g. the model's
+//     [pinecone.ModelInfo.MaxSequenceLength].
+//   - OverlapTokens: (Optional) The approximate number of tokens shared by consecutive chunks. Must be less
+//     than MaxTokens.
+//   - CharsPerToken: (Optional) The average number of characters per token. Defaults to 4.
+type TokenEstimate struct {
+	MaxTokens     int
+	OverlapTokens int
+	CharsPerToken float64
+}
+
+// [TokenEstimate.Split] implements [Splitter].
+func (s TokenEstimate) Split(text string) ([]string, error) {
+	if err := validateSizes(s.MaxTokens, s.OverlapTokens); err != nil {
+		return nil, err
+	}
+	return mergeWithOverlapFunc(strings.Fields(text), " ", s.MaxTokens, s.OverlapTokens, s.estimate), nil
+}
+
+func (s TokenEstimate) estimate(text string) int {
+	charsPerToken := s.CharsPerToken
+	if charsPerToken <= 0 {
+		charsPerToken = 4
+	}
+	tokens := 0
+	for _, word := range strings.Fields(text) {
+		tokens += max(1, int(math.Ceil(float64(utf8.RuneCountInString(word))/charsPerToken)))
+	}
+	return tokens
+}
+
+// [EstimateTokens] returns the number of tokens in text as estimated by [TokenEstimate] with the default
+// CharsPerToken.
+func EstimateTokens(text string) int {
+	return TokenEstimate{}.estimate(text)
+}
+
+func validateSizes(size, overlap int) error {
+	if size <= 0 {
+		return fmt.Errorf("chunk size must be greater than 0")
+	}
+	if overlap < 0 || overlap >= size {
+		return fmt.Errorf("overlap must be at least 0 and less than the chunk size, got %d for size %d", overlap, size)
+	}
+	return nil
+}
+
+func splitSentences(text string) []string {
+	var sentences []string
+	runes := []rune(text)
+	start := 0
+	for i, r := range runes {
+		if (r == '.' || r == '!' || r == '?') && (i+1 == len(runes) || unicode.IsSpace(runes[i+1])) {
+			if sentence := strings.TrimSpace(string(runes[start : i+1])); sentence != "" {
+				sentences = append(sentences, sentence)
+			}
+			start = i + 1
+		}
+	}
+	if sentence := strings.TrimSpace(string(runes[start:])); sentence != "" {
+		sentences = append(sentences, sentence)
+	}
+	return sentences
+}
+
+// groupUnits joins consecutive units into chunks of at most maxSize, measured by size, repeating the last
+// overlap units of each chunk at the start of the next one.
+func groupUnits(units []string, separator string, maxSize, overlap int, size func(string) int) []string {
+	var chunks []string
+	var current []string
+	currentSize := 0
+	for i := 0; i < len(units); i++ {
+		unitSize := size(units[i])
+		if len(current) > 0 && currentSize+len(separator)+unitSize > maxSize {
+			chunks = append(chunks, strings.Join(current, separator))
+			keep := min(overlap, len(current))
+			// Only carry over sentences if the next unit still fits after them.
+			for keep > 0 && size(strings.Join(current[len(current)-keep:], separator))+len(separator)+unitSize > maxSize {
+				keep--
+			}
+			current = append([]string(nil), current[len(current)-keep:]...)
+			currentSize = size(strings.Join(current, separator))
+		}
+		if len(current) > 0 {
+			currentSize += len(separator)
+		}
+		current = append(current, units[i])
+		currentSize += unitSize
+	}
+	if len(current) > 0 {
+		chunks = append(chunks, strings.Join(current, separator))
+	}
+	return chunks
+}
+
+func mergeWithOverlap(pieces []string, separator string, maxSize, overlap int) []string {
+	return mergeWithOverlapFunc(pieces, separator, maxSize, overlap, utf8.RuneCountInString)
+}
+
+// mergeWithOverlapFunc joins consecutive pieces into chunks of at most maxSize, measured by size, starting each
+// chunk with the trailing pieces of the previous chunk that add up to at most overlap.
+func mergeWithOverlapFunc(pieces []string, separator string, maxSize, overlap int, size func(string) int) []string {
+	var chunks []string
+	var current []string
+	joinedSize := func(parts []string) int { return size(strings.Join(parts, separator)) }
+	for _, piece := range pieces {
+		if len(current) > 0 && joinedSize(append(current[:len(current):len(current)], piece)) > maxSize {
+			chunks = append(chunks, strings.Join(current, separator))
+			keep := 0
+			for keep < len(current) && joinedSize(current[len(current)-keep-1:]) <= overlap {
+				keep++
+			}
+			current = append([]string(nil), current[len(current)-keep:]...)
+			for len(current) > 0 && joinedSize(append(current[:len(current):len(current)], piece)) > maxSize {
+				current = current[1:]
+			}
+		}
+		current = append(current, piece)
+	}
+	if len(current) > 0 {
+		chunks = append(chunks, strings.Join(current, separator))
+	}
+	return chunks
+}
@@ -0,0 +1,94 @@
+package chunking
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Unit tests:
+func TestFixedSizeUnit(t *testing.T) {
+	chunks, err := FixedSize{Size: 4, Overlap: 1}.Split("abcdefghij")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"abcd", "defg", "ghij"}, chunks)
+
+	chunks, err = FixedSize{Size: 3}.Split("héllo wörld")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"hél", "lo ", "wör", "ld"}, chunks, "sizes are counted in runes")
+
+	chunks, err = FixedSize{Size: 3}.Split("   ")
+	require.NoError(t, err)
+	assert.Empty(t, chunks)
+
+	_, err = FixedSize{Size: 0}.Split("abc")
+	require.Error(t, err)
+	_, err = FixedSize{Size: 3, Overlap: 3}.Split("abc")
+	require.ErrorContains(t, err, "overlap")
+}
+
+func TestSentenceUnit(t *testing.T) {
+	text := "The cat sat. The dog ran! Did the bird sing? It did."
+
+	chunks, err := Sentence{MaxSize: 30}.Split(text)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"The cat sat. The dog ran!", "Did the bird sing? It did."}, chunks)
+
+	chunks, err = Sentence{MaxSize: 31, Overlap: 1}.Split(text)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"The cat sat. The dog ran!", "The dog ran! Did the bird sing?", "Did the bird sing? It did."}, chunks)
+
+	// Decimal points are not sentence boundaries, and long sentences are split.
+	chunks, err = Sentence{MaxSize: 10}.Split("Pi is 3.14 roughly.")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Pi is 3.14", "roughly."}, chunks)
+
+	_, err = Sentence{}.Split(text)
+	require.Error(t, err)
+}
+
+func TestRecursiveSeparatorUnit(t *testing.T) {
+	text := "First paragraph is short.\n\nSecond paragraph is a bit longer than the first one.\n\nThird."
+
+	chunks, err := RecursiveSeparator{Size: 30}.Split(text)
+	require.NoError(t, err)
+	for _, chunk := range chunks {
+		assert.LessOrEqual(t, utf8.RuneCountInString(chunk), 30, chunk)
+	}
+	assert.Equal(t, "First paragraph is short.", chunks[0], "paragraphs that fit are kept whole")
+	assert.Equal(t, "Third.", chunks[len(chunks)-1])
+	assert.Equal(t, "Second paragraph is a bit", chunks[1], "long paragraphs are split on words")
+
+	chunks, err = RecursiveSeparator{Size: 12, Overlap: 5, Separators: []string{" "}}.Split("one two three four five")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"one two", "two three", "three four", "four five"}, chunks)
+
+	chunks, err = RecursiveSeparator{Size: 4, Separators: []string{" "}}.Split("abcdefgh ij")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"abcd", "efgh", "ij"}, chunks, "pieces without separators fall back to characters")
+}
+
+func TestTokenEstimateUnit(t *testing.T) {
+	assert.Equal(t, 6, EstimateTokens("a tokenizer is fun"), "a, is, and fun are 1 token each; tokenizer is 3")
+
+	text := strings.Repeat("word ", 10)
+	chunks, err := TokenEstimate{MaxTokens: 4, OverlapTokens: 1}.Split(text)
+	require.NoError(t, err)
+	require.NotEmpty(t, chunks)
+	for i, chunk := range chunks {
+		assert.LessOrEqual(t, EstimateTokens(chunk), 4)
+		if i > 0 {
+			prev := strings.Fields(chunks[i-1])
+			assert.Equal(t, prev[len(prev)-1], strings.Fields(chunk)[0], "consecutive chunks should overlap by one word")
+		}
+	}
+
+	chunks, err = TokenEstimate{MaxTokens: 2, CharsPerToken: 1}.Split("ab cd")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"ab", "cd"}, chunks)
+
+	_, err = TokenEstimate{MaxTokens: 2, OverlapTokens: 2}.Split("ab")
+	require.Error(t, err)
+}
@@ -0,0 +1,166 @@
+package chunking
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/pinecone-io/go-pinecone/v6/pinecone"
+)
+
+const (
+	// IdSeparator separates the document ID from the chunk number in record IDs, e.g. "doc-1#0".
+	IdSeparator = "#"
+	// DocumentIdField is the record field holding the ID of the document a chunk belongs to.
+	DocumentIdField = "document_id"
+	// ChunkIndexField is the record field holding the position of a chunk within its document, starting at 0.
+	ChunkIndexField = "chunk_index"
+	// DefaultTextField is the record field holding the chunk text when [Document.TextField] is empty.
+	DefaultTextField = "text"
+)
+
+// deleteBatchSize is the number of IDs deleted per request by [DeleteDocument].
+const deleteBatchSize = 1000
+
+// [Document] is a document to be split into records with [ToRecords].
+//
+// Fields:
+//   - Id: (Required) The document ID. Record IDs are "<Id>#<chunk number>", so Id cannot contain "#".
+//   - Text: (Required) The document text.
+//   - Metadata: (Optional) Fields copied to every record, e.g. the document title or source URL.
+//   - TextField: (Optional) The record field holding the chunk text. It must match the field mapped in
+//     [pinecone.CreateIndexForModelEmbed.FieldMap] for the index. Defaults to "text".
+type Document struct {
+	Id        string
+	Text      string
+	Metadata  map[string]any
+	TextField string
+}
+
+// [ChunkId] returns the ID of the chunk at index within the document documentId.
+func ChunkId(documentId string, index int) string {
+	return fmt.Sprintf("%s%s%d", documentId, IdSeparator, index)
+}
+
+// [ToRecords] splits doc with splitter and returns one [pinecone.IntegratedRecord] per chunk, in order. Each
+// record has the "_id" [ChunkId], the chunk text in the text field, the document ID in [DocumentIdField], the
+// chunk's position in [ChunkIndexField], and the document metadata. Upserting the records of a document again
+// overwrites them, but if the new text has fewer chunks the old trailing chunks remain; call [DeleteDocument]
+// first to replace a document.
+//
+// Example:
+//
+//	    records, err := chunking.ToRecords(chunking.Document{
+//		       Id:       "handbook",
+//		       Text:     handbookText,
+//		       Metadata: map[string]any{"source": "https://example.com/handbook"},
+//	    }, chunking.RecursiveSeparator{Size: 1000, Overlap: 100})
+//	    if err != nil {
+//		       log.Fatalf("Failed to chunk document: %v", err)
+//	    }
+//
+//	    err = idxConnection.UpsertRecords(ctx, records)
+//	    if err != nil {
+//		       log.Fatalf("Failed to upsert records: %v", err)
+//	    }
+func ToRecords(doc Document, splitter Splitter) ([]*pinecone.IntegratedRecord, error) {
+	if err := validateDocumentId(doc.Id); err != nil {
+		return nil, err
+	}
+	if splitter == nil {
+		return nil, fmt.Errorf("splitter cannot be nil")
+	}
+	textField := doc.TextField
+	if textField == "" {
+		textField = DefaultTextField
+	}
+	for _, reserved := range []string{"_id", "id", textField, DocumentIdField, ChunkIndexField} {
+		if _, ok := doc.Metadata[reserved]; ok {
+			return nil, fmt.Errorf("metadata cannot contain the reserved field %q", reserved)
+		}
+	}
+
+	chunks, err := splitter.Split(doc.Text)
+	if err != nil {
+		return nil, err
+	}
+
+	records := make([]*pinecone.IntegratedRecord, len(chunks))
+	for i, chunk := range chunks {
+		record := pinecone.IntegratedRecord{
+			"_id":           ChunkId(doc.Id, i),
+			textField:       chunk,
+			DocumentIdField: doc.Id,
+			ChunkIndexField: i,
+		}
+		for key, value := range doc.Metadata {
+			record[key] = value
+		}
+		records[i] = &record
+	}
+	return records, nil
+}
+
+// [DeleteDocument] deletes every record created by [ToRecords] for the document documentId, in the namespace
+// targeted by idx. It lists the record IDs with the prefix "<documentId>#" through
+// [pinecone.IndexConnection.ListVectors] and deletes them in batches. ListVectors is only supported by
+// serverless indexes.
+//
+// Returns the number of records deleted, or an error. If an error occurs part way, some records may already
+// have been deleted; calling DeleteDocument again deletes the rest.
+//
+// Example:
+//
+//	    deleted, err := chunking.DeleteDocument(ctx, idxConnection, "handbook")
+//	    if err != nil {
+//		       log.Fatalf("Failed to delete document: %v", err)
+//	    }
+//	    fmt.Printf("Deleted %d chunks", deleted)
+func DeleteDocument(ctx context.Context, idx *pinecone.IndexConnection, documentId string, opts ...pinecone.CallOption) (int, error) {
+	if err := validateDocumentId(documentId); err != nil {
+		return 0, err
+	}
+	if idx == nil {
+		return 0, fmt.Errorf("idx (*pinecone.IndexConnection) cannot be nil")
+	}
+
+	// Collect every ID before deleting, so deletes don't shift the pages being listed.
+	prefix := documentId + IdSeparator
+	var ids []string
+	var paginationToken *string
+	for {
+		res, err := idx.ListVectors(ctx, &pinecone.ListVectorsRequest{Prefix: &prefix, PaginationToken: paginationToken}, opts...)
+		if err != nil {
+			return 0, fmt.Errorf("failed to list chunks of document %q: %w", documentId, err)
+		}
+		for _, id := range res.VectorIds {
+			if id != nil {
+				ids = append(ids, *id)
+			}
+		}
+		if res.NextPaginationToken == nil || *res.NextPaginationToken == "" {
+			break
+		}
+		paginationToken = res.NextPaginationToken
+	}
+
+	deleted := 0
+	for start := 0; start < len(ids); start += deleteBatchSize {
+		batch := ids[start:min(start+deleteBatchSize, len(ids))]
+		if err := idx.DeleteVectorsById(ctx, batch, opts...); err != nil {
+			return deleted, fmt.Errorf("failed to delete chunks of document %q: %w", documentId, err)
+		}
+		deleted += len(batch)
+	}
+	return deleted, nil
+}
+
+func validateDocumentId(id string) error {
+	if id == "" {
+		return fmt.Errorf("document ID cannot be empty")
+	}
+	if strings.Contains(id, IdSeparator) {
+		return fmt.Errorf("document ID %q cannot contain %q", id, IdSeparator)
+	}
+	return nil
+}
@@ -0,0 +1,140 @@
+package chunking
+
+import (
+	"context"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	db_data_grpc "github.com/pinecone-io/go-pinecone/v6/internal/gen/db_data/grpc"
+	"github.com/pinecone-io/go-pinecone/v6/pinecone"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+)
+
+// idVectorService holds record IDs, paginating List two IDs at a time.
+type idVectorService struct {
+	db_data_grpc.UnimplementedVectorServiceServer
+
+	mu  sync.Mutex
+	ids map[string]bool
+}
+
+func (s *idVectorService) List(_ context.Context, req *db_data_grpc.ListRequest) (*db_data_grpc.ListResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var ids []string
+	for id := range s.ids {
+		if strings.HasPrefix(id, req.GetPrefix()) {
+			ids = append(ids, id)
+		}
+	}
+	sort.Strings(ids)
+
+	start, _ := strconv.Atoi(req.GetPaginationToken())
+	end := min(start+2, len(ids))
+	res := &db_data_grpc.ListResponse{}
+	for _, id := range ids[start:end] {
+		res.Vectors = append(res.Vectors, &db_data_grpc.ListItem{Id: id})
+	}
+	if end < len(ids) {
+		res.Pagination = &db_data_grpc.Pagination{Next: strconv.Itoa(end)}
+	}
+	return res, nil
+}
+
+func (s *idVectorService) Delete(_ context.Context, req *db_data_grpc.DeleteRequest) (*db_data_grpc.DeleteResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, id := range req.Ids {
+		delete(s.ids, id)
+	}
+	return &db_data_grpc.DeleteResponse{}, nil
+}
+
+func newIdIndexConnection(t *testing.T, svc *idVectorService) *pinecone.IndexConnection {
+	t.Helper()
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	srv := grpc.NewServer()
+	db_data_grpc.RegisterVectorServiceServer(srv, svc)
+	go func() { _ = srv.Serve(lis) }()
+	t.Cleanup(srv.Stop)
+
+	pc, err := pinecone.NewClient(pinecone.NewClientParams{ApiKey: "test-key"})
+	require.NoError(t, err)
+	idx, err := pc.Index(pinecone.NewIndexConnParams{Host: "http://" + lis.Addr().String()})
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = idx.Close() })
+	return idx
+}
+
+// Unit tests:
+func TestToRecordsUnit(t *testing.T) {
+	records, err := ToRecords(Document{
+		Id:       "doc-1",
+		Text:     "The cat sat. The dog ran!",
+		Metadata: map[string]any{"source": "pets.txt"},
+	}, Sentence{MaxSize: 15})
+	require.NoError(t, err)
+
+	require.Len(t, records, 2)
+	assert.Equal(t, pinecone.IntegratedRecord{
+		"_id": "doc-1#0", "text": "The cat sat.", "document_id": "doc-1", "chunk_index": 0, "source": "pets.txt",
+	}, *records[0])
+	assert.Equal(t, "doc-1#1", (*records[1])["_id"])
+	assert.Equal(t, "The dog ran!", (*records[1])["text"])
+
+	again, err := ToRecords(Document{Id: "doc-1", Text: "The cat sat. The dog ran!", Metadata: map[string]any{"source": "pets.txt"}}, Sentence{MaxSize: 15})
+	require.NoError(t, err)
+	assert.Equal(t, records, again, "record IDs should be deterministic")
+
+	records, err = ToRecords(Document{Id: "doc-2", Text: "hello", TextField: "chunk_text"}, FixedSize{Size: 10})
+	require.NoError(t, err)
+	assert.Equal(t, "hello", (*records[0])["chunk_text"])
+}
+
+func TestToRecordsValidationUnit(t *testing.T) {
+	_, err := ToRecords(Document{Text: "hello"}, FixedSize{Size: 10})
+	require.ErrorContains(t, err, "cannot be empty")
+
+	_, err = ToRecords(Document{Id: "a#b", Text: "hello"}, FixedSize{Size: 10})
+	require.ErrorContains(t, err, "cannot contain")
+
+	_, err = ToRecords(Document{Id: "a", Text: "hello"}, nil)
+	require.ErrorContains(t, err, "splitter")
+
+	_, err = ToRecords(Document{Id: "a", Text: "hello", Metadata: map[string]any{"text": "x"}}, FixedSize{Size: 10})
+	require.ErrorContains(t, err, `reserved field "text"`)
+
+	_, err = ToRecords(Document{Id: "a", Text: "hello"}, FixedSize{Size: 0})
+	require.Error(t, err)
+}
+
+func TestDeleteDocumentUnit(t *testing.T) {
+	svc := &idVectorService{ids: map[string]bool{}}
+	for i := 0; i < 5; i++ {
+		svc.ids[ChunkId("doc-1", i)] = true
+	}
+	svc.ids[ChunkId("doc-10", 0)] = true
+	svc.ids["doc-1"] = true
+	idx := newIdIndexConnection(t, svc)
+
+	deleted, err := DeleteDocument(context.Background(), idx, "doc-1")
+	require.NoError(t, err)
+	assert.Equal(t, 5, deleted)
+	assert.Equal(t, map[string]bool{"doc-10#0": true, "doc-1": true}, svc.ids, "other documents should be untouched")
+
+	deleted, err = DeleteDocument(context.Background(), idx, "doc-1")
+	require.NoError(t, err)
+	assert.Equal(t, 0, deleted)
+
+	_, err = DeleteDocument(context.Background(), idx, "doc#1")
+	require.Error(t, err)
+	_, err = DeleteDocument(context.Background(), nil, "doc-1")
+	require.Error(t, err)
+}
@@ -0,0 +1,209 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+
+	"github.com/pinecone-io/go-pinecone/v6/pinecone"
+)
+
+func adminCommand() *command {
+	return &command{name: "admin", summary: "Manage projects, API keys, and service accounts (needs a service account)", commands: []*command{
+		{name: "projects", summary: "List, create, and delete projects", commands: []*command{
+			{name: "list", summary: "List the projects of the organization", setup: noFlags(runProjectList)},
+			{name: "create", args: "<name>", summary: "Create a project", setup: projectCreate},
+			{name: "delete", args: "<project-id>", summary: "Delete a project", setup: noFlags(runProjectDelete)},
+		}},
+		{name: "keys", summary: "List, create, and delete API keys", commands: []*command{
+			{name: "list", args: "<project-id>", summary: "List the API keys of a project", setup: noFlags(runKeyList)},
+			{name: "create", args: "<project-id> <name>", summary: "Create an API key and print its secret value", setup: keyCreate},
+			{name: "delete", args: "<key-id>", summary: "Delete an API key", setup: noFlags(runKeyDelete)},
+		}},
+		{name: "service-accounts", summary: "List, create, and delete service accounts", commands: []*command{
+			{name: "list", summary: "List the service accounts of the organization", setup: noFlags(runServiceAccountList)},
+			{name: "create", args: "<name>", summary: "Create a service account and print its client secret", setup: noFlags(runServiceAccountCreate)},
+			{name: "delete", args: "<service-account-id>", summary: "Delete a service account", setup: noFlags(runServiceAccountDelete)},
+		}},
+	}}
+}
+
+func runProjectList(ctx context.Context, a *app, args []string) error {
+	if err := a.requireArgs(args, 0, 0); err != nil {
+		return err
+	}
+	admin, err := a.adminClient()
+	if err != nil {
+		return err
+	}
+	projects, err := admin.Project.List(ctx)
+	if err != nil {
+		return err
+	}
+	t := &table{headers: []string{"ID", "NAME", "MAX PODS", "CMEK", "CREATED"}}
+	for _, project := range projects {
+		t.add(project.Id, project.Name, project.MaxPods, project.ForceEncryptionWithCmek, project.CreatedAt)
+	}
+	return a.render(projects, t)
+}
+
+func projectCreate(fs *flag.FlagSet) runFunc {
+	maxPods := fs.Int("max-pods", 0, "the maximum number of pods in the project")
+	cmek := fs.Bool("force-encryption-with-cmek", false, "encrypt the project's indexes with customer-managed keys")
+	return func(ctx context.Context, a *app, args []string) error {
+		if err := a.requireArgs(args, 1, 1); err != nil {
+			return err
+		}
+		admin, err := a.adminClient()
+		if err != nil {
+			return err
+		}
+		project, err := admin.Project.Create(ctx, &pinecone.CreateProjectParams{Name: args[0], MaxPods: optional(*maxPods), ForceEncryptionWithCmek: optional(*cmek)})
+		if err != nil {
+			return err
+		}
+		return a.renderFields(project, "id", project.Id, "name", project.Name, "max_pods", project.MaxPods, "force_encryption_with_cmek", project.ForceEncryptionWithCmek)
+	}
+}
+
+func runProjectDelete(ctx context.Context, a *app, args []string) error {
+	if err := a.requireArgs(args, 1, 1); err != nil {
+		return err
+	}
+	admin, err := a.adminClient()
+	if err != nil {
+		return err
+	}
+	if err := admin.Project.Delete(ctx, args[0]); err != nil {
+		return err
+	}
+	fmt.Fprintf(a.stderr, "Deleted project %s\n", args[0])
+	return nil
+}
+
+func runKeyList(ctx context.Context, a *app, args []string) error {
+	if err := a.requireArgs(args, 1, 1); err != nil {
+		return err
+	}
+	admin, err := a.adminClient()
+	if err != nil {
+		return err
+	}
+	keys, err := admin.APIKey.List(ctx, args[0])
+	if err != nil {
+		return err
+	}
+	t := &table{headers: []string{"ID", "NAME", "ROLES"}}
+	for _, key := range keys {
+		t.add(key.Id, key.Name, key.Roles)
+	}
+	return a.render(keys, t)
+}
+
+func keyCreate(fs *flag.FlagSet) runFunc {
+	roles := &stringsFlag{}
+	fs.Var(roles, "role", "a role of the key, e.g. ProjectEditor; may be repeated")
+	return func(ctx context.Context, a *app, args []string) error {
+		if err := a.requireArgs(args, 2, 2); err != nil {
+			return err
+		}
+		admin, err := a.adminClient()
+		if err != nil {
+			return err
+		}
+		params := &pinecone.CreateAPIKeyParams{Name: args[1]}
+		if len(*roles) > 0 {
+			params.Roles = (*[]string)(roles)
+		}
+		key, err := admin.APIKey.Create(ctx, args[0], params)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(a.stderr, "Store the key value now; it cannot be retrieved again.")
+		return a.renderFields(key, "id", key.Key.Id, "name", key.Key.Name, "roles", key.Key.Roles, "value", key.Value)
+	}
+}
+
+func runKeyDelete(ctx context.Context, a *app, args []string) error {
+	if err := a.requireArgs(args, 1, 1); err != nil {
+		return err
+	}
+	admin, err := a.adminClient()
+	if err != nil {
+		return err
+	}
+	if err := admin.APIKey.Delete(ctx, args[0]); err != nil {
+		return err
+	}
+	fmt.Fprintf(a.stderr, "Deleted API key %s\n", args[0])
+	return nil
+}
+
+func runServiceAccountList(ctx context.Context, a *app, args []string) error {
+	if err := a.requireArgs(args, 0, 0); err != nil {
+		return err
+	}
+	admin, err := a.adminClient()
+	if err != nil {
+		return err
+	}
+	var accounts []*pinecone.ServiceAccount
+	var token *string
+	for {
+		page, err := admin.ServiceAccount.List(ctx, &pinecone.ListServiceAccountsParams{PaginationToken: token})
+		if err != nil {
+			return err
+		}
+		accounts = append(accounts, page.Data...)
+		if page.Pagination == nil || page.Pagination.Next == "" {
+			break
+		}
+		token = &page.Pagination.Next
+	}
+	t := &table{headers: []string{"ID", "NAME", "CLIENT ID", "CREATED"}}
+	for _, account := range accounts {
+		t.add(account.Id, account.Name, account.ClientId, account.CreatedAt)
+	}
+	return a.render(accounts, t)
+}
+
+func runServiceAccountCreate(ctx context.Context, a *app, args []string) error {
+	if err := a.requireArgs(args, 1, 1); err != nil {
+		return err
+	}
+	admin, err := a.adminClient()
+	if err != nil {
+		return err
+	}
+	account, err := admin.ServiceAccount.Create(ctx, &pinecone.CreateServiceAccountParams{Name: args[0]})
+	if err != nil {
+		return err
+	}
+	fmt.Fprintln(a.stderr, "Store the client secret now; it cannot be retrieved again.")
+	return a.renderFields(account, "id", account.ServiceAccount.Id, "name", account.ServiceAccount.Name, "client_id", account.ServiceAccount.ClientId, "client_secret", account.ClientSecret)
+}
+
+func runServiceAccountDelete(ctx context.Context, a *app, args []string) error {
+	if err := a.requireArgs(args, 1, 1); err != nil {
+		return err
+	}
+	admin, err := a.adminClient()
+	if err != nil {
+		return err
+	}
+	if err := admin.ServiceAccount.Delete(ctx, args[0]); err != nil {
+		return err
+	}
+	fmt.Fprintf(a.stderr, "Deleted service account %s\n", args[0])
+	return nil
+}
+
+// stringsFlag collects a repeated string flag.
+type stringsFlag []string
+
+func (s *stringsFlag) String() string { return fmt.Sprint([]string(*s)) }
+
+func (s *stringsFlag) Set(value string) error {
+	*s = append(*s, value)
+	return nil
+}
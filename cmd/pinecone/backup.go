@@ -0,0 +1,184 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+
+	"github.com/pinecone-io/go-pinecone/v6/pinecone"
+)
+
+func backupCommand() *command {
+	return &command{name: "backup", summary: "Back up serverless indexes and restore them", commands: []*command{
+		{name: "create", args: "<index>", summary: "Back up an index", setup: backupCreate},
+		{name: "list", summary: "List the backups of the project, or of one index", setup: backupList},
+		{name: "describe", args: "<backup-id>", summary: "Describe a backup", setup: noFlags(runBackupDescribe)},
+		{name: "delete", args: "<backup-id>", summary: "Delete a backup", setup: noFlags(runBackupDelete)},
+		{name: "restore", args: "<backup-id> <index>", summary: "Create an index from a backup and wait until it is ready", setup: backupRestore},
+		{name: "restore-status", args: "<restore-job-id>", summary: "Describe a restore job", setup: noFlags(runBackupRestoreStatus)},
+	}}
+}
+
+func backupCreate(fs *flag.FlagSet) runFunc {
+	name := fs.String("name", "", "the name of the backup")
+	description := fs.String("description", "", "the description of the backup")
+	return func(ctx context.Context, a *app, args []string) error {
+		if err := a.requireArgs(args, 1, 1); err != nil {
+			return err
+		}
+		pc, err := a.client()
+		if err != nil {
+			return err
+		}
+		backup, err := pc.CreateBackup(ctx, &pinecone.CreateBackupParams{IndexName: args[0], Name: optional(*name), Description: optional(*description)})
+		if err != nil {
+			return err
+		}
+		return a.renderBackup(backup)
+	}
+}
+
+func backupList(fs *flag.FlagSet) runFunc {
+	indexName := fs.String("index", "", "only list the backups of this index")
+	return func(ctx context.Context, a *app, args []string) error {
+		if err := a.requireArgs(args, 0, 0); err != nil {
+			return err
+		}
+		pc, err := a.client()
+		if err != nil {
+			return err
+		}
+		var backups []*pinecone.Backup
+		var token *string
+		for {
+			page, err := pc.ListBackups(ctx, &pinecone.ListBackupsParams{IndexName: optional(*indexName), PaginationToken: token})
+			if err != nil {
+				return err
+			}
+			backups = append(backups, page.Data...)
+			if page.Pagination == nil || page.Pagination.Next == "" {
+				break
+			}
+			token = &page.Pagination.Next
+		}
+		t := &table{headers: []string{"ID", "NAME", "INDEX", "STATUS", "RECORDS", "CREATED"}}
+		for _, backup := range backups {
+			t.add(backup.BackupId, backup.Name, backup.SourceIndexName, backup.Status, backup.RecordCount, backup.CreatedAt)
+		}
+		return a.render(backups, t)
+	}
+}
+
+func runBackupDescribe(ctx context.Context, a *app, args []string) error {
+	if err := a.requireArgs(args, 1, 1); err != nil {
+		return err
+	}
+	pc, err := a.client()
+	if err != nil {
+		return err
+	}
+	backup, err := pc.DescribeBackup(ctx, args[0])
+	if err != nil {
+		return err
+	}
+	return a.renderBackup(backup)
+}
+
+func (a *app) renderBackup(backup *pinecone.Backup) error {
+	return a.renderFields(backup,
+		"id", backup.BackupId,
+		"name", backup.Name,
+		"description", backup.Description,
+		"index", backup.SourceIndexName,
+		"status", backup.Status,
+		"records", backup.RecordCount,
+		"namespaces", backup.NamespaceCount,
+		"created_at", backup.CreatedAt,
+	)
+}
+
+func runBackupDelete(ctx context.Context, a *app, args []string) error {
+	if err := a.requireArgs(args, 1, 1); err != nil {
+		return err
+	}
+	pc, err := a.client()
+	if err != nil {
+		return err
+	}
+	if err := pc.DeleteBackup(ctx, args[0]); err != nil {
+		return err
+	}
+	fmt.Fprintf(a.stderr, "Deleted backup %s\n", args[0])
+	return nil
+}
+
+func backupRestore(fs *flag.FlagSet) runFunc {
+	noWait := fs.Bool("no-wait", false, "start the restore and print its job ID without waiting")
+	verify := fs.Bool("verify", false, "wait until the index's record and namespace counts match the backup")
+	deletionProtection := fs.String("deletion-protection", "", `"enabled" or "disabled"`)
+	tags := tagFlag{}
+	fs.Var(tags, "tag", "a key=value tag; may be repeated")
+	return func(ctx context.Context, a *app, args []string) error {
+		if err := a.requireArgs(args, 2, 2); err != nil {
+			return err
+		}
+		if *noWait && *verify {
+			return usageError("--verify cannot be used with --no-wait")
+		}
+		pc, err := a.client()
+		if err != nil {
+			return err
+		}
+		if *noWait {
+			res, err := pc.CreateIndexFromBackup(ctx, &pinecone.CreateIndexFromBackupParams{
+				BackupId:           args[0],
+				Name:               args[1],
+				DeletionProtection: optional(pinecone.DeletionProtection(*deletionProtection)),
+				Tags:               tags.tags(),
+			})
+			if err != nil {
+				return err
+			}
+			return a.renderFields(res, "index_id", res.IndexId, "restore_job_id", res.RestoreJobId)
+		}
+
+		restored, err := pc.RestoreIndexAndWait(ctx, &pinecone.RestoreIndexAndWaitParams{
+			BackupId:           args[0],
+			Name:               args[1],
+			DeletionProtection: optional(pinecone.DeletionProtection(*deletionProtection)),
+			Tags:               tags.tags(),
+			VerifyRecordCounts: *verify,
+			Transport:          pinecone.IndexTransport(a.transport),
+			OnProgress: func(job *pinecone.RestoreJob) {
+				fmt.Fprintf(a.stderr, "Restore job %s: %s %s%%\n", job.RestoreJobId, job.Status, cellString(job.PercentComplete))
+			},
+		})
+		if err != nil {
+			return err
+		}
+		return a.renderIndex(restored.Index)
+	}
+}
+
+func runBackupRestoreStatus(ctx context.Context, a *app, args []string) error {
+	if err := a.requireArgs(args, 1, 1); err != nil {
+		return err
+	}
+	pc, err := a.client()
+	if err != nil {
+		return err
+	}
+	job, err := pc.DescribeRestoreJob(ctx, args[0])
+	if err != nil {
+		return err
+	}
+	return a.renderFields(job,
+		"id", job.RestoreJobId,
+		"backup_id", job.BackupId,
+		"index", job.TargetIndexName,
+		"status", job.Status,
+		"percent_complete", job.PercentComplete,
+		"created_at", job.CreatedAt,
+		"completed_at", job.CompletedAt,
+	)
+}
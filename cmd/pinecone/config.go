@@ -0,0 +1,164 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/pinecone-io/go-pinecone/v6/pinecone"
+	"gopkg.in/yaml.v3"
+)
+
+// config is the config file, by default $XDG_CONFIG_HOME/pinecone/config.yaml (see [os.UserConfigDir]):
+//
+//	default_profile: dev
+//	profiles:
+//	  dev:
+//	    api_key: pcsk_...
+//	  prod:
+//	    client_id: ...
+//	    client_secret: ...
+//	    project_id: ...
+//
+// Profiles with client_id and client_secret authenticate as a service account. The admin commands always
+// need client_id and client_secret.
+type config struct {
+	DefaultProfile string             `yaml:"default_profile"`
+	Profiles       map[string]profile `yaml:"profiles"`
+}
+
+type profile struct {
+	APIKey       string `yaml:"api_key"`
+	ClientID     string `yaml:"client_id"`
+	ClientSecret string `yaml:"client_secret"`
+	ProjectID    string `yaml:"project_id"`
+	Host         string `yaml:"host"`
+}
+
+func (p profile) hasCredentials() bool {
+	return p.APIKey != "" || p.ClientID != "" || p.ClientSecret != ""
+}
+
+// credentials resolves the credentials of the invocation. A profile chosen with --profile or PINECONE_PROFILE
+// takes precedence over the environment; otherwise the environment takes precedence over the default profile.
+// Credentials are never mixed between the two, so an API key in the environment cannot stand in for a
+// profile's service account.
+func (a *app) credentials() (profile, error) {
+	env := profile{
+		APIKey:       a.getenv("PINECONE_API_KEY"),
+		ClientID:     a.getenv("PINECONE_CLIENT_ID"),
+		ClientSecret: a.getenv("PINECONE_CLIENT_SECRET"),
+		ProjectID:    a.getenv("PINECONE_PROJECT_ID"),
+		Host:         a.getenv("PINECONE_CONTROLLER_HOST"),
+	}
+	name := a.profile
+	if name == "" {
+		name = a.getenv("PINECONE_PROFILE")
+	}
+	explicit := name != ""
+
+	cfg, err := a.loadConfig()
+	if err != nil {
+		return profile{}, err
+	}
+	if !explicit {
+		name = cfg.DefaultProfile
+		if name == "" {
+			name = "default"
+		}
+	}
+	selected, ok := cfg.Profiles[name]
+	if explicit && !ok {
+		return profile{}, fmt.Errorf("profile %q not found in %s", name, a.configPath())
+	}
+
+	primary, secondary := env, selected
+	if explicit {
+		primary, secondary = selected, env
+	}
+	if !primary.hasCredentials() {
+		primary, secondary = secondary, primary
+	}
+	if primary.Host == "" {
+		primary.Host = secondary.Host
+	}
+	return primary, nil
+}
+
+func (a *app) configPath() string {
+	if a.config != "" {
+		return a.config
+	}
+	if path := a.getenv("PINECONE_CONFIG"); path != "" {
+		return path
+	}
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(dir, "pinecone", "config.yaml")
+}
+
+// loadConfig reads the config file. A missing default config file is an empty config.
+func (a *app) loadConfig() (*config, error) {
+	path := a.configPath()
+	cfg := &config{}
+	if path == "" {
+		return cfg, nil
+	}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) && a.config == "" && a.getenv("PINECONE_CONFIG") == "" {
+		return cfg, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config: %w", err)
+	}
+	if err := yaml.Unmarshal(data, cfg); err != nil {
+		return nil, fmt.Errorf("failed to parse config %s: %w", path, err)
+	}
+	return cfg, nil
+}
+
+// client returns a [pinecone.Client] authenticated with the resolved credentials.
+func (a *app) client() (*pinecone.Client, error) {
+	if a.clientParams != nil {
+		return pinecone.NewClientBase(*a.clientParams)
+	}
+	creds, err := a.credentials()
+	if err != nil {
+		return nil, err
+	}
+	// NewClientBase is used rather than NewClient so that the environment cannot override a chosen profile.
+	params := pinecone.NewClientBaseParams{Headers: map[string]string{}, Host: creds.Host, SourceTag: "pinecone_cli"}
+	switch {
+	case creds.APIKey != "":
+		params.Headers["Api-Key"] = creds.APIKey
+	case creds.ClientID != "" && creds.ClientSecret != "":
+		params.TokenSource = pinecone.NewClientCredentialsTokenSource(creds.ClientID, creds.ClientSecret, nil)
+		if creds.ProjectID != "" {
+			params.Headers["X-Project-Id"] = creds.ProjectID
+		}
+	case creds.ClientID != "" || creds.ClientSecret != "":
+		return nil, fmt.Errorf("both a client ID and a client secret are needed to authenticate with a service account")
+	default:
+		return nil, fmt.Errorf("no credentials found: set PINECONE_API_KEY, or PINECONE_CLIENT_ID and PINECONE_CLIENT_SECRET, or add a profile to %s", a.configPath())
+	}
+	return pinecone.NewClientBase(params)
+}
+
+// adminClient returns a [pinecone.AdminClient] authenticated with the resolved service account.
+func (a *app) adminClient() (*pinecone.AdminClient, error) {
+	if a.adminParams != nil {
+		return pinecone.NewAdminClient(*a.adminParams)
+	}
+	creds, err := a.credentials()
+	if err != nil {
+		return nil, err
+	}
+	if creds.ClientID == "" || creds.ClientSecret == "" {
+		return nil, fmt.Errorf("admin commands need a service account: set PINECONE_CLIENT_ID and PINECONE_CLIENT_SECRET, or use a profile with client_id and client_secret")
+	}
+	return pinecone.NewAdminClient(pinecone.NewAdminClientParams{ClientId: creds.ClientID, ClientSecret: creds.ClientSecret, Host: creds.Host})
+}
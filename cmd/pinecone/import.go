@@ -0,0 +1,112 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+
+	"github.com/pinecone-io/go-pinecone/v6/pinecone"
+)
+
+func importCommand() *command {
+	return &command{name: "import", summary: "Start, check, and cancel bulk imports from object storage", commands: []*command{
+		{name: "start", args: "<index> <uri>", summary: "Start importing Parquet files from object storage into an index", setup: importStart},
+		{name: "status", args: "<index> [import-id]", summary: "Describe an import, or list the imports of an index", setup: noFlags(runImportStatus)},
+		{name: "cancel", args: "<index> <import-id>", summary: "Cancel an import", setup: noFlags(runImportCancel)},
+	}}
+}
+
+func importStart(fs *flag.FlagSet) runFunc {
+	integrationId := fs.String("integration-id", "", "the storage integration used to read the files")
+	errorMode := fs.String("error-mode", "", `"continue" to skip invalid records, or "abort" (default) to stop`)
+	return func(ctx context.Context, a *app, args []string) error {
+		if err := a.requireArgs(args, 2, 2); err != nil {
+			return err
+		}
+		pc, err := a.client()
+		if err != nil {
+			return err
+		}
+		conn, err := a.indexConnection(ctx, pc, args[0], "")
+		if err != nil {
+			return err
+		}
+		defer conn.Close()
+		res, err := conn.StartImport(ctx, args[1], optional(*integrationId), optional(*errorMode))
+		if err != nil {
+			return err
+		}
+		return a.renderFields(res, "id", res.Id)
+	}
+}
+
+func runImportStatus(ctx context.Context, a *app, args []string) error {
+	if err := a.requireArgs(args, 1, 2); err != nil {
+		return err
+	}
+	pc, err := a.client()
+	if err != nil {
+		return err
+	}
+	conn, err := a.indexConnection(ctx, pc, args[0], "")
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	if len(args) == 2 {
+		imp, err := conn.DescribeImport(ctx, args[1])
+		if err != nil {
+			return err
+		}
+		return a.renderFields(imp,
+			"id", imp.Id,
+			"status", imp.Status,
+			"percent_complete", imp.PercentComplete,
+			"records_imported", imp.RecordsImported,
+			"uri", imp.Uri,
+			"created_at", imp.CreatedAt,
+			"finished_at", imp.FinishedAt,
+			"error", imp.Error,
+		)
+	}
+
+	var imports []*pinecone.Import
+	var token *string
+	for {
+		page, err := conn.ListImports(ctx, nil, token)
+		if err != nil {
+			return err
+		}
+		imports = append(imports, page.Imports...)
+		if page.NextPaginationToken == nil || *page.NextPaginationToken == "" {
+			break
+		}
+		token = page.NextPaginationToken
+	}
+	t := &table{headers: []string{"ID", "STATUS", "PERCENT", "RECORDS", "URI", "CREATED"}}
+	for _, imp := range imports {
+		t.add(imp.Id, imp.Status, imp.PercentComplete, imp.RecordsImported, imp.Uri, imp.CreatedAt)
+	}
+	return a.render(imports, t)
+}
+
+func runImportCancel(ctx context.Context, a *app, args []string) error {
+	if err := a.requireArgs(args, 2, 2); err != nil {
+		return err
+	}
+	pc, err := a.client()
+	if err != nil {
+		return err
+	}
+	conn, err := a.indexConnection(ctx, pc, args[0], "")
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	if err := conn.CancelImport(ctx, args[1]); err != nil {
+		return err
+	}
+	fmt.Fprintf(a.stderr, "Canceled import %s\n", args[1])
+	return nil
+}
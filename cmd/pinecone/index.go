@@ -0,0 +1,256 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"strings"
+
+	"github.com/pinecone-io/go-pinecone/v6/pinecone"
+)
+
+func indexCommand() *command {
+	return &command{name: "index", summary: "List, describe, create, configure, and delete indexes", commands: []*command{
+		{name: "list", summary: "List the indexes of the project", setup: noFlags(runIndexList)},
+		{name: "describe", args: "<index>", summary: "Describe an index", setup: noFlags(runIndexDescribe)},
+		{name: "create", args: "<index>", summary: "Create a serverless, pod-based, or integrated index", setup: indexCreate},
+		{name: "configure", args: "<index>", summary: "Change the pod type, replicas, deletion protection, or tags of an index", setup: indexConfigure},
+		{name: "delete", args: "<index>", summary: "Delete an index", setup: noFlags(runIndexDelete)},
+	}}
+}
+
+func runIndexList(ctx context.Context, a *app, args []string) error {
+	if err := a.requireArgs(args, 0, 0); err != nil {
+		return err
+	}
+	pc, err := a.client()
+	if err != nil {
+		return err
+	}
+	indexes, err := pc.ListIndexes(ctx)
+	if err != nil {
+		return err
+	}
+	t := &table{headers: []string{"NAME", "SPEC", "LOCATION", "DIMENSION", "METRIC", "STATUS", "HOST"}}
+	for _, idx := range indexes {
+		spec, location := indexSpec(idx)
+		t.add(idx.Name, spec, location, idx.Dimension, idx.Metric, indexState(idx), idx.Host)
+	}
+	return a.render(indexes, t)
+}
+
+func runIndexDescribe(ctx context.Context, a *app, args []string) error {
+	if err := a.requireArgs(args, 1, 1); err != nil {
+		return err
+	}
+	pc, err := a.client()
+	if err != nil {
+		return err
+	}
+	idx, err := pc.DescribeIndex(ctx, args[0])
+	if err != nil {
+		return err
+	}
+	return a.renderIndex(idx)
+}
+
+func (a *app) renderIndex(idx *pinecone.Index) error {
+	spec, location := indexSpec(idx)
+	var model string
+	if idx.Embed != nil {
+		model = idx.Embed.Model
+	}
+	return a.renderFields(idx,
+		"name", idx.Name,
+		"spec", spec,
+		"location", location,
+		"dimension", idx.Dimension,
+		"metric", idx.Metric,
+		"vector_type", idx.VectorType,
+		"status", indexState(idx),
+		"host", idx.Host,
+		"deletion_protection", idx.DeletionProtection,
+		"model", model,
+		"tags", idx.Tags,
+	)
+}
+
+func indexSpec(idx *pinecone.Index) (kind, location string) {
+	switch {
+	case idx.Spec == nil:
+		return "", ""
+	case idx.Spec.Serverless != nil:
+		return "serverless", fmt.Sprintf("%s/%s", idx.Spec.Serverless.Cloud, idx.Spec.Serverless.Region)
+	case idx.Spec.Pod != nil:
+		return fmt.Sprintf("pod (%s x%d)", idx.Spec.Pod.PodType, idx.Spec.Pod.PodCount), idx.Spec.Pod.Environment
+	case idx.Spec.BYOC != nil:
+		return "byoc", idx.Spec.BYOC.Environment
+	}
+	return "", ""
+}
+
+func indexState(idx *pinecone.Index) string {
+	if idx.Status == nil {
+		return ""
+	}
+	return string(idx.Status.State)
+}
+
+// tagFlag collects repeated --tag key=value flags.
+type tagFlag map[string]string
+
+func (t tagFlag) String() string { return joinTags(t) }
+
+func (t tagFlag) Set(value string) error {
+	key, val, ok := strings.Cut(value, "=")
+	if !ok || key == "" {
+		return fmt.Errorf("tags must be key=value, got %q", value)
+	}
+	t[key] = val
+	return nil
+}
+
+func (t tagFlag) tags() *pinecone.IndexTags {
+	if len(t) == 0 {
+		return nil
+	}
+	tags := pinecone.IndexTags(t)
+	return &tags
+}
+
+func indexCreate(fs *flag.FlagSet) runFunc {
+	dimension := fs.Int("dimension", 0, "the dimension of dense vectors")
+	metric := fs.String("metric", "", `the distance metric: "cosine", "dotproduct", or "euclidean"`)
+	vectorType := fs.String("vector-type", "", `"dense" or "sparse" (serverless only)`)
+	cloud := fs.String("cloud", "aws", "the cloud of a serverless index")
+	region := fs.String("region", "us-east-1", "the region of a serverless index")
+	environment := fs.String("environment", "", "the environment of a pod-based index; creates a pod-based index")
+	podType := fs.String("pod-type", "p1.x1", "the pod type of a pod-based index")
+	replicas := fs.Int("replicas", 1, "the replicas of a pod-based index")
+	shards := fs.Int("shards", 1, "the shards of a pod-based index")
+	sourceCollection := fs.String("source-collection", "", "a collection to create the index from")
+	model := fs.String("model", "", "an embedding model; creates an integrated index")
+	fieldMap := fs.String("field-map", "text", "the record field embedded by --model")
+	deletionProtection := fs.String("deletion-protection", "", `"enabled" or "disabled"`)
+	tags := tagFlag{}
+	fs.Var(tags, "tag", "a key=value tag; may be repeated")
+
+	return func(ctx context.Context, a *app, args []string) error {
+		if err := a.requireArgs(args, 1, 1); err != nil {
+			return err
+		}
+		if *model != "" && *environment != "" {
+			return usageError("--model and --environment cannot be used together")
+		}
+		pc, err := a.client()
+		if err != nil {
+			return err
+		}
+
+		var idx *pinecone.Index
+		switch {
+		case *model != "":
+			idx, err = pc.CreateIndexForModel(ctx, &pinecone.CreateIndexForModelRequest{
+				Name:               args[0],
+				Cloud:              pinecone.Cloud(*cloud),
+				Region:             *region,
+				DeletionProtection: optional(pinecone.DeletionProtection(*deletionProtection)),
+				Embed:              pinecone.CreateIndexForModelEmbed{Model: *model, FieldMap: map[string]any{"text": *fieldMap}, Metric: optional(pinecone.IndexMetric(*metric))},
+				Tags:               tags.tags(),
+			})
+		case *environment != "":
+			idx, err = pc.CreatePodIndex(ctx, &pinecone.CreatePodIndexRequest{
+				Name:               args[0],
+				Dimension:          int32(*dimension),
+				Environment:        *environment,
+				PodType:            *podType,
+				Shards:             int32(*shards),
+				Replicas:           int32(*replicas),
+				Metric:             optional(pinecone.IndexMetric(*metric)),
+				DeletionProtection: optional(pinecone.DeletionProtection(*deletionProtection)),
+				SourceCollection:   optional(*sourceCollection),
+				Tags:               tags.tags(),
+			})
+		default:
+			idx, err = pc.CreateServerlessIndex(ctx, &pinecone.CreateServerlessIndexRequest{
+				Name:               args[0],
+				Cloud:              pinecone.Cloud(*cloud),
+				Region:             *region,
+				Metric:             optional(pinecone.IndexMetric(*metric)),
+				DeletionProtection: optional(pinecone.DeletionProtection(*deletionProtection)),
+				Dimension:          optional(int32(*dimension)),
+				VectorType:         optional(*vectorType),
+				Tags:               tags.tags(),
+				SourceCollection:   optional(*sourceCollection),
+			})
+		}
+		if err != nil {
+			return err
+		}
+		return a.renderIndex(idx)
+	}
+}
+
+// optional returns a pointer to v, or nil if v is the zero value, for optional request fields set by flags.
+func optional[T comparable](v T) *T {
+	var zero T
+	if v == zero {
+		return nil
+	}
+	return &v
+}
+
+func indexConfigure(fs *flag.FlagSet) runFunc {
+	podType := fs.String("pod-type", "", "the new pod type of a pod-based index")
+	replicas := fs.Int("replicas", 0, "the new replicas of a pod-based index")
+	deletionProtection := fs.String("deletion-protection", "", `"enabled" or "disabled"`)
+	tags := tagFlag{}
+	fs.Var(tags, "tag", "a key=value tag to set, or key= to remove; may be repeated")
+
+	return func(ctx context.Context, a *app, args []string) error {
+		if err := a.requireArgs(args, 1, 1); err != nil {
+			return err
+		}
+		if *podType == "" && *replicas == 0 && *deletionProtection == "" && len(tags) == 0 {
+			return usageError("nothing to configure: pass --pod-type, --replicas, --deletion-protection, or --tag")
+		}
+		pc, err := a.client()
+		if err != nil {
+			return err
+		}
+		idx, err := pc.ConfigureIndex(ctx, args[0], pinecone.ConfigureIndexParams{
+			PodType:            *podType,
+			Replicas:           int32(*replicas),
+			DeletionProtection: pinecone.DeletionProtection(*deletionProtection),
+			Tags:               pinecone.IndexTags(tags),
+		})
+		if err != nil {
+			return err
+		}
+		return a.renderIndex(idx)
+	}
+}
+
+func runIndexDelete(ctx context.Context, a *app, args []string) error {
+	if err := a.requireArgs(args, 1, 1); err != nil {
+		return err
+	}
+	pc, err := a.client()
+	if err != nil {
+		return err
+	}
+	if err := pc.DeleteIndex(ctx, args[0]); err != nil {
+		return err
+	}
+	fmt.Fprintf(a.stderr, "Deleted index %s\n", args[0])
+	return nil
+}
+
+// indexConnection connects to the data plane of an index.
+func (a *app) indexConnection(ctx context.Context, pc *pinecone.Client, name, namespace string) (*pinecone.IndexConnection, error) {
+	idx, err := pc.DescribeIndex(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	return pc.Index(pinecone.NewIndexConnParams{Host: idx.Host, Namespace: namespace, Transport: pinecone.IndexTransport(a.transport)})
+}
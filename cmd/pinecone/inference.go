@@ -0,0 +1,164 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/pinecone-io/go-pinecone/v6/pinecone"
+)
+
+func inferenceCommand() *command {
+	return &command{name: "inference", summary: "Embed text and rerank documents with hosted models", commands: []*command{
+		{name: "embed", args: "[text]...", summary: "Embed texts given as arguments, or one per line of --file or stdin", setup: inferenceEmbed},
+		{name: "rerank", args: "[document]...", summary: "Rerank documents given as arguments, or JSONL documents from --file or stdin", setup: inferenceRerank},
+	}}
+}
+
+func inferenceEmbed(fs *flag.FlagSet) runFunc {
+	model := fs.String("model", "llama-text-embed-v2", "the embedding model")
+	inputType := fs.String("input-type", "passage", `"passage" or "query"`)
+	file := fs.String("file", "", "a file with one text per line; - for stdin")
+	return func(ctx context.Context, a *app, args []string) error {
+		texts := args
+		if len(texts) == 0 || *file != "" {
+			if len(texts) > 0 {
+				return usageError("pass texts as arguments or with --file, not both")
+			}
+			in, closeIn, err := a.openInput(*file)
+			if err != nil {
+				return err
+			}
+			defer closeIn()
+			if texts, err = readLines(in); err != nil {
+				return err
+			}
+		}
+		if len(texts) == 0 {
+			return usageError("nothing to embed")
+		}
+		pc, err := a.client()
+		if err != nil {
+			return err
+		}
+		res, err := pc.Inference.Embed(ctx, &pinecone.EmbedRequest{
+			Model:      *model,
+			TextInputs: texts,
+			Parameters: pinecone.EmbedParameters{"input_type": *inputType},
+		})
+		if err != nil {
+			return err
+		}
+		t := &table{headers: []string{"#", "TEXT", "TYPE", "VALUES"}}
+		for i, embedding := range res.Data {
+			switch {
+			case embedding.DenseEmbedding != nil:
+				t.add(i, truncate(texts[i], 40), "dense", valuesSummary(embedding.DenseEmbedding.Values))
+			case embedding.SparseEmbedding != nil:
+				t.add(i, truncate(texts[i], 40), "sparse", fmt.Sprintf("%d non-zero", len(embedding.SparseEmbedding.SparseValues)))
+			}
+		}
+		return a.render(res, t)
+	}
+}
+
+func inferenceRerank(fs *flag.FlagSet) runFunc {
+	model := fs.String("model", "bge-reranker-v2-m3", "the reranking model")
+	query := fs.String("query", "", "the query to rank documents against (required)")
+	file := fs.String("file", "", `a JSONL file of documents, e.g. {"id": "1", "text": "..."}; - for stdin`)
+	rankField := fs.String("rank-field", "text", "the document field to rank on")
+	topN := fs.Int("top-n", 0, "the number of documents to return; default all")
+	return func(ctx context.Context, a *app, args []string) error {
+		if *query == "" {
+			return usageError("--query is required")
+		}
+		var documents []pinecone.Document
+		for _, text := range args {
+			documents = append(documents, pinecone.Document{*rankField: text})
+		}
+		if len(args) == 0 || *file != "" {
+			if len(args) > 0 {
+				return usageError("pass documents as arguments or with --file, not both")
+			}
+			in, closeIn, err := a.openInput(*file)
+			if err != nil {
+				return err
+			}
+			defer closeIn()
+			lines, err := readLines(in)
+			if err != nil {
+				return err
+			}
+			for i, line := range lines {
+				var document pinecone.Document
+				if err := json.Unmarshal([]byte(line), &document); err != nil {
+					return fmt.Errorf("line %d: %w", i+1, err)
+				}
+				documents = append(documents, document)
+			}
+		}
+		if len(documents) == 0 {
+			return usageError("nothing to rerank")
+		}
+
+		pc, err := a.client()
+		if err != nil {
+			return err
+		}
+		returnDocuments := true
+		res, err := pc.Inference.Rerank(ctx, &pinecone.RerankRequest{
+			Model:           *model,
+			Query:           *query,
+			Documents:       documents,
+			RankFields:      &[]string{*rankField},
+			ReturnDocuments: &returnDocuments,
+			TopN:            optional(*topN),
+		})
+		if err != nil {
+			return err
+		}
+		t := &table{headers: []string{"RANK", "INDEX", "SCORE", "DOCUMENT"}}
+		for rank, ranked := range res.Data {
+			text := fmt.Sprint(documents[ranked.Index][*rankField])
+			t.add(rank+1, ranked.Index, ranked.Score, truncate(text, 60))
+		}
+		return a.render(res, t)
+	}
+}
+
+// openInput opens path for reading, or stdin if path is empty or -.
+func (a *app) openInput(path string) (io.Reader, func(), error) {
+	if path == "" || path == "-" {
+		return a.stdin, func() {}, nil
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	return f, func() { f.Close() }, nil
+}
+
+// readLines returns the non-blank lines of r.
+func readLines(r io.Reader) ([]string, error) {
+	var lines []string
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		if line := strings.TrimSpace(scanner.Text()); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return lines, scanner.Err()
+}
+
+func truncate(s string, n int) string {
+	if r := []rune(s); len(r) > n {
+		return string(r[:n-1]) + "…"
+	}
+	return s
+}
@@ -0,0 +1,241 @@
+// Command pinecone is a command-line tool for working with Pinecone indexes, data, backups, inference, and
+// organization administration. It is built on the Go SDK in this module, so it always uses the SDK's types.
+//
+// Usage:
+//
+//	pinecone [flags] <command> [subcommand] [flags] [args]
+//
+// Run "pinecone help" for the list of commands. Every command accepts these flags:
+//
+//	--profile NAME    the config profile to use (env PINECONE_PROFILE)
+//	--config PATH     the config file (env PINECONE_CONFIG)
+//	--output FORMAT   "table" (default) or "json"
+//	--transport NAME  the data plane transport, "grpc" (default) or "rest"
+//	--timeout DUR     a deadline for the whole command, e.g. 30s
+//
+// Credentials come from PINECONE_API_KEY, or PINECONE_CLIENT_ID and PINECONE_CLIENT_SECRET, or a profile in the
+// config file. See config.go for the file format.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/pinecone-io/go-pinecone/v6/internal"
+	"github.com/pinecone-io/go-pinecone/v6/pinecone"
+)
+
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	a := &app{stdin: os.Stdin, stdout: os.Stdout, stderr: os.Stderr, getenv: os.Getenv}
+	if err := a.run(ctx, os.Args[1:]); err != nil {
+		fmt.Fprintf(os.Stderr, "pinecone: %v\n", err)
+		if errors.Is(err, errUsage) {
+			os.Exit(2)
+		}
+		os.Exit(1)
+	}
+}
+
+// errUsage marks errors caused by how a command was invoked.
+var errUsage = errors.New("usage")
+
+func usageError(format string, args ...any) error {
+	return fmt.Errorf("%w: %s", errUsage, fmt.Sprintf(format, args...))
+}
+
+// app holds the state of one invocation. Tests replace its streams, environment, and client parameters.
+type app struct {
+	stdin  io.Reader
+	stdout io.Writer
+	stderr io.Writer
+	getenv func(string) string
+
+	command   string
+	profile   string
+	config    string
+	output    string
+	transport string
+	timeout   time.Duration
+
+	// clientParams, when set, replaces the resolved credentials. Tests use it to point at a fake server.
+	clientParams *pinecone.NewClientBaseParams
+	adminParams  *pinecone.NewAdminClientParams
+}
+
+// command is a node of the command tree. A command either has a setup function or subcommands. setup defines
+// the command's flags on fs and returns the function that runs the command with its positional arguments.
+type command struct {
+	name     string
+	args     string
+	summary  string
+	setup    func(fs *flag.FlagSet) runFunc
+	commands []*command
+}
+
+type runFunc func(ctx context.Context, a *app, args []string) error
+
+// noFlags is the setup of a command without flags of its own.
+func noFlags(run runFunc) func(fs *flag.FlagSet) runFunc {
+	return func(fs *flag.FlagSet) runFunc { return run }
+}
+
+func commands() []*command {
+	return []*command{
+		indexCommand(),
+		namespaceCommand(),
+		vectorCommand(),
+		importCommand(),
+		backupCommand(),
+		inferenceCommand(),
+		adminCommand(),
+		{name: "version", summary: "Print the SDK version the tool is built with", setup: noFlags(func(ctx context.Context, a *app, args []string) error {
+			fmt.Fprintln(a.stdout, internal.Version)
+			return nil
+		})},
+	}
+}
+
+func (a *app) run(ctx context.Context, args []string) error {
+	root := &command{name: "pinecone", commands: commands()}
+	path := []string{root.name}
+	cmd := root
+	var leading []string
+	for len(args) > 0 && cmd.setup == nil {
+		if strings.HasPrefix(args[0], "-") && !isHelpFlag(args[0]) {
+			// Global flags may come before the command; they are parsed with the command's flags. All of them
+			// take a value.
+			leading = append(leading, args[0])
+			if !strings.Contains(args[0], "=") && len(args) > 1 {
+				leading = append(leading, args[1])
+				args = args[1:]
+			}
+			args = args[1:]
+			continue
+		}
+		if args[0] == "help" || isHelpFlag(args[0]) {
+			a.printHelp(cmd, path, args[1:])
+			return nil
+		}
+		next := cmd.find(args[0])
+		if next == nil {
+			break
+		}
+		cmd, path, args = next, append(path, next.name), args[1:]
+	}
+	if cmd.setup == nil {
+		a.printCommands(a.stderr, cmd, path)
+		if cmd == root || len(args) == 0 {
+			return usageError("missing command")
+		}
+		return usageError("unknown command %q", strings.Join(append(path, args[0]), " "))
+	}
+
+	fs := flag.NewFlagSet(strings.Join(path, " "), flag.ContinueOnError)
+	fs.SetOutput(a.stderr)
+	fs.StringVar(&a.profile, "profile", "", "the config profile to use")
+	fs.StringVar(&a.config, "config", "", "the config file")
+	fs.StringVar(&a.output, "output", "table", `the output format, "table" or "json"`)
+	fs.StringVar(&a.transport, "transport", string(pinecone.IndexTransportGRPC), `the data plane transport, "grpc" or "rest"`)
+	fs.DurationVar(&a.timeout, "timeout", 0, "a deadline for the command")
+	run := cmd.setup(fs)
+	fs.Usage = func() {
+		fmt.Fprintf(a.stderr, "Usage: %s [flags] %s\n\n%s\n\nFlags:\n", fs.Name(), cmd.args, cmd.summary)
+		fs.PrintDefaults()
+	}
+	positional, err := parseInterspersed(fs, append(leading, args...))
+	if errors.Is(err, flag.ErrHelp) {
+		return nil
+	}
+	if err != nil {
+		return usageError("%v", err)
+	}
+	if a.output != "table" && a.output != "json" {
+		return usageError(`--output must be "table" or "json"`)
+	}
+	if a.transport != string(pinecone.IndexTransportGRPC) && a.transport != string(pinecone.IndexTransportREST) {
+		return usageError(`--transport must be "grpc" or "rest"`)
+	}
+	if a.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, a.timeout)
+		defer cancel()
+	}
+	a.command = fs.Name()
+	return run(ctx, a, positional)
+}
+
+func (c *command) find(name string) *command {
+	for _, sub := range c.commands {
+		if sub.name == name {
+			return sub
+		}
+	}
+	return nil
+}
+
+// parseInterspersed parses flags that appear before, between, or after positional arguments, which the flag
+// package alone stops at. Arguments after "--" are positional.
+func parseInterspersed(fs *flag.FlagSet, args []string) ([]string, error) {
+	var positional []string
+	for {
+		if err := fs.Parse(args); err != nil {
+			return nil, err
+		}
+		rest := fs.Args()
+		if len(rest) == 0 {
+			return positional, nil
+		}
+		if len(args) > 0 && len(rest) < len(args) && args[len(args)-len(rest)-1] == "--" {
+			return append(positional, rest...), nil
+		}
+		positional = append(positional, rest[0])
+		args = rest[1:]
+	}
+}
+
+func isHelpFlag(arg string) bool {
+	return arg == "-h" || arg == "-help" || arg == "--help"
+}
+
+func (a *app) printHelp(cmd *command, path []string, args []string) {
+	for _, name := range args {
+		next := cmd.find(name)
+		if next == nil {
+			break
+		}
+		cmd, path = next, append(path, next.name)
+	}
+	if cmd.setup != nil {
+		fmt.Fprintf(a.stdout, "Usage: %s [flags] %s\n\n%s\n\nRun \"%s --help\" for its flags.\n", strings.Join(path, " "), cmd.args, cmd.summary, strings.Join(path, " "))
+		return
+	}
+	a.printCommands(a.stdout, cmd, path)
+}
+
+func (a *app) printCommands(out io.Writer, cmd *command, path []string) {
+	fmt.Fprintf(out, "Usage: %s <command> [flags] [args]\n\nCommands:\n", strings.Join(path, " "))
+	w := tabwriter.NewWriter(out, 0, 4, 2, ' ', 0)
+	for _, sub := range cmd.commands {
+		fmt.Fprintf(w, "  %s\t%s\n", sub.name, sub.summary)
+	}
+	w.Flush()
+	fmt.Fprintf(out, "\nRun \"%s help <command>\" for details.\n", strings.Join(path, " "))
+}
+
+// requireArgs checks the number of positional arguments of the command. A negative max allows any number.
+func (a *app) requireArgs(args []string, min, max int) error {
+	if len(args) < min || (max >= 0 && len(args) > max) {
+		return usageError("wrong number of arguments to %s, run \"%s --help\" for usage", a.command, a.command)
+	}
+	return nil
+}
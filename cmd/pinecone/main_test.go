@@ -0,0 +1,280 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/pinecone-io/go-pinecone/v6/pinecone"
+)
+
+// Unit tests:
+func TestCredentialsPrecedenceUnit(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	config := `
+default_profile: dev
+profiles:
+  dev:
+    api_key: dev-key
+  prod:
+    client_id: prod-id
+    client_secret: prod-secret
+    project_id: prod-project
+  hosted:
+    host: https://profile-host
+`
+	if err := os.WriteFile(path, []byte(config), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		profile string
+		env     map[string]string
+		want    profile
+		wantErr string
+	}{
+		{
+			name: "default profile without environment",
+			want: profile{APIKey: "dev-key"},
+		},
+		{
+			name: "environment over default profile",
+			env:  map[string]string{"PINECONE_API_KEY": "env-key"},
+			want: profile{APIKey: "env-key"},
+		},
+		{
+			name:    "flag profile over environment",
+			profile: "prod",
+			env:     map[string]string{"PINECONE_API_KEY": "env-key"},
+			want:    profile{ClientID: "prod-id", ClientSecret: "prod-secret", ProjectID: "prod-project"},
+		},
+		{
+			name: "PINECONE_PROFILE over environment",
+			env:  map[string]string{"PINECONE_PROFILE": "prod", "PINECONE_API_KEY": "env-key"},
+			want: profile{ClientID: "prod-id", ClientSecret: "prod-secret", ProjectID: "prod-project"},
+		},
+		{
+			name:    "profile without credentials falls back to environment",
+			profile: "hosted",
+			env:     map[string]string{"PINECONE_API_KEY": "env-key"},
+			want:    profile{APIKey: "env-key", Host: "https://profile-host"},
+		},
+		{
+			name:    "missing profile",
+			profile: "staging",
+			wantErr: `profile "staging" not found`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := &app{profile: tt.profile, config: path, getenv: func(key string) string { return tt.env[key] }}
+			got, err := a.credentials()
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("expected error containing %q, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("credentials() error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("credentials() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMissingDefaultConfigUnit(t *testing.T) {
+	a := &app{getenv: func(key string) string {
+		if key == "PINECONE_API_KEY" {
+			return "env-key"
+		}
+		return ""
+	}}
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	t.Setenv("HOME", t.TempDir())
+	got, err := a.credentials()
+	if err != nil {
+		t.Fatalf("credentials() error: %v", err)
+	}
+	if got.APIKey != "env-key" {
+		t.Errorf("expected the environment API key, got %+v", got)
+	}
+}
+
+func TestParseInterspersedUnit(t *testing.T) {
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	namespace := fs.String("namespace", "", "")
+	values := fs.Bool("include-values", false, "")
+	got, err := parseInterspersed(fs, []string{"my-index", "--namespace", "ns1", "id-1", "--include-values", "--", "--not-a-flag"})
+	if err != nil {
+		t.Fatalf("parseInterspersed() error: %v", err)
+	}
+	if want := []string{"my-index", "id-1", "--not-a-flag"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("positional = %v, want %v", got, want)
+	}
+	if *namespace != "ns1" || !*values {
+		t.Errorf("flags not parsed: namespace=%q include-values=%v", *namespace, *values)
+	}
+}
+
+func TestReadVectorsUnit(t *testing.T) {
+	input := `{"id": "a", "values": [0.1, 0.2], "metadata": {"genre": "drama"}}
+
+{"id": "b", "sparse_values": {"indices": [1], "values": [0.5]}}
+{"id": "c", "values": [0.3, 0.4]}
+`
+	var batches [][]string
+	err := readVectors(strings.NewReader(input), 2, func(batch []*pinecone.Vector) error {
+		var ids []string
+		for _, v := range batch {
+			ids = append(ids, v.Id)
+		}
+		batches = append(batches, ids)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("readVectors() error: %v", err)
+	}
+	if want := [][]string{{"a", "b"}, {"c"}}; !reflect.DeepEqual(batches, want) {
+		t.Errorf("batches = %v, want %v", batches, want)
+	}
+
+	err = readVectors(strings.NewReader("{\"id\": \"a\", \"values\": [1]}\n{\"id\": \"b\", \"vals\": [1]}\n"), 10, func([]*pinecone.Vector) error { return nil })
+	if err == nil || !strings.Contains(err.Error(), "line 2") {
+		t.Errorf("expected an error on line 2, got %v", err)
+	}
+}
+
+func TestUsageErrorsUnit(t *testing.T) {
+	for _, args := range [][]string{
+		{},
+		{"index", "bogus"},
+		{"index", "describe"},
+		{"index", "list", "--output", "yaml"},
+	} {
+		a := &app{stdout: &bytes.Buffer{}, stderr: &bytes.Buffer{}, getenv: func(string) string { return "" }}
+		if err := a.run(context.Background(), args); !errors.Is(err, errUsage) {
+			t.Errorf("run(%q): expected a usage error, got %v", args, err)
+		}
+	}
+}
+
+// fakeServer serves the control plane and the REST data plane of one index.
+type fakeServer struct {
+	srv *httptest.Server
+
+	mu       sync.Mutex
+	upserted []string
+}
+
+func newFakeServer(t *testing.T) *fakeServer {
+	t.Helper()
+	f := &fakeServer{}
+	writeJSON := func(w http.ResponseWriter, status int, body any) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		json.NewEncoder(w).Encode(body)
+	}
+	describe := func(name string) map[string]any {
+		return map[string]any{"name": name, "host": f.srv.URL, "metric": "cosine", "dimension": 2, "vector_type": "dense",
+			"spec":   map[string]any{"serverless": map[string]any{"cloud": "aws", "region": "us-east-1"}},
+			"status": map[string]any{"ready": true, "state": "Ready"}}
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /indexes", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, map[string]any{"indexes": []any{describe("movies")}})
+	})
+	mux.HandleFunc("GET /indexes/{name}", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, describe(r.PathValue("name")))
+	})
+	mux.HandleFunc("POST /vectors/upsert", func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Vectors []struct {
+				Id string `json:"id"`
+			} `json:"vectors"`
+			Namespace string `json:"namespace"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			writeJSON(w, http.StatusBadRequest, map[string]any{"error": err.Error()})
+			return
+		}
+		f.mu.Lock()
+		for _, v := range body.Vectors {
+			f.upserted = append(f.upserted, body.Namespace+"/"+v.Id)
+		}
+		f.mu.Unlock()
+		writeJSON(w, http.StatusOK, map[string]any{"upsertedCount": len(body.Vectors)})
+	})
+	f.srv = httptest.NewTLSServer(mux)
+	t.Cleanup(f.srv.Close)
+	return f
+}
+
+func (f *fakeServer) app(stdin string) (*app, *bytes.Buffer) {
+	stdout := &bytes.Buffer{}
+	return &app{
+		stdin:        strings.NewReader(stdin),
+		stdout:       stdout,
+		stderr:       &bytes.Buffer{},
+		getenv:       func(string) string { return "" },
+		clientParams: &pinecone.NewClientBaseParams{Headers: map[string]string{"Api-Key": "test-key"}, Host: f.srv.URL, RestClient: f.srv.Client()},
+	}, stdout
+}
+
+func TestIndexListUnit(t *testing.T) {
+	f := newFakeServer(t)
+
+	a, stdout := f.app("")
+	if err := a.run(context.Background(), []string{"index", "list"}); err != nil {
+		t.Fatalf("index list: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(stdout.String()), "\n")
+	if len(lines) != 2 || !strings.HasPrefix(lines[0], "NAME") || !strings.Contains(lines[1], "movies") || !strings.Contains(lines[1], "aws/us-east-1") {
+		t.Errorf("unexpected table output:\n%s", stdout)
+	}
+
+	a, stdout = f.app("")
+	if err := a.run(context.Background(), []string{"--output", "json", "index", "list"}); err != nil {
+		t.Fatalf("index list --output json: %v", err)
+	}
+	var indexes []*pinecone.Index
+	if err := json.Unmarshal(stdout.Bytes(), &indexes); err != nil {
+		t.Fatalf("output is not a JSON list of indexes: %v\n%s", err, stdout)
+	}
+	if len(indexes) != 1 || indexes[0].Name != "movies" || indexes[0].Dimension == nil || *indexes[0].Dimension != 2 {
+		t.Errorf("unexpected indexes: %s", stdout)
+	}
+}
+
+func TestVectorUpsertUnit(t *testing.T) {
+	f := newFakeServer(t)
+	input := `{"id": "a", "values": [0.1, 0.2]}
+{"id": "b", "values": [0.3, 0.4]}
+{"id": "c", "values": [0.5, 0.6]}
+`
+	a, stdout := f.app(input)
+	err := a.run(context.Background(), []string{"vector", "upsert", "movies", "--namespace", "ns1", "--batch-size", "2", "--transport", "rest", "--output", "json"})
+	if err != nil {
+		t.Fatalf("vector upsert: %v", err)
+	}
+	var res map[string]int
+	if err := json.Unmarshal(stdout.Bytes(), &res); err != nil || res["upserted_count"] != 3 {
+		t.Errorf("unexpected output: %s", stdout)
+	}
+	if want := []string{"ns1/a", "ns1/b", "ns1/c"}; !reflect.DeepEqual(f.upserted, want) {
+		t.Errorf("upserted %v, want %v", f.upserted, want)
+	}
+}
@@ -0,0 +1,95 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+
+	"github.com/pinecone-io/go-pinecone/v6/pinecone"
+)
+
+func namespaceCommand() *command {
+	return &command{name: "namespace", summary: "List, create, and delete the namespaces of an index", commands: []*command{
+		{name: "list", args: "<index>", summary: "List the namespaces of an index", setup: namespaceList},
+		{name: "create", args: "<index> <namespace>", summary: "Create a namespace", setup: noFlags(runNamespaceCreate)},
+		{name: "delete", args: "<index> <namespace>", summary: "Delete a namespace and all of its records", setup: noFlags(runNamespaceDelete)},
+	}}
+}
+
+func namespaceList(fs *flag.FlagSet) runFunc {
+	prefix := fs.String("prefix", "", "only list namespaces starting with this prefix")
+	return func(ctx context.Context, a *app, args []string) error {
+		if err := a.requireArgs(args, 1, 1); err != nil {
+			return err
+		}
+		pc, err := a.client()
+		if err != nil {
+			return err
+		}
+		conn, err := a.indexConnection(ctx, pc, args[0], "")
+		if err != nil {
+			return err
+		}
+		defer conn.Close()
+
+		var namespaces []*pinecone.NamespaceDescription
+		var token *string
+		for {
+			page, err := conn.ListNamespaces(ctx, &pinecone.ListNamespacesParams{PaginationToken: token, Prefix: optional(*prefix)})
+			if err != nil {
+				return err
+			}
+			namespaces = append(namespaces, page.Namespaces...)
+			if page.Pagination == nil || page.Pagination.Next == "" {
+				break
+			}
+			token = &page.Pagination.Next
+		}
+
+		t := &table{headers: []string{"NAME", "RECORDS"}}
+		for _, ns := range namespaces {
+			t.add(ns.Name, ns.RecordCount)
+		}
+		return a.render(namespaces, t)
+	}
+}
+
+func runNamespaceCreate(ctx context.Context, a *app, args []string) error {
+	if err := a.requireArgs(args, 2, 2); err != nil {
+		return err
+	}
+	pc, err := a.client()
+	if err != nil {
+		return err
+	}
+	conn, err := a.indexConnection(ctx, pc, args[0], "")
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	ns, err := conn.CreateNamespace(ctx, &pinecone.CreateNamespaceParams{Name: args[1]})
+	if err != nil {
+		return err
+	}
+	return a.renderFields(ns, "name", ns.Name, "records", ns.RecordCount)
+}
+
+func runNamespaceDelete(ctx context.Context, a *app, args []string) error {
+	if err := a.requireArgs(args, 2, 2); err != nil {
+		return err
+	}
+	pc, err := a.client()
+	if err != nil {
+		return err
+	}
+	conn, err := a.indexConnection(ctx, pc, args[0], "")
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	if err := conn.DeleteNamespace(ctx, args[1]); err != nil {
+		return err
+	}
+	fmt.Fprintf(a.stderr, "Deleted namespace %s of index %s\n", args[1], args[0])
+	return nil
+}
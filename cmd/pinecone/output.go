@@ -0,0 +1,161 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/pinecone-io/go-pinecone/v6/pinecone"
+)
+
+// table is the table form of a command's output.
+type table struct {
+	headers []string
+	rows    [][]string
+}
+
+func (t *table) add(cells ...any) {
+	row := make([]string, len(cells))
+	for i, cell := range cells {
+		row[i] = cellString(cell)
+	}
+	t.rows = append(t.rows, row)
+}
+
+// render writes v as indented JSON with --output json, and t as a table otherwise.
+func (a *app) render(v any, t *table) error {
+	if a.output == "json" {
+		enc := json.NewEncoder(a.stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(v)
+	}
+	w := tabwriter.NewWriter(a.stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, strings.Join(t.headers, "\t"))
+	for _, row := range t.rows {
+		fmt.Fprintln(w, strings.Join(row, "\t"))
+	}
+	return w.Flush()
+}
+
+// renderFields writes v as JSON, or as a two-column table of the given fields.
+func (a *app) renderFields(v any, fields ...any) error {
+	t := &table{headers: []string{"FIELD", "VALUE"}}
+	for i := 0; i+1 < len(fields); i += 2 {
+		t.add(fields[i], fields[i+1])
+	}
+	return a.render(v, t)
+}
+
+func cellString(v any) string {
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case *string:
+		if v == nil {
+			return ""
+		}
+		return *v
+	case *int:
+		if v == nil {
+			return ""
+		}
+		return fmt.Sprint(*v)
+	case *int32:
+		if v == nil {
+			return ""
+		}
+		return fmt.Sprint(*v)
+	case *float32:
+		if v == nil {
+			return ""
+		}
+		return fmt.Sprint(*v)
+	case time.Time:
+		if v.IsZero() {
+			return ""
+		}
+		return v.Format(time.RFC3339)
+	case *time.Time:
+		if v == nil {
+			return ""
+		}
+		return cellString(*v)
+	case []string:
+		return strings.Join(v, ",")
+	case map[string]string:
+		return joinTags(v)
+	case *pinecone.IndexTags:
+		if v == nil {
+			return ""
+		}
+		return joinTags(*v)
+	case fmt.Stringer:
+		return v.String()
+	}
+	return fmt.Sprint(v)
+}
+
+func joinTags(tags map[string]string) string {
+	pairs := make([]string, 0, len(tags))
+	for k, v := range tags {
+		pairs = append(pairs, k+"="+v)
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, ",")
+}
+
+// vectorView is the JSON form of a [pinecone.Vector] and the input format of "vector upsert". Metadata is a
+// plain JSON object rather than the protobuf struct the SDK uses.
+type vectorView struct {
+	Id           string                 `json:"id"`
+	Values       []float32              `json:"values,omitempty"`
+	SparseValues *pinecone.SparseValues `json:"sparse_values,omitempty"`
+	Metadata     map[string]any         `json:"metadata,omitempty"`
+	Score        *float32               `json:"score,omitempty"`
+}
+
+func viewOfVector(v *pinecone.Vector) vectorView {
+	if v == nil {
+		return vectorView{}
+	}
+	view := vectorView{Id: v.Id, SparseValues: v.SparseValues}
+	if v.Values != nil {
+		view.Values = *v.Values
+	}
+	if v.Metadata != nil {
+		view.Metadata = v.Metadata.AsMap()
+	}
+	return view
+}
+
+func (v vectorView) vector() (*pinecone.Vector, error) {
+	if v.Id == "" {
+		return nil, fmt.Errorf("vector has no id")
+	}
+	vector := &pinecone.Vector{Id: v.Id, SparseValues: v.SparseValues}
+	if v.Values != nil {
+		values := v.Values
+		vector.Values = &values
+	}
+	if v.Metadata != nil {
+		metadata, err := pinecone.NewMetadata(v.Metadata)
+		if err != nil {
+			return nil, fmt.Errorf("vector %q has invalid metadata: %w", v.Id, err)
+		}
+		vector.Metadata = metadata
+	}
+	return vector, nil
+}
+
+func metadataString(m map[string]any) string {
+	if len(m) == 0 {
+		return ""
+	}
+	b, _ := json.Marshal(m)
+	return string(b)
+}
@@ -0,0 +1,219 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/pinecone-io/go-pinecone/v6/pinecone"
+)
+
+func vectorCommand() *command {
+	return &command{name: "vector", summary: "Fetch, query, and upsert vectors", commands: []*command{
+		{name: "fetch", args: "<index> <id>...", summary: "Fetch vectors by ID", setup: vectorFetch},
+		{name: "query", args: "<index>", summary: "Query an index by vector values or by the ID of a stored vector", setup: vectorQuery},
+		{name: "upsert", args: "<index> [file]", summary: "Upsert vectors from a JSONL file, or from stdin if file is omitted or -.\n" +
+			`Each line is {"id": "...", "values": [...], "sparse_values": {"indices": [...], "values": [...]}, "metadata": {...}}.`, setup: vectorUpsert},
+	}}
+}
+
+func vectorFetch(fs *flag.FlagSet) runFunc {
+	namespace := fs.String("namespace", "", "the namespace to fetch from")
+	return func(ctx context.Context, a *app, args []string) error {
+		if err := a.requireArgs(args, 2, -1); err != nil {
+			return err
+		}
+		pc, err := a.client()
+		if err != nil {
+			return err
+		}
+		conn, err := a.indexConnection(ctx, pc, args[0], *namespace)
+		if err != nil {
+			return err
+		}
+		defer conn.Close()
+		res, err := conn.FetchVectors(ctx, args[1:])
+		if err != nil {
+			return err
+		}
+
+		ids := make([]string, 0, len(res.Vectors))
+		for id := range res.Vectors {
+			ids = append(ids, id)
+		}
+		sort.Strings(ids)
+		views := make([]vectorView, 0, len(ids))
+		t := &table{headers: []string{"ID", "VALUES", "METADATA"}}
+		for _, id := range ids {
+			view := viewOfVector(res.Vectors[id])
+			views = append(views, view)
+			t.add(view.Id, valuesSummary(view.Values), metadataString(view.Metadata))
+		}
+		return a.render(views, t)
+	}
+}
+
+func vectorQuery(fs *flag.FlagSet) runFunc {
+	namespace := fs.String("namespace", "", "the namespace to query")
+	vector := fs.String("vector", "", "the query vector, as a JSON array of numbers")
+	id := fs.String("id", "", "query with the values of the stored vector with this ID")
+	topK := fs.Uint("top-k", 10, "the number of matches to return")
+	filter := fs.String("filter", "", `a metadata filter as a JSON object, e.g. {"genre": {"$eq": "drama"}}`)
+	includeValues := fs.Bool("include-values", false, "include vector values in the matches")
+	includeMetadata := fs.Bool("include-metadata", true, "include metadata in the matches")
+	return func(ctx context.Context, a *app, args []string) error {
+		if err := a.requireArgs(args, 1, 1); err != nil {
+			return err
+		}
+		if (*vector == "") == (*id == "") {
+			return usageError("pass exactly one of --vector and --id")
+		}
+		var metadataFilter *pinecone.MetadataFilter
+		if *filter != "" {
+			var m map[string]any
+			if err := json.Unmarshal([]byte(*filter), &m); err != nil {
+				return usageError("--filter is not a JSON object: %v", err)
+			}
+			var err error
+			if metadataFilter, err = pinecone.NewMetadataFilter(m); err != nil {
+				return usageError("invalid --filter: %v", err)
+			}
+		}
+		var values []float32
+		if *vector != "" {
+			if err := json.Unmarshal([]byte(*vector), &values); err != nil {
+				return usageError("--vector is not a JSON array of numbers: %v", err)
+			}
+		}
+
+		pc, err := a.client()
+		if err != nil {
+			return err
+		}
+		conn, err := a.indexConnection(ctx, pc, args[0], *namespace)
+		if err != nil {
+			return err
+		}
+		defer conn.Close()
+		var res *pinecone.QueryVectorsResponse
+		if *id != "" {
+			res, err = conn.QueryByVectorId(ctx, &pinecone.QueryByVectorIdRequest{VectorId: *id, TopK: uint32(*topK), MetadataFilter: metadataFilter, IncludeValues: *includeValues, IncludeMetadata: *includeMetadata})
+		} else {
+			res, err = conn.QueryByVectorValues(ctx, &pinecone.QueryByVectorValuesRequest{Vector: values, TopK: uint32(*topK), MetadataFilter: metadataFilter, IncludeValues: *includeValues, IncludeMetadata: *includeMetadata})
+		}
+		if err != nil {
+			return err
+		}
+
+		views := make([]vectorView, 0, len(res.Matches))
+		t := &table{headers: []string{"ID", "SCORE", "METADATA"}}
+		for _, match := range res.Matches {
+			view := viewOfVector(match.Vector)
+			score := match.Score
+			view.Score = &score
+			views = append(views, view)
+			t.add(view.Id, score, metadataString(view.Metadata))
+		}
+		return a.render(views, t)
+	}
+}
+
+func vectorUpsert(fs *flag.FlagSet) runFunc {
+	namespace := fs.String("namespace", "", "the namespace to upsert into")
+	batchSize := fs.Int("batch-size", 100, "the number of vectors per upsert request")
+	return func(ctx context.Context, a *app, args []string) error {
+		if err := a.requireArgs(args, 1, 2); err != nil {
+			return err
+		}
+		if *batchSize <= 0 {
+			return usageError("--batch-size must be positive")
+		}
+		var path string
+		if len(args) == 2 {
+			path = args[1]
+		}
+		in, closeIn, err := a.openInput(path)
+		if err != nil {
+			return err
+		}
+		defer closeIn()
+
+		pc, err := a.client()
+		if err != nil {
+			return err
+		}
+		conn, err := a.indexConnection(ctx, pc, args[0], *namespace)
+		if err != nil {
+			return err
+		}
+		defer conn.Close()
+
+		var upserted int
+		err = readVectors(in, *batchSize, func(batch []*pinecone.Vector) error {
+			count, err := conn.UpsertVectors(ctx, batch)
+			upserted += int(count)
+			return err
+		})
+		if err != nil {
+			return fmt.Errorf("upserted %d vectors before failing: %w", upserted, err)
+		}
+		result := map[string]int{"upserted_count": upserted}
+		return a.renderFields(result, "upserted_count", upserted)
+	}
+}
+
+// readVectors decodes JSONL vectors from r and passes them to upsert in batches. Blank lines are skipped.
+func readVectors(r io.Reader, batchSize int, upsert func([]*pinecone.Vector) error) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	var batch []*pinecone.Vector
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" {
+			continue
+		}
+		var view vectorView
+		dec := json.NewDecoder(strings.NewReader(text))
+		dec.DisallowUnknownFields()
+		if err := dec.Decode(&view); err != nil {
+			return fmt.Errorf("line %d: %w", line, err)
+		}
+		vector, err := view.vector()
+		if err != nil {
+			return fmt.Errorf("line %d: %w", line, err)
+		}
+		if batch = append(batch, vector); len(batch) == batchSize {
+			if err := upsert(batch); err != nil {
+				return err
+			}
+			batch = nil
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+	if len(batch) > 0 {
+		return upsert(batch)
+	}
+	return nil
+}
+
+// valuesSummary abbreviates vector values for tables.
+func valuesSummary(values []float32) string {
+	if len(values) == 0 {
+		return ""
+	}
+	parts := make([]string, 0, 3)
+	for _, v := range values[:min(3, len(values))] {
+		parts = append(parts, fmt.Sprint(v))
+	}
+	if len(values) > 3 {
+		parts = append(parts, fmt.Sprintf("... (%d)", len(values)))
+	}
+	return "[" + strings.Join(parts, ", ") + "]"
+}
@@ -0,0 +1,26 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/google/uuid"
+)
+
+type RequestIdHeader struct {
+	name string
+}
+
+// NewRequestIdProvider returns a request editor that stamps each outgoing request with a freshly generated
+// UUID under the given header name. A value that is already present on the request is left untouched, so
+// callers can supply their own ID.
+func NewRequestIdProvider(name string) *RequestIdHeader {
+	return &RequestIdHeader{name: name}
+}
+
+func (h *RequestIdHeader) Intercept(ctx context.Context, req *http.Request) error {
+	if req.Header.Get(h.name) == "" {
+		req.Header.Set(h.name, uuid.NewString())
+	}
+	return nil
+}
@@ -0,0 +1,56 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestRequestIdIntercept(t *testing.T) {
+	name := "X-Request-Id"
+	header := NewRequestIdProvider(name)
+
+	first, err := http.NewRequest("GET", "https://example.com", nil)
+	if err != nil {
+		t.Fatalf("Failed to create HTTP request: %v", err)
+	}
+	second, err := http.NewRequest("GET", "https://example.com", nil)
+	if err != nil {
+		t.Fatalf("Failed to create HTTP request: %v", err)
+	}
+
+	ctx := context.Background()
+	if err := header.Intercept(ctx, first); err != nil {
+		t.Errorf("Intercept failed: %v", err)
+	}
+	if err := header.Intercept(ctx, second); err != nil {
+		t.Errorf("Intercept failed: %v", err)
+	}
+
+	if _, err := uuid.Parse(first.Header.Get(name)); err != nil {
+		t.Errorf("Expected header '%s' to hold a UUID, got '%s'", name, first.Header.Get(name))
+	}
+	if first.Header.Get(name) == second.Header.Get(name) {
+		t.Errorf("Expected each request to receive a distinct ID, both got '%s'", first.Header.Get(name))
+	}
+}
+
+func TestRequestIdInterceptPreservesExisting(t *testing.T) {
+	name := "X-Request-Id"
+	header := NewRequestIdProvider(name)
+
+	req, err := http.NewRequest("GET", "https://example.com", nil)
+	if err != nil {
+		t.Fatalf("Failed to create HTTP request: %v", err)
+	}
+	req.Header.Set(name, "caller-supplied")
+
+	if err := header.Intercept(context.Background(), req); err != nil {
+		t.Errorf("Intercept failed: %v", err)
+	}
+	if req.Header.Get(name) != "caller-supplied" {
+		t.Errorf("Expected existing header value to be preserved, got '%s'", req.Header.Get(name))
+	}
+}
@@ -0,0 +1,200 @@
+package localindex
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/pinecone-io/go-pinecone/v6/pinecone"
+)
+
+// predicate reports whether the metadata of a vector, as returned by structpb.Struct.AsMap, matches a filter.
+type predicate func(metadata map[string]any) bool
+
+// compileFilter turns a [pinecone.MetadataFilter] into a predicate, returning an error for an invalid filter.
+// A nil filter matches every vector.
+func compileFilter(filter *pinecone.MetadataFilter) (predicate, error) {
+	if filter == nil {
+		return func(map[string]any) bool { return true }, nil
+	}
+	return compileExpression(filter.AsMap())
+}
+
+func compileExpression(expr map[string]any) (predicate, error) {
+	// Sort the keys so the first error reported for an invalid filter is deterministic.
+	keys := make([]string, 0, len(expr))
+	for key := range expr {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	predicates := make([]predicate, 0, len(expr))
+	for _, key := range keys {
+		var p predicate
+		var err error
+		switch key {
+		case "$and", "$or":
+			p, err = compileLogical(key, expr[key])
+		default:
+			if len(key) > 0 && key[0] == '$' {
+				return nil, fmt.Errorf("unsupported filter operator %q", key)
+			}
+			p, err = compileField(key, expr[key])
+		}
+		if err != nil {
+			return nil, err
+		}
+		predicates = append(predicates, p)
+	}
+	return all(predicates), nil
+}
+
+func compileLogical(op string, operand any) (predicate, error) {
+	items, ok := operand.([]any)
+	if !ok || len(items) == 0 {
+		return nil, fmt.Errorf("%s requires a non-empty list of filters", op)
+	}
+	predicates := make([]predicate, len(items))
+	for n, item := range items {
+		expr, ok := item.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("%s requires a list of filters, got %T", op, item)
+		}
+		p, err := compileExpression(expr)
+		if err != nil {
+			return nil, err
+		}
+		predicates[n] = p
+	}
+	if op == "$and" {
+		return all(predicates), nil
+	}
+	return func(metadata map[string]any) bool {
+		for _, p := range predicates {
+			if p(metadata) {
+				return true
+			}
+		}
+		return false
+	}, nil
+}
+
+// compileField compiles the condition on a single metadata field. A condition that is not an object of
+// operators is an implicit $eq, and several operators must all match.
+func compileField(field string, condition any) (predicate, error) {
+	ops, ok := condition.(map[string]any)
+	if !ok {
+		ops = map[string]any{"$eq": condition}
+	}
+	predicates := make([]predicate, 0, len(ops))
+	for op, operand := range ops {
+		p, err := compileOperator(field, op, operand)
+		if err != nil {
+			return nil, err
+		}
+		predicates = append(predicates, p)
+	}
+	return all(predicates), nil
+}
+
+func compileOperator(field string, op string, operand any) (predicate, error) {
+	switch op {
+	case "$eq", "$ne":
+		if !isScalar(operand) {
+			return nil, fmt.Errorf("%s on field %q requires a string, number, or boolean, got %T", op, field, operand)
+		}
+		want := op == "$eq"
+		return func(metadata map[string]any) bool {
+			value, ok := metadata[field]
+			if !ok {
+				return !want
+			}
+			return contains(value, operand) == want
+		}, nil
+	case "$in", "$nin":
+		list, ok := operand.([]any)
+		if !ok {
+			return nil, fmt.Errorf("%s on field %q requires a list, got %T", op, field, operand)
+		}
+		for _, item := range list {
+			if !isScalar(item) {
+				return nil, fmt.Errorf("%s on field %q requires a list of strings, numbers, or booleans", op, field)
+			}
+		}
+		want := op == "$in"
+		return func(metadata map[string]any) bool {
+			value, ok := metadata[field]
+			if !ok {
+				return !want
+			}
+			for _, item := range list {
+				if contains(value, item) {
+					return want
+				}
+			}
+			return !want
+		}, nil
+	case "$gt", "$gte", "$lt", "$lte":
+		bound, ok := operand.(float64)
+		if !ok {
+			return nil, fmt.Errorf("%s on field %q requires a number, got %T", op, field, operand)
+		}
+		return func(metadata map[string]any) bool {
+			value, ok := metadata[field].(float64)
+			if !ok {
+				return false
+			}
+			switch op {
+			case "$gt":
+				return value > bound
+			case "$gte":
+				return value >= bound
+			case "$lt":
+				return value < bound
+			default:
+				return value <= bound
+			}
+		}, nil
+	case "$exists":
+		want, ok := operand.(bool)
+		if !ok {
+			return nil, fmt.Errorf("$exists on field %q requires a boolean, got %T", field, operand)
+		}
+		return func(metadata map[string]any) bool {
+			_, ok := metadata[field]
+			return ok == want
+		}, nil
+	}
+	return nil, fmt.Errorf("unsupported filter operator %q on field %q", op, field)
+}
+
+func all(predicates []predicate) predicate {
+	return func(metadata map[string]any) bool {
+		for _, p := range predicates {
+			if !p(metadata) {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+func isScalar(value any) bool {
+	switch value.(type) {
+	case string, float64, bool:
+		return true
+	}
+	return false
+}
+
+// contains reports whether value equals want or, for a list value, whether any element equals want.
+func contains(value any, want any) bool {
+	if list, ok := value.([]any); ok {
+		for _, item := range list {
+			if item == want {
+				return true
+			}
+		}
+		return false
+	}
+	return value == want
+}
@@ -0,0 +1,59 @@
+package localindex
+
+import (
+	"testing"
+
+	"github.com/pinecone-io/go-pinecone/v6/pinecone"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Unit tests:
+func TestCompileFilterUnit(t *testing.T) {
+	metadata := map[string]any{"genre": "drama", "year": 2020.0, "tags": []any{"a", "b"}, "award": true}
+
+	tests := []struct {
+		filter map[string]any
+		want   bool
+	}{
+		{map[string]any{"genre": "drama"}, true},
+		{map[string]any{"genre": map[string]any{"$ne": "drama"}}, false},
+		{map[string]any{"genre": map[string]any{"$in": []any{"comedy", "drama"}}}, true},
+		{map[string]any{"genre": map[string]any{"$nin": []any{"comedy", "drama"}}}, false},
+		{map[string]any{"year": map[string]any{"$gte": 2020, "$lt": 2021}}, true},
+		{map[string]any{"year": map[string]any{"$gt": 2020}}, false},
+		{map[string]any{"year": map[string]any{"$lte": 2019}}, false},
+		{map[string]any{"tags": "b"}, true},
+		{map[string]any{"tags": map[string]any{"$nin": []any{"c"}}}, true},
+		{map[string]any{"award": true}, true},
+		{map[string]any{"missing": map[string]any{"$exists": false}}, true},
+		{map[string]any{"missing": map[string]any{"$ne": "x"}}, true},
+		{map[string]any{"missing": "x"}, false},
+		{map[string]any{"$or": []any{map[string]any{"genre": "comedy"}, map[string]any{"year": 2020}}}, true},
+		{map[string]any{"$and": []any{map[string]any{"genre": "drama"}, map[string]any{"year": 2019}}}, false},
+		{map[string]any{"genre": "drama", "year": 2019}, false},
+	}
+	for _, tt := range tests {
+		filter, err := pinecone.NewMetadataFilter(tt.filter)
+		require.NoError(t, err)
+		match, err := compileFilter(filter)
+		require.NoError(t, err, tt.filter)
+		assert.Equal(t, tt.want, match(metadata), tt.filter)
+	}
+}
+
+func TestCompileFilterErrorsUnit(t *testing.T) {
+	for _, invalid := range []map[string]any{
+		{"year": map[string]any{"$gt": "2020"}},
+		{"genre": map[string]any{"$in": "drama"}},
+		{"genre": map[string]any{"$regex": "d.*"}},
+		{"$not": map[string]any{"genre": "drama"}},
+		{"$or": []any{}},
+		{"award": map[string]any{"$exists": "yes"}},
+	} {
+		filter, err := pinecone.NewMetadataFilter(invalid)
+		require.NoError(t, err)
+		_, err = compileFilter(filter)
+		assert.Error(t, err, invalid)
+	}
+}
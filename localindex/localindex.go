@@ -0,0 +1,680 @@
+// Package localindex is an in-memory Pinecone index for offline development and tests. An [Index] implements
+// [pinecone.VectorStore], the interface satisfied by [pinecone.IndexConnection], so application code that
+// depends on VectorStore can run against a local index without network access or an API key.
+//
+// Queries are exact: every vector in the namespace is scored with the index's metric, so results match what a
+// Pinecone index would return up to its approximate nearest neighbor recall. Metadata filters support the
+// $eq, $ne, $gt, $gte, $lt, $lte, $in, $nin, $exists, $and, and $or operators. An index can be saved to a file
+// with [Index.Save] and restored with [Load].
+//
+// Pagination tokens are opaque, like Pinecone's, and usage is always reported as zero read units.
+package localindex
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/pinecone-io/go-pinecone/v6/pinecone"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// defaultPageSize is the number of IDs or namespaces returned per page when no limit is given.
+const defaultPageSize = 100
+
+// [Options] configures a new [Index].
+//
+// Fields:
+//   - Dimension: The dimension of the vectors. Required for dense indexes; must be 0 for sparse indexes.
+//   - Metric: (Optional) The distance metric used to score queries. Defaults to [pinecone.Cosine], or to
+//     [pinecone.Dotproduct] for sparse indexes, which only support dotproduct.
+//   - VectorType: (Optional) "dense" or "sparse". Defaults to "dense".
+type Options struct {
+	Dimension  int32                `json:"dimension,omitempty"`
+	Metric     pinecone.IndexMetric `json:"metric"`
+	VectorType string               `json:"vector_type"`
+}
+
+// [Index] is an in-memory index. It is safe for concurrent use. Copies returned by [Index.WithNamespace]
+// share the same data.
+type Index struct {
+	store     *store
+	namespace string
+}
+
+type store struct {
+	mu         sync.RWMutex
+	options    Options
+	namespaces map[string]*namespaceData
+}
+
+type namespaceData struct {
+	vectors map[string]*entry
+	schema  *pinecone.MetadataSchema
+	// created is set for namespaces created with CreateNamespace, which exist even without vectors.
+	created bool
+}
+
+// entry is a stored vector with its metadata decoded once for filtering.
+type entry struct {
+	vector   *pinecone.Vector
+	metadata map[string]any
+}
+
+var _ pinecone.VectorStore = (*Index)(nil)
+
+// [New] creates an empty [Index].
+//
+// Example:
+//
+//	    var store pinecone.VectorStore
+//	    store, err := localindex.New(localindex.Options{Dimension: 3, Metric: pinecone.Cosine})
+//	    if err != nil {
+//		       log.Fatalf("Failed to create local index: %v", err)
+//	    }
+//
+//	    _, err = store.UpsertVectors(ctx, []*pinecone.Vector{{Id: "a", Values: &[]float32{1, 0, 0}}})
+//	    if err != nil {
+//		       log.Fatalf("Failed to upsert vectors: %v", err)
+//	    }
+func New(options Options) (*Index, error) {
+	if options.VectorType == "" {
+		options.VectorType = "dense"
+	}
+	switch options.VectorType {
+	case "dense":
+		if options.Dimension <= 0 {
+			return nil, fmt.Errorf("Dimension must be greater than 0 for a dense index")
+		}
+		if options.Metric == "" {
+			options.Metric = pinecone.Cosine
+		}
+	case "sparse":
+		if options.Dimension != 0 {
+			return nil, fmt.Errorf("Dimension must not be set for a sparse index")
+		}
+		if options.Metric == "" {
+			options.Metric = pinecone.Dotproduct
+		}
+		if options.Metric != pinecone.Dotproduct {
+			return nil, fmt.Errorf("sparse indexes only support the dotproduct metric")
+		}
+	default:
+		return nil, fmt.Errorf("invalid VectorType %q: must be \"dense\" or \"sparse\"", options.VectorType)
+	}
+	switch options.Metric {
+	case pinecone.Cosine, pinecone.Dotproduct, pinecone.Euclidean:
+	default:
+		return nil, fmt.Errorf("invalid Metric %q", options.Metric)
+	}
+	return &Index{store: &store{options: options, namespaces: make(map[string]*namespaceData)}}, nil
+}
+
+// [Index.WithNamespace] returns a copy of the index that targets namespace, sharing the same data.
+func (idx *Index) WithNamespace(namespace string) *Index {
+	return &Index{store: idx.store, namespace: namespace}
+}
+
+// [Index.Namespace] returns the namespace targeted by the index.
+func (idx *Index) Namespace() string {
+	return idx.namespace
+}
+
+// [Index.Close] is a no-op; it exists to satisfy [pinecone.VectorStore].
+func (idx *Index) Close() error {
+	return nil
+}
+
+// [Index.UpsertVectors] inserts vectors into the namespace, replacing any vectors with the same IDs.
+func (idx *Index) UpsertVectors(ctx context.Context, in []*pinecone.Vector, opts ...pinecone.CallOption) (uint32, error) {
+	if len(in) == 0 {
+		return 0, fmt.Errorf("in (*[]Vector) must contain at least one vector")
+	}
+	entries := make([]*entry, len(in))
+	for n, vector := range in {
+		if vector == nil {
+			return 0, fmt.Errorf("vector at position %d cannot be nil", n)
+		}
+		if err := idx.store.validateVector(vector.Id, vector.Values, vector.SparseValues, false); err != nil {
+			return 0, err
+		}
+		entries[n] = newEntry(vector)
+	}
+
+	s := idx.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	ns := s.namespaceForWrite(idx.targetNamespace(opts))
+	for _, e := range entries {
+		ns.vectors[e.vector.Id] = e
+	}
+	return uint32(len(entries)), nil
+}
+
+// [Index.FetchVectors] returns the vectors with the given IDs. IDs that do not exist are left out.
+func (idx *Index) FetchVectors(ctx context.Context, ids []string, opts ...pinecone.CallOption) (*pinecone.FetchVectorsResponse, error) {
+	if len(ids) == 0 {
+		return nil, fmt.Errorf("ids must contain at least one ID")
+	}
+	namespace := idx.targetNamespace(opts)
+
+	s := idx.store
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	vectors := make(map[string]*pinecone.Vector)
+	if ns := s.namespaces[namespace]; ns != nil {
+		for _, id := range ids {
+			if e, ok := ns.vectors[id]; ok {
+				vectors[id] = copyVector(e.vector, true, true)
+			}
+		}
+	}
+	return &pinecone.FetchVectorsResponse{Vectors: vectors, Usage: &pinecone.Usage{}, Namespace: namespace}, nil
+}
+
+// [Index.FetchVectorsByMetadata] returns the vectors whose metadata matches in.Filter, a page at a time in ID
+// order.
+func (idx *Index) FetchVectorsByMetadata(ctx context.Context, in *pinecone.FetchVectorsByMetadataRequest, opts ...pinecone.CallOption) (*pinecone.FetchVectorsByMetadataResponse, error) {
+	if in == nil {
+		return nil, fmt.Errorf("in (*FetchVectorsByMetadataRequest) cannot be nil")
+	}
+	if in.Filter == nil {
+		return nil, fmt.Errorf("Filter is required to fetch vectors by metadata")
+	}
+	match, err := compileFilter(in.Filter)
+	if err != nil {
+		return nil, badRequest(err)
+	}
+	namespace := idx.targetNamespace(opts)
+	if in.Namespace != nil {
+		namespace = *in.Namespace
+	}
+
+	s := idx.store
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	var matching []*entry
+	for _, e := range s.sortedEntries(namespace) {
+		if match(e.metadata) {
+			matching = append(matching, e)
+		}
+	}
+	page, next := paginate(matching, func(e *entry) string { return e.vector.Id }, in.Limit, in.PaginationToken)
+
+	vectors := make(map[string]*pinecone.Vector, len(page))
+	for _, e := range page {
+		vectors[e.vector.Id] = copyVector(e.vector, true, true)
+	}
+	var pagination *pinecone.Pagination
+	if next != nil {
+		pagination = &pinecone.Pagination{Next: *next}
+	}
+	return &pinecone.FetchVectorsByMetadataResponse{
+		Vectors:    vectors,
+		Usage:      &pinecone.Usage{},
+		Namespace:  namespace,
+		Pagination: pagination,
+	}, nil
+}
+
+// [Index.ListVectors] returns the IDs of the vectors in the namespace that start with in.Prefix, a page at a
+// time in ID order.
+func (idx *Index) ListVectors(ctx context.Context, in *pinecone.ListVectorsRequest, opts ...pinecone.CallOption) (*pinecone.ListVectorsResponse, error) {
+	if in == nil {
+		return nil, fmt.Errorf("in (*ListVectorsRequest) cannot be nil")
+	}
+	namespace := idx.targetNamespace(opts)
+	prefix := ""
+	if in.Prefix != nil {
+		prefix = *in.Prefix
+	}
+
+	s := idx.store
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	var ids []string
+	for _, e := range s.sortedEntries(namespace) {
+		if strings.HasPrefix(e.vector.Id, prefix) {
+			ids = append(ids, e.vector.Id)
+		}
+	}
+	page, next := paginate(ids, func(id string) string { return id }, in.Limit, in.PaginationToken)
+
+	vectorIds := make([]*string, len(page))
+	for n := range page {
+		vectorIds[n] = &page[n]
+	}
+	return &pinecone.ListVectorsResponse{
+		VectorIds:           vectorIds,
+		Usage:               &pinecone.Usage{},
+		NextPaginationToken: next,
+		Namespace:           namespace,
+	}, nil
+}
+
+// [Index.UpdateVector] replaces the values or sparse values of the vector with ID in.Id, and sets the fields
+// of in.Metadata in its metadata, leaving other fields unchanged. Updating an ID that does not exist does
+// nothing.
+func (idx *Index) UpdateVector(ctx context.Context, in *pinecone.UpdateVectorRequest, opts ...pinecone.CallOption) error {
+	if in == nil {
+		return fmt.Errorf("in (*UpdateVectorRequest) cannot be nil")
+	}
+	if in.Id == "" {
+		return fmt.Errorf("a vector ID plus at least one of Values, SparseValues, or Metadata must be provided to update a vector")
+	}
+	if in.Values == nil && in.SparseValues == nil && in.Metadata == nil {
+		return fmt.Errorf("a vector ID plus at least one of Values, SparseValues, or Metadata must be provided to update a vector")
+	}
+	if err := idx.store.validateVector(in.Id, valuesPtr(in.Values), in.SparseValues, true); err != nil {
+		return err
+	}
+
+	s := idx.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	ns := s.namespaces[idx.targetNamespace(opts)]
+	if ns == nil || ns.vectors[in.Id] == nil {
+		return nil
+	}
+	vector := copyVector(ns.vectors[in.Id].vector, true, true)
+	if in.Values != nil {
+		vector.Values = valuesPtr(append([]float32(nil), in.Values...))
+	}
+	if in.SparseValues != nil {
+		vector.SparseValues = copySparse(in.SparseValues)
+	}
+	vector.Metadata = mergeMetadata(vector.Metadata, in.Metadata)
+	ns.vectors[in.Id] = newEntry(vector)
+	return nil
+}
+
+// [Index.UpdateVectorsByMetadata] sets the fields of in.Metadata in the metadata of every vector matching
+// in.Filter. With in.DryRun set, it only counts the matching vectors.
+func (idx *Index) UpdateVectorsByMetadata(ctx context.Context, in *pinecone.UpdateVectorsByMetadataRequest, opts ...pinecone.CallOption) (*pinecone.UpdateVectorsByMetadataResponse, error) {
+	if in == nil {
+		return nil, fmt.Errorf("in (*UpdateVectorsByMetadataRequest) cannot be nil")
+	}
+	if in.Filter == nil {
+		return nil, fmt.Errorf("Filter is required to update vectors by metadata")
+	}
+	if in.Metadata == nil {
+		return nil, fmt.Errorf("Metadata is required to update vectors by metadata")
+	}
+	match, err := compileFilter(in.Filter)
+	if err != nil {
+		return nil, badRequest(err)
+	}
+	dryRun := in.DryRun != nil && *in.DryRun
+
+	s := idx.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	ns := s.namespaces[idx.targetNamespace(opts)]
+	if ns == nil {
+		return &pinecone.UpdateVectorsByMetadataResponse{}, nil
+	}
+	var matched int32
+	for id, e := range ns.vectors {
+		if !match(e.metadata) {
+			continue
+		}
+		matched++
+		if !dryRun {
+			vector := copyVector(e.vector, true, true)
+			vector.Metadata = mergeMetadata(vector.Metadata, in.Metadata)
+			ns.vectors[id] = newEntry(vector)
+		}
+	}
+	return &pinecone.UpdateVectorsByMetadataResponse{MatchedRecords: matched}, nil
+}
+
+// [Index.DeleteVectorsById] deletes the vectors with the given IDs. IDs that do not exist are ignored.
+func (idx *Index) DeleteVectorsById(ctx context.Context, ids []string, opts ...pinecone.CallOption) error {
+	if len(ids) == 0 {
+		return fmt.Errorf("ids must contain at least one ID")
+	}
+	s := idx.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	namespace := idx.targetNamespace(opts)
+	if ns := s.namespaces[namespace]; ns != nil {
+		for _, id := range ids {
+			delete(ns.vectors, id)
+		}
+		s.dropIfEmpty(namespace)
+	}
+	return nil
+}
+
+// [Index.DeleteVectorsByFilter] deletes the vectors whose metadata matches metadataFilter.
+func (idx *Index) DeleteVectorsByFilter(ctx context.Context, metadataFilter *pinecone.MetadataFilter, opts ...pinecone.CallOption) error {
+	if metadataFilter == nil {
+		return fmt.Errorf("metadataFilter cannot be nil")
+	}
+	match, err := compileFilter(metadataFilter)
+	if err != nil {
+		return badRequest(err)
+	}
+	s := idx.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	namespace := idx.targetNamespace(opts)
+	if ns := s.namespaces[namespace]; ns != nil {
+		for id, e := range ns.vectors {
+			if match(e.metadata) {
+				delete(ns.vectors, id)
+			}
+		}
+		s.dropIfEmpty(namespace)
+	}
+	return nil
+}
+
+// [Index.DeleteAllVectorsInNamespace] deletes every vector in the namespace.
+func (idx *Index) DeleteAllVectorsInNamespace(ctx context.Context, opts ...pinecone.CallOption) error {
+	s := idx.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	namespace := idx.targetNamespace(opts)
+	if ns := s.namespaces[namespace]; ns != nil {
+		ns.vectors = make(map[string]*entry)
+		s.dropIfEmpty(namespace)
+	}
+	return nil
+}
+
+// [Index.DescribeIndexStats] returns the dimension, metric, and vector counts of the index.
+func (idx *Index) DescribeIndexStats(ctx context.Context, opts ...pinecone.CallOption) (*pinecone.DescribeIndexStatsResponse, error) {
+	return idx.DescribeIndexStatsFiltered(ctx, nil, opts...)
+}
+
+// [Index.DescribeIndexStatsFiltered] returns the dimension, metric, and the counts of vectors matching
+// metadataFilter. Namespaces without matching vectors are left out.
+func (idx *Index) DescribeIndexStatsFiltered(ctx context.Context, metadataFilter *pinecone.MetadataFilter, opts ...pinecone.CallOption) (*pinecone.DescribeIndexStatsResponse, error) {
+	match, err := compileFilter(metadataFilter)
+	if err != nil {
+		return nil, badRequest(err)
+	}
+	s := idx.store
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	metric := s.options.Metric
+	vectorType := s.options.VectorType
+	res := &pinecone.DescribeIndexStatsResponse{
+		Metric:     &metric,
+		VectorType: &vectorType,
+		Namespaces: make(map[string]*pinecone.NamespaceSummary),
+	}
+	if s.options.Dimension > 0 {
+		dimension := uint32(s.options.Dimension)
+		res.Dimension = &dimension
+	}
+	for name, ns := range s.namespaces {
+		var count uint32
+		for _, e := range ns.vectors {
+			if match(e.metadata) {
+				count++
+			}
+		}
+		if count > 0 {
+			res.Namespaces[name] = &pinecone.NamespaceSummary{VectorCount: count}
+			res.TotalVectorCount += count
+		}
+	}
+	return res, nil
+}
+
+// [Index.CreateNamespace] creates an empty namespace. It returns a [pinecone.PineconeError] with code 409 if
+// the namespace already exists.
+func (idx *Index) CreateNamespace(ctx context.Context, in *pinecone.CreateNamespaceParams, opts ...pinecone.CallOption) (*pinecone.NamespaceDescription, error) {
+	if in == nil {
+		return nil, fmt.Errorf("in (*CreateNamespaceParams) cannot be nil")
+	}
+	if in.Name == "" {
+		return nil, fmt.Errorf("namespace name is required")
+	}
+	s := idx.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.namespaces[in.Name]; ok {
+		return nil, apiError(http.StatusConflict, "namespace %q already exists", in.Name)
+	}
+	ns := &namespaceData{vectors: make(map[string]*entry), schema: copySchema(in.Schema), created: true}
+	s.namespaces[in.Name] = ns
+	return describe(in.Name, ns), nil
+}
+
+// [Index.DescribeNamespace] describes namespace. It returns a [pinecone.PineconeError] with code 404 if the
+// namespace does not exist.
+func (idx *Index) DescribeNamespace(ctx context.Context, namespace string, opts ...pinecone.CallOption) (*pinecone.NamespaceDescription, error) {
+	s := idx.store
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	ns, ok := s.namespaces[namespace]
+	if !ok {
+		return nil, apiError(http.StatusNotFound, "namespace %q not found", namespace)
+	}
+	return describe(namespace, ns), nil
+}
+
+// [Index.ListNamespaces] lists the namespaces of the index, a page at a time in name order.
+func (idx *Index) ListNamespaces(ctx context.Context, in *pinecone.ListNamespacesParams, opts ...pinecone.CallOption) (*pinecone.ListNamespacesResponse, error) {
+	if in == nil {
+		in = &pinecone.ListNamespacesParams{}
+	}
+	prefix := ""
+	if in.Prefix != nil {
+		prefix = *in.Prefix
+	}
+	s := idx.store
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	var names []string
+	for name := range s.namespaces {
+		if strings.HasPrefix(name, prefix) {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	page, next := paginate(names, func(name string) string { return name }, in.Limit, in.PaginationToken)
+
+	res := &pinecone.ListNamespacesResponse{TotalCount: int32(len(names))}
+	for _, name := range page {
+		res.Namespaces = append(res.Namespaces, describe(name, s.namespaces[name]))
+	}
+	if next != nil {
+		res.Pagination = &pinecone.Pagination{Next: *next}
+	}
+	return res, nil
+}
+
+// [Index.DeleteNamespace] deletes namespace and all of its vectors. It returns a [pinecone.PineconeError] with
+// code 404 if the namespace does not exist.
+func (idx *Index) DeleteNamespace(ctx context.Context, namespace string, opts ...pinecone.CallOption) error {
+	s := idx.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.namespaces[namespace]; !ok {
+		return apiError(http.StatusNotFound, "namespace %q not found", namespace)
+	}
+	delete(s.namespaces, namespace)
+	return nil
+}
+
+// targetNamespace returns the namespace set with [pinecone.WithNamespace] in opts, or the index's namespace.
+func (idx *Index) targetNamespace(opts []pinecone.CallOption) string {
+	if namespace, ok := pinecone.CallNamespace(opts...); ok {
+		return namespace
+	}
+	return idx.namespace
+}
+
+// namespaceForWrite returns the namespace called name, creating it if needed. s.mu must be held for writing.
+func (s *store) namespaceForWrite(name string) *namespaceData {
+	ns, ok := s.namespaces[name]
+	if !ok {
+		ns = &namespaceData{vectors: make(map[string]*entry)}
+		s.namespaces[name] = ns
+	}
+	return ns
+}
+
+// dropIfEmpty removes the namespace called name if it has no vectors and was not created explicitly, as
+// Pinecone does. s.mu must be held for writing.
+func (s *store) dropIfEmpty(name string) {
+	if ns := s.namespaces[name]; ns != nil && len(ns.vectors) == 0 && !ns.created {
+		delete(s.namespaces, name)
+	}
+}
+
+// sortedEntries returns the vectors of the namespace called name in ID order. s.mu must be held.
+func (s *store) sortedEntries(name string) []*entry {
+	ns := s.namespaces[name]
+	if ns == nil {
+		return nil
+	}
+	entries := make([]*entry, 0, len(ns.vectors))
+	for _, e := range ns.vectors {
+		entries = append(entries, e)
+	}
+	sort.Slice(entries, func(a, b int) bool { return entries[a].vector.Id < entries[b].vector.Id })
+	return entries
+}
+
+// validateVector checks a vector or update against the index's vector type and dimension. For updates, the
+// values may be left out.
+func (s *store) validateVector(id string, values *[]float32, sparseValues *pinecone.SparseValues, update bool) error {
+	if id == "" {
+		return badRequest(fmt.Errorf("vector ID cannot be empty"))
+	}
+	if values != nil && s.options.VectorType == "sparse" {
+		return badRequest(fmt.Errorf("vector %q: sparse indexes do not accept dense values", id))
+	}
+	if values != nil && int32(len(*values)) != s.options.Dimension {
+		return badRequest(fmt.Errorf("vector %q: dimension %d does not match the dimension of the index %d", id, len(*values), s.options.Dimension))
+	}
+	if !update && s.options.VectorType == "dense" && values == nil {
+		return badRequest(fmt.Errorf("vector %q: dense indexes require values", id))
+	}
+	if !update && s.options.VectorType == "sparse" && sparseValues == nil {
+		return badRequest(fmt.Errorf("vector %q: sparse indexes require sparse values", id))
+	}
+	if sparseValues != nil {
+		if len(sparseValues.Indices) != len(sparseValues.Values) {
+			return badRequest(fmt.Errorf("vector %q: sparse values have %d indices but %d values", id, len(sparseValues.Indices), len(sparseValues.Values)))
+		}
+		seen := make(map[uint32]bool, len(sparseValues.Indices))
+		for _, index := range sparseValues.Indices {
+			if seen[index] {
+				return badRequest(fmt.Errorf("vector %q: duplicate sparse index %d", id, index))
+			}
+			seen[index] = true
+		}
+	}
+	return nil
+}
+
+// paginate returns the page of items after the item whose key is token, and the token for the next page.
+// items must be sorted by key.
+func paginate[T any](items []T, key func(T) string, limit *uint32, token *string) ([]T, *string) {
+	size := defaultPageSize
+	if limit != nil && *limit > 0 {
+		size = int(*limit)
+	}
+	start := 0
+	if token != nil && *token != "" {
+		start = sort.Search(len(items), func(n int) bool { return key(items[n]) > *token })
+	}
+	end := min(start+size, len(items))
+	page := items[start:end]
+	if end == len(items) {
+		return page, nil
+	}
+	next := key(items[end-1])
+	return page, &next
+}
+
+func newEntry(vector *pinecone.Vector) *entry {
+	e := &entry{vector: copyVector(vector, true, true), metadata: map[string]any{}}
+	if vector.Metadata != nil {
+		e.metadata = vector.Metadata.AsMap()
+	}
+	return e
+}
+
+// copyVector returns a deep copy of vector, keeping its values and metadata only if requested.
+func copyVector(vector *pinecone.Vector, values bool, metadata bool) *pinecone.Vector {
+	out := &pinecone.Vector{Id: vector.Id}
+	if values {
+		if vector.Values != nil {
+			out.Values = valuesPtr(append([]float32(nil), *vector.Values...))
+		}
+		out.SparseValues = copySparse(vector.SparseValues)
+	}
+	if metadata && vector.Metadata != nil {
+		out.Metadata = proto.Clone(vector.Metadata).(*structpb.Struct)
+	}
+	return out
+}
+
+func copySparse(sv *pinecone.SparseValues) *pinecone.SparseValues {
+	if sv == nil {
+		return nil
+	}
+	return &pinecone.SparseValues{
+		Indices: append([]uint32(nil), sv.Indices...),
+		Values:  append([]float32(nil), sv.Values...),
+	}
+}
+
+func copySchema(schema *pinecone.MetadataSchema) *pinecone.MetadataSchema {
+	if schema == nil {
+		return nil
+	}
+	out := &pinecone.MetadataSchema{Fields: make(map[string]pinecone.MetadataSchemaField, len(schema.Fields))}
+	for name, field := range schema.Fields {
+		out.Fields[name] = field
+	}
+	return out
+}
+
+// mergeMetadata returns metadata with the fields of update set.
+func mergeMetadata(metadata *pinecone.Metadata, update *pinecone.Metadata) *pinecone.Metadata {
+	if update == nil {
+		return metadata
+	}
+	if metadata == nil {
+		metadata = &structpb.Struct{}
+	}
+	if metadata.Fields == nil {
+		metadata.Fields = make(map[string]*structpb.Value, len(update.Fields))
+	}
+	for key, value := range update.Fields {
+		metadata.Fields[key] = proto.Clone(value).(*structpb.Value)
+	}
+	return metadata
+}
+
+func describe(name string, ns *namespaceData) *pinecone.NamespaceDescription {
+	return &pinecone.NamespaceDescription{Name: name, RecordCount: uint64(len(ns.vectors)), Schema: copySchema(ns.schema)}
+}
+
+func valuesPtr(values []float32) *[]float32 {
+	if values == nil {
+		return nil
+	}
+	return &values
+}
+
+func apiError(code int, format string, args ...any) error {
+	return &pinecone.PineconeError{Code: code, Msg: fmt.Errorf(format, args...)}
+}
+
+func badRequest(err error) error {
+	return &pinecone.PineconeError{Code: http.StatusBadRequest, Msg: err}
+}
@@ -0,0 +1,264 @@
+package localindex
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/pinecone-io/go-pinecone/v6/pinecone"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func vector(t *testing.T, id string, values []float32, metadata map[string]any) *pinecone.Vector {
+	t.Helper()
+	v := &pinecone.Vector{Id: id, Values: &values}
+	if metadata != nil {
+		m, err := pinecone.NewMetadata(metadata)
+		require.NoError(t, err)
+		v.Metadata = m
+	}
+	return v
+}
+
+func filter(t *testing.T, f map[string]any) *pinecone.MetadataFilter {
+	t.Helper()
+	m, err := pinecone.NewMetadataFilter(f)
+	require.NoError(t, err)
+	return m
+}
+
+func matchIds(res *pinecone.QueryVectorsResponse) []string {
+	ids := make([]string, len(res.Matches))
+	for n, match := range res.Matches {
+		ids[n] = match.Vector.Id
+	}
+	return ids
+}
+
+func newTestIndex(t *testing.T, metric pinecone.IndexMetric) *Index {
+	t.Helper()
+	idx, err := New(Options{Dimension: 2, Metric: metric})
+	require.NoError(t, err)
+	_, err = idx.UpsertVectors(context.Background(), []*pinecone.Vector{
+		vector(t, "a", []float32{1, 0}, map[string]any{"genre": "drama"}),
+		vector(t, "b", []float32{2, 2}, map[string]any{"genre": "comedy"}),
+		vector(t, "c", []float32{0, 3}, map[string]any{"genre": "drama"}),
+	})
+	require.NoError(t, err)
+	return idx
+}
+
+// Unit tests:
+func TestQueryMetricsUnit(t *testing.T) {
+	ctx := context.Background()
+	query := &pinecone.QueryByVectorValuesRequest{Vector: []float32{1, 0.1}, TopK: 3}
+
+	res, err := newTestIndex(t, pinecone.Cosine).QueryByVectorValues(ctx, query)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a", "b", "c"}, matchIds(res))
+	assert.InDelta(t, 0.995, res.Matches[0].Score, 0.001)
+
+	res, err = newTestIndex(t, pinecone.Dotproduct).QueryByVectorValues(ctx, query)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"b", "a", "c"}, matchIds(res))
+	assert.InDelta(t, 2.2, res.Matches[0].Score, 0.001)
+
+	res, err = newTestIndex(t, pinecone.Euclidean).QueryByVectorValues(ctx, query)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a", "b", "c"}, matchIds(res), "euclidean results are ordered by smallest distance")
+	assert.InDelta(t, 0.01, res.Matches[0].Score, 0.001)
+	assert.Nil(t, res.Matches[0].Vector.Values, "values are only returned when requested")
+	assert.Nil(t, res.Matches[0].Vector.Metadata)
+}
+
+func TestQueryOptionsUnit(t *testing.T) {
+	ctx := context.Background()
+	idx := newTestIndex(t, pinecone.Cosine)
+
+	res, err := idx.QueryByVectorValues(ctx, &pinecone.QueryByVectorValuesRequest{
+		Vector:          []float32{1, 0},
+		TopK:            1,
+		MetadataFilter:  filter(t, map[string]any{"genre": "drama"}),
+		IncludeValues:   true,
+		IncludeMetadata: true,
+	})
+	require.NoError(t, err)
+	require.Equal(t, []string{"a"}, matchIds(res))
+	assert.Equal(t, []float32{1, 0}, *res.Matches[0].Vector.Values)
+	assert.Equal(t, "drama", res.Matches[0].Vector.Metadata.AsMap()["genre"])
+
+	res, err = idx.QueryByVectorId(ctx, &pinecone.QueryByVectorIdRequest{VectorId: "c", TopK: 2})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"c", "b"}, matchIds(res))
+
+	_, err = idx.QueryByVectorId(ctx, &pinecone.QueryByVectorIdRequest{VectorId: "missing", TopK: 2})
+	var perr *pinecone.PineconeError
+	require.ErrorAs(t, err, &perr)
+	_, err = idx.QueryByVectorValues(ctx, &pinecone.QueryByVectorValuesRequest{Vector: []float32{1}, TopK: 1})
+	require.ErrorContains(t, err, "dimension")
+	_, err = idx.QueryByVectorValues(ctx, &pinecone.QueryByVectorValuesRequest{Vector: []float32{1, 0}})
+	require.ErrorContains(t, err, "TopK")
+}
+
+func TestSparseAndHybridUnit(t *testing.T) {
+	ctx := context.Background()
+	idx, err := New(Options{VectorType: "sparse"})
+	require.NoError(t, err)
+	_, err = idx.UpsertVectors(ctx, []*pinecone.Vector{
+		{Id: "a", SparseValues: &pinecone.SparseValues{Indices: []uint32{1, 5}, Values: []float32{1, 2}}},
+		{Id: "b", SparseValues: &pinecone.SparseValues{Indices: []uint32{5}, Values: []float32{0.5}}},
+	})
+	require.NoError(t, err)
+	res, err := idx.QueryByVectorValues(ctx, &pinecone.QueryByVectorValuesRequest{
+		SparseValues: &pinecone.SparseValues{Indices: []uint32{5}, Values: []float32{2}},
+		TopK:         2,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a", "b"}, matchIds(res))
+	assert.Equal(t, float32(4), res.Matches[0].Score)
+
+	_, err = idx.UpsertVectors(ctx, []*pinecone.Vector{vector(t, "c", []float32{1}, nil)})
+	require.ErrorContains(t, err, "do not accept dense values")
+	_, err = New(Options{VectorType: "sparse", Metric: pinecone.Cosine})
+	require.Error(t, err)
+
+	hybrid := newTestIndex(t, pinecone.Dotproduct)
+	_, err = hybrid.UpsertVectors(ctx, []*pinecone.Vector{{
+		Id: "d", Values: &[]float32{0, 0}, SparseValues: &pinecone.SparseValues{Indices: []uint32{7}, Values: []float32{10}},
+	}})
+	require.NoError(t, err)
+	res, err = hybrid.QueryByVectorValues(ctx, &pinecone.QueryByVectorValuesRequest{
+		Vector:       []float32{1, 0},
+		SparseValues: &pinecone.SparseValues{Indices: []uint32{7}, Values: []float32{1}},
+		TopK:         1,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"d"}, matchIds(res), "sparse values should add to the dense score")
+}
+
+func TestFetchUpdateDeleteUnit(t *testing.T) {
+	ctx := context.Background()
+	idx := newTestIndex(t, pinecone.Cosine)
+
+	fetched, err := idx.FetchVectors(ctx, []string{"a", "missing"})
+	require.NoError(t, err)
+	require.Len(t, fetched.Vectors, 1)
+	(*fetched.Vectors["a"].Values)[0] = 42
+	again, err := idx.FetchVectors(ctx, []string{"a"})
+	require.NoError(t, err)
+	assert.Equal(t, []float32{1, 0}, *again.Vectors["a"].Values, "returned vectors should be copies")
+
+	m, err := pinecone.NewMetadata(map[string]any{"rating": 5})
+	require.NoError(t, err)
+	require.NoError(t, idx.UpdateVector(ctx, &pinecone.UpdateVectorRequest{Id: "a", Values: []float32{0, 1}, Metadata: m}))
+	fetched, err = idx.FetchVectors(ctx, []string{"a"})
+	require.NoError(t, err)
+	assert.Equal(t, []float32{0, 1}, *fetched.Vectors["a"].Values)
+	assert.Equal(t, map[string]any{"genre": "drama", "rating": 5.0}, fetched.Vectors["a"].Metadata.AsMap(), "metadata should be merged")
+
+	dryRun := true
+	updated, err := idx.UpdateVectorsByMetadata(ctx, &pinecone.UpdateVectorsByMetadataRequest{
+		Filter: filter(t, map[string]any{"genre": "drama"}), Metadata: m, DryRun: &dryRun,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, int32(2), updated.MatchedRecords)
+	stats, err := idx.DescribeIndexStatsFiltered(ctx, filter(t, map[string]any{"rating": 5}))
+	require.NoError(t, err)
+	assert.Equal(t, uint32(1), stats.TotalVectorCount, "a dry run should not update")
+
+	byMetadata, err := idx.FetchVectorsByMetadata(ctx, &pinecone.FetchVectorsByMetadataRequest{Filter: filter(t, map[string]any{"genre": "drama"})})
+	require.NoError(t, err)
+	assert.Len(t, byMetadata.Vectors, 2)
+
+	require.NoError(t, idx.DeleteVectorsByFilter(ctx, filter(t, map[string]any{"genre": "comedy"})))
+	require.NoError(t, idx.DeleteVectorsById(ctx, []string{"c"}))
+	stats, err = idx.DescribeIndexStats(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, uint32(1), stats.TotalVectorCount)
+	assert.Equal(t, uint32(2), *stats.Dimension)
+	assert.Equal(t, pinecone.Cosine, *stats.Metric)
+
+	require.NoError(t, idx.DeleteAllVectorsInNamespace(ctx))
+	stats, err = idx.DescribeIndexStats(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, stats.Namespaces)
+}
+
+func TestListAndNamespacesUnit(t *testing.T) {
+	ctx := context.Background()
+	idx, err := New(Options{Dimension: 1})
+	require.NoError(t, err)
+	tenant := idx.WithNamespace("tenant")
+	for _, id := range []string{"doc1#0", "doc1#1", "doc1#2", "doc2#0"} {
+		_, err := tenant.UpsertVectors(ctx, []*pinecone.Vector{vector(t, id, []float32{1}, nil)})
+		require.NoError(t, err)
+	}
+
+	prefix := "doc1#"
+	limit := uint32(2)
+	var ids []string
+	var token *string
+	for pages := 0; ; pages++ {
+		require.Less(t, pages, 3)
+		res, err := idx.ListVectors(ctx, &pinecone.ListVectorsRequest{Prefix: &prefix, Limit: &limit, PaginationToken: token}, pinecone.WithNamespace("tenant"))
+		require.NoError(t, err)
+		for _, id := range res.VectorIds {
+			ids = append(ids, *id)
+		}
+		if res.NextPaginationToken == nil {
+			break
+		}
+		token = res.NextPaginationToken
+	}
+	assert.Equal(t, []string{"doc1#0", "doc1#1", "doc1#2"}, ids)
+
+	res, err := idx.ListVectors(ctx, &pinecone.ListVectorsRequest{})
+	require.NoError(t, err)
+	assert.Empty(t, res.VectorIds, "the default namespace should be empty")
+
+	_, err = idx.CreateNamespace(ctx, &pinecone.CreateNamespaceParams{Name: "empty"})
+	require.NoError(t, err)
+	_, err = idx.CreateNamespace(ctx, &pinecone.CreateNamespaceParams{Name: "tenant"})
+	var perr *pinecone.PineconeError
+	require.ErrorAs(t, err, &perr)
+	assert.Equal(t, 409, perr.Code)
+
+	namespaces, err := idx.ListNamespaces(ctx, nil)
+	require.NoError(t, err)
+	require.Len(t, namespaces.Namespaces, 2)
+	assert.Equal(t, "empty", namespaces.Namespaces[0].Name)
+	assert.Equal(t, uint64(4), namespaces.Namespaces[1].RecordCount)
+
+	require.NoError(t, idx.DeleteNamespace(ctx, "tenant"))
+	_, err = idx.DescribeNamespace(ctx, "tenant")
+	require.ErrorAs(t, err, &perr)
+	assert.Equal(t, 404, perr.Code)
+}
+
+func TestSaveAndLoadUnit(t *testing.T) {
+	ctx := context.Background()
+	idx := newTestIndex(t, pinecone.Euclidean)
+	_, err := idx.CreateNamespace(ctx, &pinecone.CreateNamespaceParams{Name: "empty"})
+	require.NoError(t, err)
+
+	path := filepath.Join(t.TempDir(), "index.json")
+	require.NoError(t, idx.Save(path))
+	loaded, err := Load(path)
+	require.NoError(t, err)
+
+	want, err := idx.DescribeIndexStats(ctx)
+	require.NoError(t, err)
+	got, err := loaded.DescribeIndexStats(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, want, got)
+
+	fetched, err := loaded.FetchVectors(ctx, []string{"b"})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{"genre": "comedy"}, fetched.Vectors["b"].Metadata.AsMap())
+	_, err = loaded.DescribeNamespace(ctx, "empty")
+	require.NoError(t, err, "explicitly created namespaces should be kept")
+
+	_, err = Load(filepath.Join(t.TempDir(), "missing.json"))
+	require.Error(t, err)
+}
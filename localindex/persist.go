@@ -0,0 +1,127 @@
+package localindex
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/pinecone-io/go-pinecone/v6/pinecone"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// fileFormat is the JSON written by [Index.Save].
+type fileFormat struct {
+	Options    Options                   `json:"options"`
+	Namespaces map[string]*fileNamespace `json:"namespaces"`
+}
+
+type fileNamespace struct {
+	Created bool                     `json:"created,omitempty"`
+	Schema  *pinecone.MetadataSchema `json:"schema,omitempty"`
+	Vectors []*fileVector            `json:"vectors"`
+}
+
+type fileVector struct {
+	Id           string                 `json:"id"`
+	Values       *[]float32             `json:"values,omitempty"`
+	SparseValues *pinecone.SparseValues `json:"sparse_values,omitempty"`
+	Metadata     map[string]any         `json:"metadata,omitempty"`
+}
+
+// [Index.Save] writes every namespace of the index to the file at path as JSON, replacing the file
+// atomically. Restore it with [Load].
+//
+// Example:
+//
+//	    if err := idx.Save("testdata/index.json"); err != nil {
+//		       log.Fatalf("Failed to save local index: %v", err)
+//	    }
+func (idx *Index) Save(path string) error {
+	s := idx.store
+	s.mu.RLock()
+	file := fileFormat{Options: s.options, Namespaces: make(map[string]*fileNamespace, len(s.namespaces))}
+	for name, ns := range s.namespaces {
+		fns := &fileNamespace{Created: ns.created, Schema: copySchema(ns.schema), Vectors: []*fileVector{}}
+		for _, e := range ns.vectors {
+			v := copyVector(e.vector, true, false)
+			fv := &fileVector{Id: v.Id, Values: v.Values, SparseValues: v.SparseValues}
+			if len(e.metadata) > 0 {
+				fv.Metadata = e.metadata
+			}
+			fns.Vectors = append(fns.Vectors, fv)
+		}
+		sort.Slice(fns.Vectors, func(a, b int) bool { return fns.Vectors[a].Id < fns.Vectors[b].Id })
+		file.Namespaces[name] = fns
+	}
+	data, err := json.Marshal(file)
+	s.mu.RUnlock()
+	if err != nil {
+		return fmt.Errorf("failed to encode local index: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".tmp-*")
+	if err != nil {
+		return fmt.Errorf("failed to save local index: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to save local index: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to save local index: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("failed to save local index: %w", err)
+	}
+	return nil
+}
+
+// [Load] reads an index written by [Index.Save]. The returned index targets the default namespace.
+//
+// Example:
+//
+//	    idx, err := localindex.Load("testdata/index.json")
+//	    if err != nil {
+//		       log.Fatalf("Failed to load local index: %v", err)
+//	    }
+//	    res, err := idx.WithNamespace("tenant-a").QueryByVectorValues(ctx, &pinecone.QueryByVectorValuesRequest{
+//		       Vector: []float32{0.1, 0.2, 0.3},
+//		       TopK:   5,
+//	    })
+func Load(path string) (*Index, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load local index: %w", err)
+	}
+	var file fileFormat
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("failed to decode local index %s: %w", path, err)
+	}
+	idx, err := New(file.Options)
+	if err != nil {
+		return nil, fmt.Errorf("invalid local index %s: %w", path, err)
+	}
+
+	for name, fns := range file.Namespaces {
+		ns := &namespaceData{vectors: make(map[string]*entry, len(fns.Vectors)), schema: fns.Schema, created: fns.Created}
+		for _, fv := range fns.Vectors {
+			vector := &pinecone.Vector{Id: fv.Id, Values: fv.Values, SparseValues: fv.SparseValues}
+			if fv.Metadata != nil {
+				metadata, err := structpb.NewStruct(fv.Metadata)
+				if err != nil {
+					return nil, fmt.Errorf("invalid metadata for vector %q in local index %s: %w", fv.Id, path, err)
+				}
+				vector.Metadata = metadata
+			}
+			if err := idx.store.validateVector(vector.Id, vector.Values, vector.SparseValues, false); err != nil {
+				return nil, fmt.Errorf("invalid local index %s: %w", path, err)
+			}
+			ns.vectors[fv.Id] = newEntry(vector)
+		}
+		idx.store.namespaces[name] = ns
+	}
+	return idx, nil
+}
@@ -0,0 +1,166 @@
+package localindex
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net/http"
+	"sort"
+
+	"github.com/pinecone-io/go-pinecone/v6/pinecone"
+)
+
+// maxTopK is the largest TopK Pinecone accepts.
+const maxTopK = 10000
+
+// [Index.QueryByVectorValues] scores every vector in the namespace that matches in.MetadataFilter against the
+// query vector and returns the in.TopK best. Scores are the cosine similarity, the dot product (including the
+// sparse values), or the squared Euclidean distance, depending on the metric. Euclidean results are ordered
+// from the smallest distance, the others from the largest score; ties are ordered by ID.
+func (idx *Index) QueryByVectorValues(ctx context.Context, in *pinecone.QueryByVectorValuesRequest, opts ...pinecone.CallOption) (*pinecone.QueryVectorsResponse, error) {
+	if in == nil {
+		return nil, fmt.Errorf("in (*QueryByVectorValuesRequest) cannot be nil")
+	}
+	return idx.query(idx.targetNamespace(opts), in.Vector, in.SparseValues, in.TopK, in.MetadataFilter, in.IncludeValues, in.IncludeMetadata)
+}
+
+// [Index.QueryByVectorId] queries the namespace with the values of the vector with ID in.VectorId, like
+// [Index.QueryByVectorValues]. The vector itself is included in the results. It returns a
+// [pinecone.PineconeError] with code 400 if the vector does not exist.
+func (idx *Index) QueryByVectorId(ctx context.Context, in *pinecone.QueryByVectorIdRequest, opts ...pinecone.CallOption) (*pinecone.QueryVectorsResponse, error) {
+	if in == nil {
+		return nil, fmt.Errorf("in (*QueryByVectorIdRequest) cannot be nil")
+	}
+	namespace := idx.targetNamespace(opts)
+
+	s := idx.store
+	s.mu.RLock()
+	var values []float32
+	var sparseValues *pinecone.SparseValues
+	var found bool
+	if ns := s.namespaces[namespace]; ns != nil {
+		if e, ok := ns.vectors[in.VectorId]; ok {
+			found = true
+			values = derefValues(e.vector.Values)
+			sparseValues = e.vector.SparseValues
+		}
+	}
+	s.mu.RUnlock()
+	if !found {
+		return nil, apiError(http.StatusBadRequest, "vector %q not found in namespace %q", in.VectorId, namespace)
+	}
+	if in.SparseValues != nil {
+		sparseValues = in.SparseValues
+	}
+	return idx.query(namespace, values, sparseValues, in.TopK, in.MetadataFilter, in.IncludeValues, in.IncludeMetadata)
+}
+
+func (idx *Index) query(namespace string, values []float32, sparseValues *pinecone.SparseValues, topK uint32, filter *pinecone.MetadataFilter,
+	includeValues bool, includeMetadata bool) (*pinecone.QueryVectorsResponse, error) {
+	s := idx.store
+	if topK < 1 || topK > maxTopK {
+		return nil, badRequest(fmt.Errorf("TopK must be between 1 and %d, got %d", maxTopK, topK))
+	}
+	if s.options.VectorType == "dense" && len(values) == 0 {
+		return nil, badRequest(fmt.Errorf("a query vector is required for dense indexes"))
+	}
+	if len(values) > 0 {
+		if err := s.validateVector("query", &values, nil, true); err != nil {
+			return nil, err
+		}
+	}
+	if sparseValues != nil {
+		if s.options.Metric != pinecone.Dotproduct {
+			return nil, badRequest(fmt.Errorf("sparse query values require the dotproduct metric"))
+		}
+		if err := s.validateVector("query", nil, sparseValues, true); err != nil {
+			return nil, err
+		}
+	} else if s.options.VectorType == "sparse" {
+		return nil, badRequest(fmt.Errorf("sparse values are required to query sparse indexes"))
+	}
+	match, err := compileFilter(filter)
+	if err != nil {
+		return nil, badRequest(err)
+	}
+	querySparse := sparseMap(sparseValues)
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	var matches []*pinecone.ScoredVector
+	for _, e := range s.sortedEntries(namespace) {
+		if !match(e.metadata) {
+			continue
+		}
+		matches = append(matches, &pinecone.ScoredVector{
+			Vector: copyVector(e.vector, includeValues, includeMetadata),
+			Score:  s.score(values, querySparse, e.vector),
+		})
+	}
+	ascending := s.options.Metric == pinecone.Euclidean
+	sort.SliceStable(matches, func(a, b int) bool {
+		if matches[a].Score != matches[b].Score {
+			return (matches[a].Score < matches[b].Score) == ascending
+		}
+		return false
+	})
+	if len(matches) > int(topK) {
+		matches = matches[:topK]
+	}
+	return &pinecone.QueryVectorsResponse{Matches: matches, Usage: &pinecone.Usage{}, Namespace: namespace}, nil
+}
+
+// score scores vector against the query. querySparse maps the query's sparse indices to their values.
+func (s *store) score(values []float32, querySparse map[uint32]float32, vector *pinecone.Vector) float32 {
+	stored := derefValues(vector.Values)
+	switch s.options.Metric {
+	case pinecone.Cosine:
+		var dot, queryNorm, storedNorm float64
+		for n := range values {
+			dot += float64(values[n]) * float64(stored[n])
+			queryNorm += float64(values[n]) * float64(values[n])
+			storedNorm += float64(stored[n]) * float64(stored[n])
+		}
+		if queryNorm == 0 || storedNorm == 0 {
+			return 0
+		}
+		return float32(dot / math.Sqrt(queryNorm*storedNorm))
+	case pinecone.Euclidean:
+		var distance float64
+		for n := range values {
+			d := float64(values[n]) - float64(stored[n])
+			distance += d * d
+		}
+		return float32(distance)
+	default:
+		var dot float64
+		if len(stored) == len(values) {
+			for n := range values {
+				dot += float64(values[n]) * float64(stored[n])
+			}
+		}
+		if vector.SparseValues != nil {
+			for n, index := range vector.SparseValues.Indices {
+				dot += float64(querySparse[index]) * float64(vector.SparseValues.Values[n])
+			}
+		}
+		return float32(dot)
+	}
+}
+
+func sparseMap(sv *pinecone.SparseValues) map[uint32]float32 {
+	out := make(map[uint32]float32)
+	if sv != nil {
+		for n, index := range sv.Indices {
+			out[index] = sv.Values[n]
+		}
+	}
+	return out
+}
+
+func derefValues(values *[]float32) []float32 {
+	if values == nil {
+		return nil
+	}
+	return *values
+}
@@ -0,0 +1,315 @@
+package pinecone
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"time"
+)
+
+// [AccessFlag] marks an [AccessEntry] that needs attention in an access review.
+type AccessFlag string
+
+const (
+	// The principal is an invite that has been pending for longer than [AccessReportParams] StaleInviteAge,
+	// or that has expired.
+	AccessFlagStaleInvite AccessFlag = "stale_invite"
+	// The principal is a service account with no role bindings, so it cannot access anything.
+	AccessFlagUnusedServiceAccount AccessFlag = "unused_service_account"
+)
+
+// [AccessReportParams] contains parameters for building an [AccessReport].
+type AccessReportParams struct {
+	// (Optional) How long an invite can be pending before it is flagged as stale. Default is 7 days.
+	StaleInviteAge time.Duration
+}
+
+// [AccessEntry] is a row of an [AccessReport]: the roles one principal has on one resource.
+type AccessEntry struct {
+	// The kind of principal.
+	PrincipalType PrincipalType `json:"principal_type"`
+
+	// The principal's ID.
+	PrincipalId string `json:"principal_id"`
+
+	// The user's or invite's email, or the service account's or API key's name. Empty if the principal was not
+	// found in the listings.
+	PrincipalName string `json:"principal_name,omitempty"`
+
+	// The kind of resource the roles apply to. Empty for a principal with no access.
+	ResourceType ResourceType `json:"resource_type,omitempty"`
+
+	// The ID of the organization or project.
+	ResourceId string `json:"resource_id,omitempty"`
+
+	// The name of the organization or project.
+	ResourceName string `json:"resource_name,omitempty"`
+
+	// The roles the principal has on the resource, sorted.
+	Roles []string `json:"roles"`
+
+	// (Optional) Why the entry needs attention.
+	Flags []AccessFlag `json:"flags,omitempty"`
+}
+
+// [AccessReport] lists who has which access in an organization, for access reviews and audits.
+type AccessReport struct {
+	// When the report was built.
+	GeneratedAt time.Time `json:"generated_at"`
+
+	// The principal-by-resource access matrix, sorted by principal type, principal name, resource type, and
+	// resource name. Service accounts with no role bindings have an entry without a resource.
+	Entries []AccessEntry `json:"entries"`
+
+	// Invites pending for longer than the stale invite age, or expired.
+	StaleInvites []*Invite `json:"stale_invites"`
+
+	// Service accounts with no role bindings.
+	UnusedServiceAccounts []*ServiceAccount `json:"unused_service_accounts"`
+}
+
+// Builds an [AccessReport] of the organization by listing its organizations, projects, users, service accounts,
+// invites, API keys, and role bindings, following every page. Role bindings and the roles of API keys are
+// combined into one entry per principal and resource.
+//
+// The Admin API does not report when a service account was last used, so service accounts are flagged as
+// unused when they have no role bindings.
+//
+// Parameters:
+//   - ctx: The request context.
+//   - in: (Optional) A pointer to [AccessReportParams]. May be nil to use defaults.
+//
+// Returns a pointer to an [AccessReport] or an error.
+//
+// Example:
+//
+//	report, err := adminClient.AccessReport(ctx, &pinecone.AccessReportParams{StaleInviteAge: 14 * 24 * time.Hour})
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	f, err := os.Create("access-review.csv")
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	defer f.Close()
+//	if err := report.WriteCSV(f); err != nil {
+//		log.Fatal(err)
+//	}
+func (a *AdminClient) AccessReport(ctx context.Context, in *AccessReportParams) (*AccessReport, error) {
+	if in == nil {
+		in = &AccessReportParams{}
+	}
+	report := &AccessReport{GeneratedAt: time.Now().UTC()}
+
+	resourceNames := map[string]string{}
+	organizations, err := a.Organization.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list organizations: %w", err)
+	}
+	for _, organization := range organizations {
+		resourceNames[organization.Id] = organization.Name
+	}
+	projects, err := a.Project.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list projects: %w", err)
+	}
+	for _, project := range projects {
+		resourceNames[project.Id] = project.Name
+	}
+
+	principalNames := map[PrincipalType]map[string]string{
+		PrincipalTypeUser:           {},
+		PrincipalTypeServiceAccount: {},
+		PrincipalTypeAPIKey:         {},
+		PrincipalTypeInvite:         {},
+	}
+	users, err := listAllPages(func(token *string) ([]*User, *Pagination, error) {
+		list, err := a.User.List(ctx, &ListUsersParams{PaginationToken: token})
+		if err != nil {
+			return nil, nil, err
+		}
+		return list.Data, list.Pagination, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list users: %w", err)
+	}
+	for _, user := range users {
+		principalNames[PrincipalTypeUser][user.Id] = user.Email
+	}
+	serviceAccounts, err := listAllPages(func(token *string) ([]*ServiceAccount, *Pagination, error) {
+		list, err := a.ServiceAccount.List(ctx, &ListServiceAccountsParams{PaginationToken: token})
+		if err != nil {
+			return nil, nil, err
+		}
+		return list.Data, list.Pagination, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list service accounts: %w", err)
+	}
+	for _, account := range serviceAccounts {
+		principalNames[PrincipalTypeServiceAccount][account.Id] = account.Name
+	}
+	invites, err := listAllPages(func(token *string) ([]*Invite, *Pagination, error) {
+		list, err := a.Invite.List(ctx, &ListInvitesParams{PaginationToken: token})
+		if err != nil {
+			return nil, nil, err
+		}
+		return list.Data, list.Pagination, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list invites: %w", err)
+	}
+	staleInvites := map[string]bool{}
+	staleBefore := report.GeneratedAt.Add(-valueOrFallback(in.StaleInviteAge, 7*24*time.Hour))
+	for _, invite := range invites {
+		principalNames[PrincipalTypeInvite][invite.Id] = invite.Email
+		if invite.Status == InviteStatusExpired || invite.Status == InviteStatusPending && invite.CreatedAt.Before(staleBefore) {
+			staleInvites[invite.Id] = true
+			report.StaleInvites = append(report.StaleInvites, invite)
+		}
+	}
+
+	matrix := accessMatrix{}
+	for _, project := range projects {
+		keys, err := a.APIKey.List(ctx, project.Id)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list api keys of project %q: %w", project.Name, err)
+		}
+		for _, key := range keys {
+			principalNames[PrincipalTypeAPIKey][key.Id] = key.Name
+			matrix.add(PrincipalTypeAPIKey, key.Id, ResourceTypeProject, key.ProjectId, key.Roles...)
+		}
+	}
+	bindings, err := listAllPages(func(token *string) ([]*RoleBinding, *Pagination, error) {
+		list, err := a.RoleBinding.List(ctx, &ListRoleBindingsParams{PaginationToken: token})
+		if err != nil {
+			return nil, nil, err
+		}
+		return list.Data, list.Pagination, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list role bindings: %w", err)
+	}
+	for _, binding := range bindings {
+		matrix.add(binding.PrincipalType, binding.PrincipalId, binding.ResourceType, binding.ResourceId, binding.Role)
+	}
+
+	for key, roles := range matrix {
+		entry := AccessEntry{
+			PrincipalType: key.principalType,
+			PrincipalId:   key.principalId,
+			PrincipalName: principalNames[key.principalType][key.principalId],
+			ResourceType:  key.resourceType,
+			ResourceId:    key.resourceId,
+			ResourceName:  resourceNames[key.resourceId],
+			Roles:         roles,
+		}
+		slices.Sort(entry.Roles)
+		if key.principalType == PrincipalTypeInvite && staleInvites[key.principalId] {
+			entry.Flags = append(entry.Flags, AccessFlagStaleInvite)
+		}
+		report.Entries = append(report.Entries, entry)
+	}
+	for _, account := range serviceAccounts {
+		if matrix.has(PrincipalTypeServiceAccount, account.Id) {
+			continue
+		}
+		report.UnusedServiceAccounts = append(report.UnusedServiceAccounts, account)
+		report.Entries = append(report.Entries, AccessEntry{
+			PrincipalType: PrincipalTypeServiceAccount,
+			PrincipalId:   account.Id,
+			PrincipalName: account.Name,
+			Roles:         []string{},
+			Flags:         []AccessFlag{AccessFlagUnusedServiceAccount},
+		})
+	}
+	slices.SortFunc(report.Entries, func(a, b AccessEntry) int {
+		for _, cmp := range [][2]string{
+			{string(a.PrincipalType), string(b.PrincipalType)},
+			{a.PrincipalName, b.PrincipalName},
+			{a.PrincipalId, b.PrincipalId},
+			{string(a.ResourceType), string(b.ResourceType)},
+			{a.ResourceName, b.ResourceName},
+			{a.ResourceId, b.ResourceId},
+		} {
+			if c := strings.Compare(cmp[0], cmp[1]); c != 0 {
+				return c
+			}
+		}
+		return 0
+	})
+	return report, nil
+}
+
+// accessMatrix collects the roles of each principal on each resource.
+type accessMatrix map[accessKey][]string
+
+type accessKey struct {
+	principalType PrincipalType
+	principalId   string
+	resourceType  ResourceType
+	resourceId    string
+}
+
+func (m accessMatrix) add(principalType PrincipalType, principalId string, resourceType ResourceType, resourceId string, roles ...string) {
+	key := accessKey{principalType: principalType, principalId: principalId, resourceType: resourceType, resourceId: resourceId}
+	if _, ok := m[key]; !ok {
+		m[key] = []string{}
+	}
+	for _, role := range roles {
+		if !slices.Contains(m[key], role) {
+			m[key] = append(m[key], role)
+		}
+	}
+}
+
+func (m accessMatrix) has(principalType PrincipalType, principalId string) bool {
+	for key := range m {
+		if key.principalType == principalType && key.principalId == principalId {
+			return true
+		}
+	}
+	return false
+}
+
+// [AccessReport.WriteCSV] writes the report's entries as CSV with a header row. Roles and flags are joined
+// with ";".
+func (r *AccessReport) WriteCSV(w io.Writer) error {
+	writer := csv.NewWriter(w)
+	header := []string{"principal_type", "principal_id", "principal_name", "resource_type", "resource_id", "resource_name", "roles", "flags"}
+	if err := writer.Write(header); err != nil {
+		return err
+	}
+	for _, entry := range r.Entries {
+		flags := make([]string, len(entry.Flags))
+		for i, flag := range entry.Flags {
+			flags[i] = string(flag)
+		}
+		err := writer.Write([]string{
+			string(entry.PrincipalType),
+			entry.PrincipalId,
+			entry.PrincipalName,
+			string(entry.ResourceType),
+			entry.ResourceId,
+			entry.ResourceName,
+			strings.Join(entry.Roles, ";"),
+			strings.Join(flags, ";"),
+		})
+		if err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+// [AccessReport.WriteJSON] writes the report as indented JSON.
+func (r *AccessReport) WriteJSON(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(r)
+}
@@ -0,0 +1,106 @@
+package pinecone
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeOrganizations struct {
+	OrganizationClient
+	organizations []*Organization
+}
+
+func (f fakeOrganizations) List(ctx context.Context) ([]*Organization, error) {
+	return f.organizations, nil
+}
+
+type fakeProjectAPIKeys struct {
+	APIKeyClient
+	keys []*APIKey
+}
+
+func (f fakeProjectAPIKeys) List(ctx context.Context, projectId string) ([]*APIKey, error) {
+	var keys []*APIKey
+	for _, key := range f.keys {
+		if key.ProjectId == projectId {
+			keys = append(keys, key)
+		}
+	}
+	return keys, nil
+}
+
+// Unit tests:
+func TestAccessReportUnit(t *testing.T) {
+	now := time.Now()
+	org := &fakeOrg{
+		projects:        []*Project{{Id: "p-prod", Name: "prod"}},
+		users:           []*User{{Id: "u-ann", Email: "ann@example.com"}},
+		serviceAccounts: []*ServiceAccount{{Id: "sa-ci", Name: "ci"}, {Id: "sa-idle", Name: "idle"}},
+		invites: []*Invite{
+			{Id: "inv-new", Email: "new@example.com", Status: InviteStatusPending, CreatedAt: now.Add(-time.Hour)},
+			{Id: "inv-old", Email: "old@example.com", Status: InviteStatusPending, CreatedAt: now.Add(-30 * 24 * time.Hour)},
+		},
+		bindings: []*RoleBinding{
+			{Id: "b1", PrincipalType: PrincipalTypeUser, PrincipalId: "u-ann", ResourceType: ResourceTypeOrganization, ResourceId: "org", Role: "OrgOwner"},
+			{Id: "b2", PrincipalType: PrincipalTypeUser, PrincipalId: "u-ann", ResourceType: ResourceTypeProject, ResourceId: "p-prod", Role: "ProjectViewer"},
+			{Id: "b3", PrincipalType: PrincipalTypeUser, PrincipalId: "u-ann", ResourceType: ResourceTypeProject, ResourceId: "p-prod", Role: "DataPlaneEditor"},
+			{Id: "b4", PrincipalType: PrincipalTypeServiceAccount, PrincipalId: "sa-ci", ResourceType: ResourceTypeProject, ResourceId: "p-prod", Role: "ProjectEditor"},
+			{Id: "b5", PrincipalType: PrincipalTypeInvite, PrincipalId: "inv-old", ResourceType: ResourceTypeOrganization, ResourceId: "org", Role: "OrgMember"},
+			{Id: "b6", PrincipalType: PrincipalTypeAPIKey, PrincipalId: "key-1", ResourceType: ResourceTypeProject, ResourceId: "p-prod", Role: "ProjectEditor"},
+		},
+	}
+	admin := org.adminClient()
+	admin.Organization = fakeOrganizations{organizations: []*Organization{{Id: "org", Name: "acme"}}}
+	admin.APIKey = fakeProjectAPIKeys{keys: []*APIKey{{Id: "key-1", Name: "ingest", ProjectId: "p-prod", Roles: []string{"ProjectEditor"}}}}
+
+	report, err := admin.AccessReport(context.Background(), nil)
+	require.NoError(t, err)
+
+	var rows []string
+	for _, entry := range report.Entries {
+		rows = append(rows, fmt.Sprintf("%s %s -> %s %s: %s %v", entry.PrincipalType, entry.PrincipalName, entry.ResourceType, entry.ResourceName, strings.Join(entry.Roles, ","), entry.Flags))
+	}
+	assert.Equal(t, []string{
+		"api_key ingest -> project prod: ProjectEditor []",
+		"invite old@example.com -> organization acme: OrgMember [stale_invite]",
+		"service_account ci -> project prod: ProjectEditor []",
+		"service_account idle ->  :  [unused_service_account]",
+		"user ann@example.com -> organization acme: OrgOwner []",
+		"user ann@example.com -> project prod: DataPlaneEditor,ProjectViewer []",
+	}, rows)
+	require.Len(t, report.StaleInvites, 1)
+	assert.Equal(t, "inv-old", report.StaleInvites[0].Id)
+	require.Len(t, report.UnusedServiceAccounts, 1)
+	assert.Equal(t, "sa-idle", report.UnusedServiceAccounts[0].Id)
+
+	report, err = admin.AccessReport(context.Background(), &AccessReportParams{StaleInviteAge: time.Minute})
+	require.NoError(t, err)
+	assert.Len(t, report.StaleInvites, 2)
+}
+
+func TestAccessReportExportUnit(t *testing.T) {
+	report := &AccessReport{Entries: []AccessEntry{
+		{PrincipalType: PrincipalTypeUser, PrincipalId: "u", PrincipalName: "ann@example.com", ResourceType: ResourceTypeProject, ResourceId: "p", ResourceName: "prod", Roles: []string{"DataPlaneEditor", "ProjectViewer"}},
+		{PrincipalType: PrincipalTypeServiceAccount, PrincipalId: "sa", PrincipalName: "idle, old", Roles: []string{}, Flags: []AccessFlag{AccessFlagUnusedServiceAccount}},
+	}}
+
+	var csvOut bytes.Buffer
+	require.NoError(t, report.WriteCSV(&csvOut))
+	assert.Equal(t, "principal_type,principal_id,principal_name,resource_type,resource_id,resource_name,roles,flags\n"+
+		"user,u,ann@example.com,project,p,prod,DataPlaneEditor;ProjectViewer,\n"+
+		"service_account,sa,\"idle, old\",,,,,unused_service_account\n", csvOut.String())
+
+	var jsonOut bytes.Buffer
+	require.NoError(t, report.WriteJSON(&jsonOut))
+	var decoded AccessReport
+	require.NoError(t, json.Unmarshal(jsonOut.Bytes(), &decoded))
+	assert.Equal(t, report.Entries, decoded.Entries)
+}
@@ -9,6 +9,7 @@ import (
 	"net/http"
 	"net/url"
 	"os"
+	"time"
 
 	"github.com/google/uuid"
 	openapi_types "github.com/oapi-codegen/runtime/types"
@@ -213,8 +214,8 @@ type DefaultUserClient struct {
 }
 
 // [NewAdminClientParams] contains parameters used to configure the [AdminClient].
-// You must provide either a client ID and secret, or an access token, either directly or via environment
-// variables (PINECONE_CLIENT_ID, PINECONE_CLIENT_SECRET, PINECONE_ACCESS_TOKEN).
+// You must provide either a [TokenSource], a client ID and secret, or an access token, either directly or via
+// environment variables (PINECONE_CLIENT_ID, PINECONE_CLIENT_SECRET, PINECONE_ACCESS_TOKEN).
 type NewAdminClientParams struct {
 	// The OAuth client ID used for authentication.
 	ClientId string
@@ -225,6 +226,11 @@ type NewAdminClientParams struct {
 	// The OAuth access token used for authentication.
 	AccessToken string
 
+	// (Optional) The source of the access tokens used for authentication, e.g. workload identity or a
+	// secrets vault. Takes precedence over AccessToken, ClientId, and ClientSecret. Tokens are cached and
+	// refreshed before they expire or when a request is rejected with 401 Unauthorized.
+	TokenSource TokenSource
+
 	// The host URL of the Pinecone API. If not provided, the default value is "https://api.pinecone.io".
 	Host string
 
@@ -249,30 +255,34 @@ func NewAdminClient(in NewAdminClientParams) (*AdminClient, error) {
 // context and parameters. This function allows for finer control over timeout, and
 // cancellation of the authentication request. It validates the client ID and secret
 // from the input or environment, authenticates, and constructs an authorized [AdminClient].
+// Access tokens obtained with the client ID and secret are refreshed before they expire.
 func NewAdminClientWithContext(ctx context.Context, in NewAdminClientParams) (*AdminClient, error) {
-	var authHeader string
 	clientOptions := buildAdminClientOptions(in)
 
-	accessToken := valueOrFallback(in.AccessToken, os.Getenv("PINECONE_ACCESS_TOKEN"))
-	if accessToken != "" {
-		// Use access token directly if provided
-		authHeader = fmt.Sprintf("Bearer %s", accessToken)
-	} else {
-		// Fall back to client ID and secret if access token is not provided
-		clientId := valueOrFallback(in.ClientId, os.Getenv("PINECONE_CLIENT_ID"))
-		clientSecret := valueOrFallback(in.ClientSecret, os.Getenv("PINECONE_CLIENT_SECRET"))
-		if clientId == "" {
-			return nil, fmt.Errorf("no ClientId provided, please pass an ClientId for authorization through NewAdminClientParams or set the PINECONE_CLIENT_ID environment variable")
-		}
-		if clientSecret == "" {
-			return nil, fmt.Errorf("no ClientSecret provided, please pass an ClientSecret for authorization through NewAdminClientParams or set the PINECONE_CLIENT_SECRET environment variable")
+	tokenSource := in.TokenSource
+	if tokenSource == nil {
+		accessToken := valueOrFallback(in.AccessToken, os.Getenv("PINECONE_ACCESS_TOKEN"))
+		if accessToken != "" {
+			// Use access token directly if provided
+			tokenSource = StaticTokenSource(accessToken)
+		} else {
+			// Fall back to client ID and secret if access token is not provided
+			clientId := valueOrFallback(in.ClientId, os.Getenv("PINECONE_CLIENT_ID"))
+			clientSecret := valueOrFallback(in.ClientSecret, os.Getenv("PINECONE_CLIENT_SECRET"))
+			if clientId == "" {
+				return nil, fmt.Errorf("no ClientId provided, please pass an ClientId for authorization through NewAdminClientParams or set the PINECONE_CLIENT_ID environment variable")
+			}
+			if clientSecret == "" {
+				return nil, fmt.Errorf("no ClientSecret provided, please pass an ClientSecret for authorization through NewAdminClientParams or set the PINECONE_CLIENT_SECRET environment variable")
+			}
+			tokenSource = &clientCredentialsTokenSource{clientId: clientId, clientSecret: clientSecret, opts: clientOptions}
 		}
+	}
 
-		authToken, err := getAuthTokenFunc(ctx, clientId, clientSecret, clientOptions...)
-		if err != nil {
-			return nil, err
-		}
-		authHeader = fmt.Sprintf("Bearer %s", authToken)
+	// Authenticate up front so invalid credentials fail here rather than on the first request.
+	tokens := newReuseTokenSource(tokenSource)
+	if _, err := tokens.token(ctx); err != nil {
+		return nil, err
 	}
 
 	hostOverride := valueOrFallback(in.Host, os.Getenv("PINECONE_CONTROLLER_HOST"))
@@ -284,8 +294,7 @@ func NewAdminClientWithContext(ctx context.Context, in NewAdminClientParams) (*A
 		}
 	}
 
-	authProvider := provider.NewHeaderProvider("Authorization", authHeader)
-	clientOptions = append(clientOptions, admin.WithRequestEditorFn(authProvider.Intercept))
+	clientOptions = append(clientOptions, admin.WithHTTPClient(newTokenHTTPClient(tokens, in.RestClient)))
 
 	adminClient, err := newAdminClient(valueOrFallback(hostOverride, "https://api.pinecone.io"), clientOptions...)
 	if err != nil {
@@ -1864,18 +1873,18 @@ type authTokenResponse struct {
 	Scope       string `json:"scope"`
 }
 
-func getAuthToken(ctx context.Context, clientId string, clientSecret string, opts ...admin.ClientOption) (string, error) {
+func getAuthToken(ctx context.Context, clientId string, clientSecret string, opts ...admin.ClientOption) (*Token, error) {
 	// build REST client for retrieving token
 	authServer := "https://login.pinecone.io"
 	tokenClient, err := admin.NewClient(authServer, opts...)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
 	// build authentication request
 	serverURL, err := url.Parse(authServer)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
 	operationPath := "/oauth/token"
@@ -1885,7 +1894,7 @@ func getAuthToken(ctx context.Context, clientId string, clientSecret string, opt
 
 	queryURL, err := serverURL.Parse(operationPath)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
 	bodyMap := map[string]string{
@@ -1897,13 +1906,13 @@ func getAuthToken(ctx context.Context, clientId string, clientSecret string, opt
 
 	body, err := json.Marshal(bodyMap)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
 	bodyReader := bytes.NewReader(body)
 	req, err := http.NewRequest("POST", queryURL.String(), bodyReader)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
 	req.Header.Set("Content-Type", "application/json")
@@ -1911,21 +1920,25 @@ func getAuthToken(ctx context.Context, clientId string, clientSecret string, opt
 
 	res, err := tokenClient.Client.Do(req)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 	defer res.Body.Close()
 
 	if res.StatusCode != http.StatusOK {
-		return "", handleErrorResponseBody(res, "failed to get auth token: %s")
+		return nil, handleErrorResponseBody(res, "failed to get auth token: %s")
 	}
 
 	var tokenResponse authTokenResponse
 	err = json.NewDecoder(res.Body).Decode(&tokenResponse)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
-	return tokenResponse.AccessToken, nil
+	token := &Token{AccessToken: tokenResponse.AccessToken}
+	if tokenResponse.ExpiresIn > 0 {
+		token.Expiry = time.Now().Add(time.Duration(tokenResponse.ExpiresIn) * time.Second)
+	}
+	return token, nil
 }
 
 func buildAdminClientOptions(in NewAdminClientParams) []admin.ClientOption {
@@ -499,10 +499,10 @@ func TestNewAdminClientWithContextUnit(t *testing.T) {
 		defer func() { newAdminClient = admin.NewClient }()
 
 		// mock getAuthToken
-		getAuthTokenFunc = func(ctx context.Context, id, secret string, opts ...admin.ClientOption) (string, error) {
+		getAuthTokenFunc = func(ctx context.Context, id, secret string, opts ...admin.ClientOption) (*Token, error) {
 			assert.Equal(t, clientId, id)
 			assert.Equal(t, clientSecret, secret)
-			return "mock-token", nil
+			return &Token{AccessToken: "mock-token"}, nil
 		}
 		defer func() { getAuthTokenFunc = getAuthToken }()
 
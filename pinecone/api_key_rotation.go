@@ -0,0 +1,320 @@
+package pinecone
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+	"time"
+)
+
+// [APIKeySecretSink] receives the secret of a new API key during [RotateAPIKey], e.g. to write it to a
+// secret store that deployments read their API key from.
+type APIKeySecretSink interface {
+	// StoreAPIKey stores the new key. Rotation stops with an error, keeping the old key, if it fails.
+	StoreAPIKey(ctx context.Context, key *APIKeyWithSecret) error
+}
+
+// [APIKeySecretSinkFunc] adapts a function to an [APIKeySecretSink].
+type APIKeySecretSinkFunc func(ctx context.Context, key *APIKeyWithSecret) error
+
+// StoreAPIKey implements [APIKeySecretSink].
+func (f APIKeySecretSinkFunc) StoreAPIKey(ctx context.Context, key *APIKeyWithSecret) error {
+	return f(ctx, key)
+}
+
+// [APIKeyRotationStep] is a step of an API key rotation, reported in [APIKeyRotation].
+type APIKeyRotationStep string
+
+const (
+	// The old key is described to copy its name, project, and roles.
+	APIKeyRotationStepDescribe APIKeyRotationStep = "describe"
+	// The new key is created.
+	APIKeyRotationStepCreate APIKeyRotationStep = "create"
+	// The new key's secret is handed to the [APIKeySecretSink].
+	APIKeyRotationStepStore APIKeyRotationStep = "store"
+	// The new key is verified by listing indexes with it.
+	APIKeyRotationStepVerify APIKeyRotationStep = "verify"
+	// The grace period before deleting the old key elapses.
+	APIKeyRotationStepWait APIKeyRotationStep = "wait"
+	// The caller confirms deleting the old key.
+	APIKeyRotationStepConfirm APIKeyRotationStep = "confirm"
+	// The old key is deleted.
+	APIKeyRotationStepDelete APIKeyRotationStep = "delete"
+)
+
+// [RotateAPIKeyParams] contains parameters for rotating an API key.
+type RotateAPIKeyParams struct {
+	// The ID of the API key to rotate. Not needed when resuming a rotation.
+	ApiKeyId string
+
+	// The sink receiving the new key's secret.
+	Sink APIKeySecretSink
+
+	// (Optional) The suffix appended to the old key's name to name the new key. Defaults to "-rotated-" and the
+	// current UTC time, replacing the suffix of a previous rotation. Names are truncated to 80 characters.
+	NameSuffix string
+
+	// (Optional) Whether to verify the new key by calling [Client.ListIndexes] with it before deleting the old key.
+	Verify bool
+
+	// (Optional) The new key's secret, used to verify a rotation resumed from a saved [APIKeyRotation] after the
+	// secret was stored, e.g. read back from the sink's secret store. The secret itself is never saved in the
+	// rotation, so without VerifyKey such a rotation fails at the verify step.
+	VerifyKey string
+
+	// (Optional) How long to keep retrying verification while the new key propagates. Default is 30 seconds.
+	VerifyTimeout time.Duration
+
+	// (Optional) The parameters of the temporary [Client] used for verification, e.g. Host or RestClient.
+	// ApiKey is replaced with the new key.
+	VerifyClientParams *NewClientParams
+
+	// (Optional) How long to wait after storing and verifying the new key before deleting the old key, so that
+	// deployments can pick up the new key.
+	GracePeriod time.Duration
+
+	// (Optional) Called before deleting the old key. If it returns false, rotation stops without deleting the
+	// old key; pass the returned [APIKeyRotation] as Resume to finish it later.
+	ConfirmDelete func(ctx context.Context, rotation *APIKeyRotation) (bool, error)
+
+	// (Optional) Called after each completed step.
+	OnStep func(rotation *APIKeyRotation, step APIKeyRotationStep)
+
+	// (Optional) A rotation returned by a previous, incomplete call. Its completed steps are skipped. If the new
+	// key was created but its secret was never stored and is no longer available, the new key is deleted and
+	// created again.
+	Resume *APIKeyRotation
+}
+
+// [APIKeyRotation] reports the progress of an API key rotation.
+type APIKeyRotation struct {
+	// The key being rotated.
+	OldKey *APIKey `json:"old_key,omitempty"`
+
+	// The replacement key, once created.
+	NewKey *APIKey `json:"new_key,omitempty"`
+
+	// The steps completed so far, in order.
+	CompletedSteps []APIKeyRotationStep `json:"completed_steps"`
+
+	// The step that failed, if any.
+	FailedStep APIKeyRotationStep `json:"failed_step,omitempty"`
+
+	// Whether every step completed and the old key was deleted.
+	Done bool `json:"done"`
+
+	// The new key's secret, kept in memory until it is stored and verified so that a failed store or verify can
+	// be resumed by the same process. It is not serialized; see [RotateAPIKeyParams] VerifyKey.
+	secret *APIKeyWithSecret
+}
+
+// [APIKeyRotation.Completed] reports whether step has completed.
+func (r *APIKeyRotation) Completed(step APIKeyRotationStep) bool {
+	return slices.Contains(r.CompletedSteps, step)
+}
+
+// rotationVerifyInterval is the delay between attempts to verify a new API key.
+var rotationVerifyInterval = 2 * time.Second
+
+var rotatedNameSuffix = regexp.MustCompile(`-rotated-\d{14}$`)
+
+// [RotateAPIKey] rotates an API key: creates a new key in the same project with the same roles and a suffixed
+// name, hands its secret to the sink, optionally verifies it, and deletes the old key after the grace period or
+// confirmation.
+//
+// Every step is recorded in the returned [APIKeyRotation], which is returned alongside any error. If a step
+// fails, pass the rotation as Resume to continue from that step. Until the delete step completes, both keys
+// are valid.
+//
+// Parameters:
+//   - ctx: The request context.
+//   - keys: The [APIKeyClient] managing the key, e.g. [AdminClient] APIKey.
+//   - in: A pointer to [RotateAPIKeyParams] containing the rotation configuration.
+//
+// Returns a pointer to an [APIKeyRotation] or an error.
+//
+// Example:
+//
+//	rotation, err := pinecone.RotateAPIKey(ctx, adminClient.APIKey, &pinecone.RotateAPIKeyParams{
+//		ApiKeyId:    "api-key-id",
+//		Sink:        pinecone.APIKeySecretSinkFunc(func(ctx context.Context, key *pinecone.APIKeyWithSecret) error {
+//			return vault.Write(ctx, "pinecone/api-key", key.Value)
+//		}),
+//		Verify:      true,
+//		GracePeriod: 10 * time.Minute,
+//	})
+//	if err != nil {
+//		log.Fatalf("rotation failed at step %q: %v", rotation.FailedStep, err)
+//	}
+func RotateAPIKey(ctx context.Context, keys APIKeyClient, in *RotateAPIKeyParams) (*APIKeyRotation, error) {
+	if keys == nil {
+		return nil, fmt.Errorf("keys (APIKeyClient) cannot be nil")
+	}
+	if in == nil {
+		return nil, fmt.Errorf("in (*RotateAPIKeyParams) cannot be nil")
+	}
+	if in.Sink == nil {
+		return nil, fmt.Errorf("Sink is required to rotate an API key")
+	}
+	rotation := in.Resume
+	if rotation == nil {
+		if in.ApiKeyId == "" {
+			return nil, fmt.Errorf("ApiKeyId is required to rotate an API key")
+		}
+		rotation = &APIKeyRotation{}
+	}
+	rotation.FailedStep = ""
+
+	step := func(name APIKeyRotationStep, fn func() error) error {
+		if rotation.Completed(name) {
+			return nil
+		}
+		if err := fn(); err != nil {
+			rotation.FailedStep = name
+			return fmt.Errorf("failed to rotate api key at step %q: %w", name, err)
+		}
+		rotation.CompletedSteps = append(rotation.CompletedSteps, name)
+		if in.OnStep != nil {
+			in.OnStep(rotation, name)
+		}
+		return nil
+	}
+
+	err := step(APIKeyRotationStepDescribe, func() error {
+		oldKey, err := keys.Describe(ctx, in.ApiKeyId)
+		if err != nil {
+			return err
+		}
+		rotation.OldKey = oldKey
+		return nil
+	})
+	if err != nil {
+		return rotation, err
+	}
+
+	// A new key whose secret was lost before it was stored cannot be used: replace it.
+	if rotation.Completed(APIKeyRotationStepCreate) && !rotation.Completed(APIKeyRotationStepStore) && rotation.secret == nil {
+		if rotation.NewKey != nil {
+			if err := keys.Delete(ctx, rotation.NewKey.Id); err != nil {
+				rotation.FailedStep = APIKeyRotationStepCreate
+				return rotation, fmt.Errorf("failed to delete api key %q, whose secret was not stored: %w", rotation.NewKey.Id, err)
+			}
+		}
+		rotation.NewKey = nil
+		rotation.CompletedSteps = slices.DeleteFunc(rotation.CompletedSteps, func(s APIKeyRotationStep) bool { return s == APIKeyRotationStepCreate })
+	}
+
+	err = step(APIKeyRotationStepCreate, func() error {
+		roles := slices.Clone(rotation.OldKey.Roles)
+		created, err := keys.Create(ctx, rotation.OldKey.ProjectId, &CreateAPIKeyParams{
+			Name:  rotatedAPIKeyName(rotation.OldKey.Name, in.NameSuffix, time.Now()),
+			Roles: &roles,
+		})
+		if err != nil {
+			return err
+		}
+		rotation.NewKey = &created.Key
+		rotation.secret = created
+		return nil
+	})
+	if err != nil {
+		return rotation, err
+	}
+
+	err = step(APIKeyRotationStepStore, func() error {
+		return in.Sink.StoreAPIKey(ctx, rotation.secret)
+	})
+	if err != nil {
+		return rotation, err
+	}
+
+	if in.Verify {
+		err = step(APIKeyRotationStepVerify, func() error {
+			secret := in.VerifyKey
+			if rotation.secret != nil {
+				secret = rotation.secret.Value
+			}
+			if secret == "" {
+				return fmt.Errorf("the secret of api key %q is no longer available to verify it, pass it as VerifyKey", rotation.NewKey.Id)
+			}
+			return verifyAPIKey(ctx, secret, in)
+		})
+		if err != nil {
+			return rotation, err
+		}
+	}
+	rotation.secret = nil
+
+	if in.GracePeriod > 0 {
+		err = step(APIKeyRotationStepWait, func() error {
+			if !wait(ctx, in.GracePeriod) {
+				return ctx.Err()
+			}
+			return nil
+		})
+		if err != nil {
+			return rotation, err
+		}
+	}
+
+	if in.ConfirmDelete != nil && !rotation.Completed(APIKeyRotationStepConfirm) {
+		confirmed, err := in.ConfirmDelete(ctx, rotation)
+		if err != nil {
+			rotation.FailedStep = APIKeyRotationStepConfirm
+			return rotation, fmt.Errorf("failed to rotate api key at step %q: %w", APIKeyRotationStepConfirm, err)
+		}
+		if !confirmed {
+			return rotation, nil
+		}
+		_ = step(APIKeyRotationStepConfirm, func() error { return nil })
+	}
+
+	err = step(APIKeyRotationStepDelete, func() error {
+		return keys.Delete(ctx, rotation.OldKey.Id)
+	})
+	if err != nil {
+		return rotation, err
+	}
+	rotation.Done = true
+	return rotation, nil
+}
+
+// rotatedAPIKeyName returns the name of the key replacing a key named name, at most 80 characters long.
+func rotatedAPIKeyName(name, suffix string, now time.Time) string {
+	if suffix == "" {
+		name = rotatedNameSuffix.ReplaceAllString(name, "")
+		suffix = "-rotated-" + now.UTC().Format("20060102150405")
+	}
+	if len(name)+len(suffix) > 80 {
+		name = name[:max(0, 80-len(suffix))]
+	}
+	return name + suffix
+}
+
+// verifyAPIKey lists indexes with apiKey, retrying until in.VerifyTimeout while the key propagates.
+func verifyAPIKey(ctx context.Context, apiKey string, in *RotateAPIKeyParams) error {
+	params := NewClientParams{}
+	if in.VerifyClientParams != nil {
+		params = *in.VerifyClientParams
+		params.Headers = maps.Clone(params.Headers)
+	}
+	params.ApiKey = apiKey
+	pc, err := NewClient(params)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, valueOrFallback(in.VerifyTimeout, 30*time.Second))
+	defer cancel()
+	for {
+		_, err := pc.ListIndexes(ctx)
+		if err == nil {
+			return nil
+		}
+		if !wait(ctx, rotationVerifyInterval) {
+			return fmt.Errorf("new api key could not be verified: %w", err)
+		}
+	}
+}
@@ -0,0 +1,261 @@
+package pinecone
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeAPIKeyClient is an in-memory [APIKeyClient]. Calls fail with failOn's error while it is set.
+type fakeAPIKeyClient struct {
+	keys    map[string]*APIKey
+	created int
+	calls   []string
+	failOn  map[string]error
+}
+
+func newFakeAPIKeyClient(keys ...*APIKey) *fakeAPIKeyClient {
+	f := &fakeAPIKeyClient{keys: map[string]*APIKey{}, failOn: map[string]error{}}
+	for _, key := range keys {
+		f.keys[key.Id] = key
+	}
+	return f
+}
+
+func (f *fakeAPIKeyClient) call(method, id string) error {
+	f.calls = append(f.calls, method+" "+id)
+	return f.failOn[method]
+}
+
+func (f *fakeAPIKeyClient) Create(ctx context.Context, projectId string, in *CreateAPIKeyParams) (*APIKeyWithSecret, error) {
+	if err := f.call("Create", in.Name); err != nil {
+		return nil, err
+	}
+	f.created++
+	key := &APIKey{Id: fmt.Sprintf("new-%d", f.created), Name: in.Name, ProjectId: projectId, Roles: *in.Roles}
+	f.keys[key.Id] = key
+	return &APIKeyWithSecret{Key: *key, Value: "pckey_" + key.Id}, nil
+}
+
+func (f *fakeAPIKeyClient) Update(ctx context.Context, apiKeyId string, in *UpdateAPIKeyParams) (*APIKey, error) {
+	return nil, fmt.Errorf("not implemented")
+}
+
+func (f *fakeAPIKeyClient) List(ctx context.Context, projectId string) ([]*APIKey, error) {
+	return nil, fmt.Errorf("not implemented")
+}
+
+func (f *fakeAPIKeyClient) Describe(ctx context.Context, apiKeyId string) (*APIKey, error) {
+	if err := f.call("Describe", apiKeyId); err != nil {
+		return nil, err
+	}
+	key, ok := f.keys[apiKeyId]
+	if !ok {
+		return nil, &PineconeError{Code: 404, Msg: fmt.Errorf("api key %s not found", apiKeyId)}
+	}
+	return key, nil
+}
+
+func (f *fakeAPIKeyClient) Delete(ctx context.Context, apiKeyId string) error {
+	if err := f.call("Delete", apiKeyId); err != nil {
+		return err
+	}
+	delete(f.keys, apiKeyId)
+	return nil
+}
+
+func oldAPIKey() *APIKey {
+	return &APIKey{Id: "old", Name: "ingest-rotated-20250101000000", ProjectId: "project", Roles: []string{"DataPlaneEditor"}}
+}
+
+// Unit tests:
+func TestRotateAPIKeyUnit(t *testing.T) {
+	keys := newFakeAPIKeyClient(oldAPIKey())
+	var stored []string
+	var reported []APIKeyRotationStep
+
+	rotation, err := RotateAPIKey(context.Background(), keys, &RotateAPIKeyParams{
+		ApiKeyId: "old",
+		Sink: APIKeySecretSinkFunc(func(ctx context.Context, key *APIKeyWithSecret) error {
+			stored = append(stored, key.Value)
+			return nil
+		}),
+		OnStep: func(rotation *APIKeyRotation, step APIKeyRotationStep) { reported = append(reported, step) },
+	})
+	require.NoError(t, err)
+	assert.True(t, rotation.Done)
+	assert.Equal(t, []string{"pckey_new-1"}, stored)
+	assert.Equal(t, []APIKeyRotationStep{APIKeyRotationStepDescribe, APIKeyRotationStepCreate, APIKeyRotationStepStore, APIKeyRotationStepDelete}, reported)
+	assert.Equal(t, rotation.CompletedSteps, reported)
+
+	require.NotNil(t, rotation.NewKey)
+	assert.Equal(t, []string{"DataPlaneEditor"}, rotation.NewKey.Roles)
+	assert.Equal(t, "project", rotation.NewKey.ProjectId)
+	assert.Regexp(t, `^ingest-rotated-\d{14}$`, rotation.NewKey.Name, "the previous rotation suffix should be replaced")
+	assert.NotContains(t, keys.keys, "old")
+}
+
+func TestRotateAPIKeyResumeUnit(t *testing.T) {
+	keys := newFakeAPIKeyClient(oldAPIKey())
+	sinkErr := fmt.Errorf("vault sealed")
+	sink := APIKeySecretSinkFunc(func(ctx context.Context, key *APIKeyWithSecret) error { return sinkErr })
+
+	rotation, err := RotateAPIKey(context.Background(), keys, &RotateAPIKeyParams{ApiKeyId: "old", Sink: sink, NameSuffix: "-v2"})
+	require.ErrorIs(t, err, sinkErr)
+	assert.Equal(t, APIKeyRotationStepStore, rotation.FailedStep)
+	assert.Equal(t, "ingest-rotated-20250101000000-v2", rotation.NewKey.Name)
+	assert.Contains(t, keys.keys, "old", "the old key should be kept when the rotation fails")
+
+	// Resumed in the same process, the secret is still available and the new key is reused.
+	sinkErr = nil
+	rotation, err = RotateAPIKey(context.Background(), keys, &RotateAPIKeyParams{Sink: sink, Resume: rotation})
+	require.NoError(t, err)
+	assert.True(t, rotation.Done)
+	assert.Equal(t, 1, keys.created)
+
+	// Resumed from a saved report, the new key's secret is lost, so the key is replaced.
+	keys = newFakeAPIKeyClient(oldAPIKey(), &APIKey{Id: "new-1"})
+	keys.created = 1
+	saved := &APIKeyRotation{OldKey: oldAPIKey(), NewKey: &APIKey{Id: "new-1"}, CompletedSteps: []APIKeyRotationStep{APIKeyRotationStepDescribe, APIKeyRotationStepCreate}}
+	rotation, err = RotateAPIKey(context.Background(), keys, &RotateAPIKeyParams{Sink: sink, Resume: saved})
+	require.NoError(t, err)
+	assert.Equal(t, "new-2", rotation.NewKey.Id)
+	assert.Contains(t, keys.calls, "Delete new-1")
+	assert.Equal(t, []string{"new-2"}, keysOf(keys.keys))
+}
+
+func TestRotateAPIKeyConfirmAndGracePeriodUnit(t *testing.T) {
+	keys := newFakeAPIKeyClient(oldAPIKey())
+	sink := APIKeySecretSinkFunc(func(ctx context.Context, key *APIKeyWithSecret) error { return nil })
+	confirm := false
+
+	params := &RotateAPIKeyParams{
+		ApiKeyId:      "old",
+		Sink:          sink,
+		GracePeriod:   time.Millisecond,
+		ConfirmDelete: func(ctx context.Context, rotation *APIKeyRotation) (bool, error) { return confirm, nil },
+	}
+	rotation, err := RotateAPIKey(context.Background(), keys, params)
+	require.NoError(t, err)
+	assert.False(t, rotation.Done)
+	assert.True(t, rotation.Completed(APIKeyRotationStepWait))
+	assert.Contains(t, keys.keys, "old", "the old key should be kept until deletion is confirmed")
+
+	confirm = true
+	params.Resume = rotation
+	rotation, err = RotateAPIKey(context.Background(), keys, params)
+	require.NoError(t, err)
+	assert.True(t, rotation.Done)
+	assert.Equal(t, []APIKeyRotationStep{APIKeyRotationStepDescribe, APIKeyRotationStepCreate, APIKeyRotationStepStore, APIKeyRotationStepWait, APIKeyRotationStepConfirm, APIKeyRotationStepDelete}, rotation.CompletedSteps)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	keys = newFakeAPIKeyClient(oldAPIKey())
+	rotation, err = RotateAPIKey(ctx, keys, &RotateAPIKeyParams{ApiKeyId: "old", Sink: sink, GracePeriod: time.Hour})
+	require.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, APIKeyRotationStepWait, rotation.FailedStep)
+}
+
+func TestRotateAPIKeyVerifyUnit(t *testing.T) {
+	previous := rotationVerifyInterval
+	rotationVerifyInterval = time.Millisecond
+	defer func() { rotationVerifyInterval = previous }()
+
+	var attempts int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if r.Header.Get("Api-Key") != "pckey_new-1" || attempts < 3 {
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte(`{"error":{"code":"UNAUTHENTICATED","message":"invalid key"},"status":401}`))
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"indexes":[]}`))
+	}))
+	defer srv.Close()
+
+	keys := newFakeAPIKeyClient(oldAPIKey())
+	rotation, err := RotateAPIKey(context.Background(), keys, &RotateAPIKeyParams{
+		ApiKeyId:           "old",
+		Sink:               APIKeySecretSinkFunc(func(ctx context.Context, key *APIKeyWithSecret) error { return nil }),
+		Verify:             true,
+		VerifyClientParams: &NewClientParams{Host: srv.URL},
+	})
+	require.NoError(t, err)
+	assert.True(t, rotation.Completed(APIKeyRotationStepVerify))
+	assert.Equal(t, 3, attempts, "verification should be retried while the new key propagates")
+
+	keys = newFakeAPIKeyClient(oldAPIKey())
+	attempts = -100
+	rotation, err = RotateAPIKey(context.Background(), keys, &RotateAPIKeyParams{
+		ApiKeyId:           "old",
+		Sink:               APIKeySecretSinkFunc(func(ctx context.Context, key *APIKeyWithSecret) error { return nil }),
+		Verify:             true,
+		VerifyTimeout:      20 * time.Millisecond,
+		VerifyClientParams: &NewClientParams{Host: srv.URL},
+	})
+	require.Error(t, err)
+	assert.Equal(t, APIKeyRotationStepVerify, rotation.FailedStep)
+	assert.Contains(t, keys.keys, "old")
+}
+
+func TestRotatedAPIKeyNameUnit(t *testing.T) {
+	now := time.Date(2026, 3, 4, 5, 6, 7, 0, time.UTC)
+	assert.Equal(t, "key-rotated-20260304050607", rotatedAPIKeyName("key", "", now))
+	assert.Equal(t, "key-rotated-20260304050607", rotatedAPIKeyName("key-rotated-20250101000000", "", now))
+	long := rotatedAPIKeyName(strings.Repeat("a", 80), "-next", now)
+	assert.Len(t, long, 80)
+	assert.True(t, strings.HasSuffix(long, "-next"))
+}
+
+func keysOf(m map[string]*APIKey) []string {
+	var keys []string
+	for key := range m {
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+func TestRotateAPIKeyVerifyResumedUnit(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Api-Key") != "pckey_new-1" {
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte(`{"error":{"code":"UNAUTHENTICATED","message":"invalid key"},"status":401}`))
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"indexes":[]}`))
+	}))
+	defer srv.Close()
+
+	// A saved rotation that stored the new key but stopped before verifying it.
+	saved := func() *APIKeyRotation {
+		return &APIKeyRotation{OldKey: oldAPIKey(), NewKey: &APIKey{Id: "new-1"}, CompletedSteps: []APIKeyRotationStep{
+			APIKeyRotationStepDescribe, APIKeyRotationStepCreate, APIKeyRotationStepStore,
+		}}
+	}
+	sink := APIKeySecretSinkFunc(func(ctx context.Context, key *APIKeyWithSecret) error { return nil })
+
+	keys := newFakeAPIKeyClient(oldAPIKey(), &APIKey{Id: "new-1"})
+	rotation, err := RotateAPIKey(context.Background(), keys, &RotateAPIKeyParams{
+		Sink: sink, Verify: true, VerifyClientParams: &NewClientParams{Host: srv.URL}, Resume: saved(),
+	})
+	require.ErrorContains(t, err, "VerifyKey")
+	assert.Equal(t, APIKeyRotationStepVerify, rotation.FailedStep)
+	assert.Contains(t, keys.keys, "old")
+
+	rotation, err = RotateAPIKey(context.Background(), keys, &RotateAPIKeyParams{
+		Sink: sink, Verify: true, VerifyKey: "pckey_new-1", VerifyClientParams: &NewClientParams{Host: srv.URL}, Resume: saved(),
+	})
+	require.NoError(t, err)
+	assert.True(t, rotation.Done)
+	assert.NotContains(t, keys.keys, "old")
+	assert.Contains(t, keys.keys, "new-1", "the stored key should be kept")
+}
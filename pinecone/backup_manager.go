@@ -0,0 +1,325 @@
+package pinecone
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"slices"
+	"strings"
+	"time"
+)
+
+// [BackupRetentionPolicy] decides which of an index's managed backups a [BackupManager] keeps. A backup is kept
+// if any rule keeps it; the rest are deleted.
+//
+// Fields:
+//   - KeepLast: (Optional) The number of most recent backups to keep.
+//   - KeepDaily: (Optional) The number of days, counting today, for which the newest backup of each day is kept.
+//   - KeepWeekly: (Optional) The number of ISO weeks, counting this week, for which the newest backup of each
+//     week is kept.
+//
+// At least one field must be positive. Days and weeks are computed in UTC.
+type BackupRetentionPolicy struct {
+	KeepLast   int
+	KeepDaily  int
+	KeepWeekly int
+}
+
+// [BackupLease] coordinates [BackupManager] replicas so that only one of them backs up and prunes at a time.
+// Implement it with a store every replica can reach, e.g. a database row, a Redis key, or a Kubernetes Lease.
+type BackupLease interface {
+	// Acquire takes the lease for holder until ttl elapses, or extends it if holder already has it. It reports
+	// false if another holder has the lease.
+	Acquire(ctx context.Context, holder string, ttl time.Duration) (bool, error)
+
+	// Release gives up the lease if holder has it.
+	Release(ctx context.Context, holder string) error
+}
+
+// [BackupManagerParams] contains parameters for [NewBackupManager].
+//
+// Fields:
+//   - Policies: (Required) The retention policy of each index to back up, by index name.
+//   - Interval: (Optional) How often each index is backed up. Default is 24 hours.
+//   - NamePrefix: (Optional) The prefix of the names of managed backups. Only backups whose name starts with
+//     the prefix and the index name are pruned. Default is "managed".
+//   - Lease: (Optional) The [BackupLease] shared by replicas. Without it, replicas running at the same time
+//     may back up an index more than once.
+//   - LeaseTTL: (Optional) How long the lease is held for a run. Default is 10 minutes.
+//   - Holder: (Optional) This replica's name in the lease. Defaults to the host name and a random suffix.
+//   - OnRun: (Optional) Called with the result of each run started by [BackupManager.Run].
+type BackupManagerParams struct {
+	Policies   map[string]BackupRetentionPolicy
+	Interval   time.Duration
+	NamePrefix string
+	Lease      BackupLease
+	LeaseTTL   time.Duration
+	Holder     string
+	OnRun      func(report *BackupRunReport, err error)
+}
+
+// [BackupManager] takes scheduled backups of serverless indexes and deletes the backups their
+// [BackupRetentionPolicy] no longer keeps. Create one with [NewBackupManager], then call
+// [BackupManager.RunOnce] from a scheduler or [BackupManager.Run] to run on a ticker.
+type BackupManager struct {
+	client *Client
+	params BackupManagerParams
+	now    func() time.Time
+}
+
+// [BackupRunReport] is the result of a [BackupManager] run.
+//
+// Fields:
+//   - Skipped: Whether the run was skipped because another replica holds the [BackupLease].
+//   - Indexes: The result for each index, in name order.
+type BackupRunReport struct {
+	Skipped bool                 `json:"skipped"`
+	Indexes []*IndexBackupResult `json:"indexes,omitempty"`
+}
+
+// [IndexBackupResult] is the result of a [BackupManager] run for one index.
+//
+// Fields:
+//   - IndexName: The name of the index.
+//   - Created: The backup taken in this run, or nil if the newest managed backup is recent enough.
+//   - Deleted: The IDs of the backups deleted in this run.
+//   - Kept: The number of managed backups kept.
+//   - Error: The error that stopped the run for this index, if any.
+type IndexBackupResult struct {
+	IndexName string   `json:"index_name"`
+	Created   *Backup  `json:"created,omitempty"`
+	Deleted   []string `json:"deleted,omitempty"`
+	Kept      int      `json:"kept"`
+	Error     error    `json:"-"`
+}
+
+// [NewBackupManager] returns a [BackupManager] backing up indexes with client.
+//
+// Parameters:
+//   - client: (Required) The [Client] of the project holding the indexes.
+//   - in: The [BackupManagerParams] of the manager.
+//
+// Returns a pointer to a [BackupManager] or an error if a policy keeps nothing.
+//
+// Example:
+//
+//	manager, err := pinecone.NewBackupManager(pc, pinecone.BackupManagerParams{
+//		Policies: map[string]pinecone.BackupRetentionPolicy{
+//			"my-index": {KeepLast: 3, KeepDaily: 7, KeepWeekly: 4},
+//		},
+//		Lease: lease,
+//	})
+//	if err != nil {
+//		log.Fatalf("Failed to create BackupManager: %v", err)
+//	}
+//	if err := manager.Run(ctx); err != nil && !errors.Is(err, context.Canceled) {
+//		log.Fatal(err)
+//	}
+func NewBackupManager(client *Client, in BackupManagerParams) (*BackupManager, error) {
+	if client == nil {
+		return nil, fmt.Errorf("client is required to create a BackupManager")
+	}
+	if len(in.Policies) == 0 {
+		return nil, fmt.Errorf("Policies is required to create a BackupManager")
+	}
+	for name, policy := range in.Policies {
+		if policy.KeepLast < 0 || policy.KeepDaily < 0 || policy.KeepWeekly < 0 {
+			return nil, fmt.Errorf("invalid retention policy for index %q: values cannot be negative", name)
+		}
+		if policy.KeepLast == 0 && policy.KeepDaily == 0 && policy.KeepWeekly == 0 {
+			return nil, fmt.Errorf("invalid retention policy for index %q: it must keep at least one backup", name)
+		}
+	}
+	in.Policies = maps.Clone(in.Policies)
+	in.Interval = valueOrFallback(in.Interval, 24*time.Hour)
+	in.NamePrefix = valueOrFallback(in.NamePrefix, "managed")
+	in.LeaseTTL = valueOrFallback(in.LeaseTTL, 10*time.Minute)
+	if in.Holder == "" {
+		in.Holder = defaultLeaseHolder()
+	}
+	return &BackupManager{client: client, params: in, now: time.Now}, nil
+}
+
+// [BackupManager.RunOnce] backs up each index whose newest managed backup is older than the interval, then
+// deletes the managed backups its policy no longer keeps. Backups still initializing are never deleted;
+// failed backups are always deleted. With a [BackupLease], the run is skipped if another replica holds it.
+//
+// The run continues past an index that fails; its error is recorded in the report and joined into the
+// returned error.
+//
+// Parameters:
+//   - ctx: A context.Context object controls the request's lifetime, allowing for the request
+//     to be canceled or to timeout according to the context's deadline.
+//
+// Returns a pointer to a [BackupRunReport], alongside an error if any index failed.
+func (m *BackupManager) RunOnce(ctx context.Context) (*BackupRunReport, error) {
+	report := &BackupRunReport{}
+	if lease := m.params.Lease; lease != nil {
+		acquired, err := lease.Acquire(ctx, m.params.Holder, m.params.LeaseTTL)
+		if err != nil {
+			return report, fmt.Errorf("failed to acquire backup lease: %w", err)
+		}
+		if !acquired {
+			report.Skipped = true
+			return report, nil
+		}
+		defer func() { _ = lease.Release(context.WithoutCancel(ctx), m.params.Holder) }()
+	}
+
+	var errs []error
+	for _, indexName := range slices.Sorted(maps.Keys(m.params.Policies)) {
+		result := &IndexBackupResult{IndexName: indexName}
+		report.Indexes = append(report.Indexes, result)
+		if err := m.runIndex(ctx, result); err != nil {
+			result.Error = err
+			errs = append(errs, fmt.Errorf("failed to manage backups of index %q: %w", indexName, err))
+		}
+	}
+	return report, errors.Join(errs...)
+}
+
+// [BackupManager.Run] calls [BackupManager.RunOnce] immediately and then every interval until ctx is done,
+// reporting each run to OnRun. Replicas may all call Run; with a [BackupLease], one of them backs up each
+// interval, and an index is never backed up twice within an interval.
+//
+// Parameters:
+//   - ctx: A context.Context object that stops the loop when it is done.
+//
+// Returns ctx.Err() once ctx is done.
+func (m *BackupManager) Run(ctx context.Context) error {
+	ticker := time.NewTicker(m.params.Interval)
+	defer ticker.Stop()
+	for {
+		report, err := m.RunOnce(ctx)
+		if m.params.OnRun != nil {
+			m.params.OnRun(report, err)
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+func (m *BackupManager) runIndex(ctx context.Context, result *IndexBackupResult) error {
+	backups, err := m.managedBackups(ctx, result.IndexName)
+	if err != nil {
+		return err
+	}
+
+	now := m.now().UTC()
+	// A tenth of the interval absorbs ticker jitter, so a backup taken on the previous tick counts as recent.
+	dueBefore := now.Add(-m.params.Interval * 9 / 10)
+	due := true
+	for _, backup := range backups {
+		if backup.createdAt.After(dueBefore) && backup.Status != "Failed" {
+			due = false
+			break
+		}
+	}
+	if due {
+		name := fmt.Sprintf("%s-%s-%s", m.params.NamePrefix, result.IndexName, now.Format("20060102-150405"))
+		description := fmt.Sprintf("Scheduled backup of %s taken by BackupManager at %s", result.IndexName, now.Format(time.RFC3339))
+		created, err := m.client.CreateBackup(ctx, &CreateBackupParams{IndexName: result.IndexName, Name: &name, Description: &description})
+		if err != nil {
+			return err
+		}
+		result.Created = created
+		backups = append(backups, managedBackup{Backup: created, createdAt: now})
+	}
+
+	keep := retainedBackups(backups, m.params.Policies[result.IndexName], now)
+	for _, backup := range backups {
+		if keep[backup.BackupId] || backup.Status != "Ready" && backup.Status != "Failed" {
+			result.Kept++
+			continue
+		}
+		if err := m.client.DeleteBackup(ctx, backup.BackupId); err != nil {
+			return err
+		}
+		result.Deleted = append(result.Deleted, backup.BackupId)
+	}
+	return nil
+}
+
+// managedBackup is a backup taken by a [BackupManager], with its parsed creation time.
+type managedBackup struct {
+	*Backup
+	createdAt time.Time
+}
+
+// managedBackups lists the backups of indexName named by the manager, newest first. Backups whose creation
+// time cannot be parsed are left out, so they are never deleted.
+func (m *BackupManager) managedBackups(ctx context.Context, indexName string) ([]managedBackup, error) {
+	prefix := fmt.Sprintf("%s-%s-", m.params.NamePrefix, indexName)
+	all, err := listAllPages(func(token *string) ([]*Backup, *Pagination, error) {
+		list, err := m.client.ListBackups(ctx, &ListBackupsParams{IndexName: &indexName, PaginationToken: token})
+		if err != nil {
+			return nil, nil, err
+		}
+		return list.Data, list.Pagination, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var backups []managedBackup
+	for _, backup := range all {
+		if backup.Name == nil || !strings.HasPrefix(*backup.Name, prefix) || backup.CreatedAt == nil {
+			continue
+		}
+		createdAt, err := time.Parse(time.RFC3339, *backup.CreatedAt)
+		if err != nil {
+			continue
+		}
+		backups = append(backups, managedBackup{Backup: backup, createdAt: createdAt.UTC()})
+	}
+	slices.SortFunc(backups, func(a, b managedBackup) int { return b.createdAt.Compare(a.createdAt) })
+	return backups, nil
+}
+
+// retainedBackups returns the IDs of the ready backups that policy keeps. backups must be sorted newest first.
+func retainedBackups(backups []managedBackup, policy BackupRetentionPolicy, now time.Time) map[string]bool {
+	keep := map[string]bool{}
+	last := 0
+	days := map[string]bool{}
+	weeks := map[string]bool{}
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	thisWeek := today.AddDate(0, 0, -((int(today.Weekday()) + 6) % 7))
+
+	for _, backup := range backups {
+		if backup.Status != "Ready" {
+			continue
+		}
+		if last < policy.KeepLast {
+			keep[backup.BackupId] = true
+			last++
+		}
+		if day := backup.createdAt.Format(time.DateOnly); !days[day] && !backup.createdAt.Before(today.AddDate(0, 0, 1-policy.KeepDaily)) {
+			days[day] = true
+			keep[backup.BackupId] = true
+		}
+		year, week := backup.createdAt.ISOWeek()
+		if key := fmt.Sprintf("%d-%d", year, week); !weeks[key] && !backup.createdAt.Before(thisWeek.AddDate(0, 0, 7-7*policy.KeepWeekly)) {
+			weeks[key] = true
+			keep[backup.BackupId] = true
+		}
+	}
+	return keep
+}
+
+// defaultLeaseHolder names this process in a [BackupLease].
+func defaultLeaseHolder() string {
+	host, err := os.Hostname()
+	if err != nil {
+		host = "backup-manager"
+	}
+	suffix := make([]byte, 4)
+	_, _ = rand.Read(suffix)
+	return host + "-" + hex.EncodeToString(suffix)
+}
@@ -0,0 +1,172 @@
+package pinecone
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func fakeBackup(id, name, status string, createdAt time.Time) map[string]any {
+	return map[string]any{"backup_id": id, "name": name, "source_index_name": "docs", "source_index_id": "id", "status": status, "cloud": "aws", "region": "us-east-1", "created_at": createdAt.Format(time.RFC3339)}
+}
+
+type fakeBackupLease struct {
+	mu       sync.Mutex
+	holder   string
+	released int
+}
+
+func (l *fakeBackupLease) Acquire(ctx context.Context, holder string, ttl time.Duration) (bool, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.holder != "" && l.holder != holder {
+		return false, nil
+	}
+	l.holder = holder
+	return true, nil
+}
+
+func (l *fakeBackupLease) Release(ctx context.Context, holder string) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.holder == holder {
+		l.holder = ""
+		l.released++
+	}
+	return nil
+}
+
+// Unit tests:
+func TestNewBackupManagerValidatesPoliciesUnit(t *testing.T) {
+	client := &Client{}
+	_, err := NewBackupManager(client, BackupManagerParams{})
+	assert.ErrorContains(t, err, "Policies is required")
+
+	_, err = NewBackupManager(client, BackupManagerParams{Policies: map[string]BackupRetentionPolicy{"docs": {}}})
+	assert.ErrorContains(t, err, "must keep at least one backup")
+
+	_, err = NewBackupManager(client, BackupManagerParams{Policies: map[string]BackupRetentionPolicy{"docs": {KeepLast: -1, KeepDaily: 1}}})
+	assert.ErrorContains(t, err, "cannot be negative")
+
+	manager, err := NewBackupManager(client, BackupManagerParams{Policies: map[string]BackupRetentionPolicy{"docs": {KeepLast: 1}}})
+	require.NoError(t, err)
+	assert.Equal(t, 24*time.Hour, manager.params.Interval)
+	assert.Equal(t, "managed", manager.params.NamePrefix)
+	assert.NotEmpty(t, manager.params.Holder)
+}
+
+func TestRetainedBackupsUnit(t *testing.T) {
+	// Wednesday; the ISO week started on Monday 2025-06-09.
+	now := time.Date(2025, 6, 11, 12, 0, 0, 0, time.UTC)
+	at := func(days int, hour int) time.Time {
+		return time.Date(2025, 6, 11-days, hour, 0, 0, 0, time.UTC)
+	}
+	var backups []managedBackup
+	add := func(id, status string, createdAt time.Time) {
+		backups = append(backups, managedBackup{Backup: &Backup{BackupId: id, Status: status}, createdAt: createdAt})
+	}
+	add("today-late", "Ready", at(0, 11))
+	add("today-early", "Ready", at(0, 1))
+	add("yesterday", "Ready", at(1, 6))
+	add("failed", "Failed", at(2, 6))
+	add("last-week", "Ready", at(3, 6))
+	add("two-weeks", "Ready", at(10, 6))
+	add("old", "Ready", at(40, 6))
+
+	keep := retainedBackups(backups, BackupRetentionPolicy{KeepLast: 1}, now)
+	assert.Equal(t, map[string]bool{"today-late": true}, keep)
+
+	keep = retainedBackups(backups, BackupRetentionPolicy{KeepDaily: 2}, now)
+	assert.Equal(t, map[string]bool{"today-late": true, "yesterday": true}, keep)
+
+	// 2025-06-08 is a Sunday, so "last-week" and "two-weeks" fall in the two previous ISO weeks.
+	keep = retainedBackups(backups, BackupRetentionPolicy{KeepWeekly: 3}, now)
+	assert.Equal(t, map[string]bool{"today-late": true, "last-week": true, "two-weeks": true}, keep)
+
+	keep = retainedBackups(backups, BackupRetentionPolicy{KeepLast: 2, KeepDaily: 1, KeepWeekly: 2}, now)
+	assert.Equal(t, map[string]bool{"today-late": true, "today-early": true, "last-week": true}, keep)
+}
+
+func TestBackupManagerRunOnceUnit(t *testing.T) {
+	now := time.Now().UTC().Truncate(time.Second)
+	server := newFakeControlPlane(t)
+	server.addBackups(
+		fakeBackup("b-recent", "managed-docs-recent", "Ready", now.Add(-2*24*time.Hour)),
+		fakeBackup("b-old", "managed-docs-old", "Ready", now.Add(-5*24*time.Hour)),
+		fakeBackup("b-failed", "managed-docs-failed", "Failed", now.Add(-3*24*time.Hour)),
+		fakeBackup("b-pending", "managed-docs-pending", "Initializing", now.Add(-4*24*time.Hour)),
+		fakeBackup("b-manual", "manual-docs", "Ready", now.Add(-9*24*time.Hour)),
+	)
+	lease := &fakeBackupLease{}
+	manager, err := NewBackupManager(server.client(t), BackupManagerParams{
+		Policies: map[string]BackupRetentionPolicy{"docs": {KeepLast: 1}},
+		Lease:    lease,
+	})
+	require.NoError(t, err)
+	manager.now = func() time.Time { return now }
+
+	report, err := manager.RunOnce(context.Background())
+	require.NoError(t, err)
+	assert.False(t, report.Skipped)
+	require.Len(t, report.Indexes, 1)
+	result := report.Indexes[0]
+	require.NotNil(t, result.Created)
+	assert.Equal(t, "managed-docs-"+now.Format("20060102-150405"), *result.Created.Name)
+	assert.Contains(t, *result.Created.Description, "Scheduled backup of docs")
+	assert.Equal(t, []string{"b-failed", "b-old"}, result.Deleted)
+	// The new backup and b-pending are still initializing, and b-recent is the last ready backup.
+	assert.Equal(t, 3, result.Kept)
+	assert.Equal(t, []string{"backup b-failed", "backup b-old"}, server.deleted)
+	assert.Equal(t, 1, lease.released)
+
+	// A backup taken within the interval is recent enough.
+	manager.now = func() time.Time { return now.Add(time.Hour) }
+	report, err = manager.RunOnce(context.Background())
+	require.NoError(t, err)
+	assert.Nil(t, report.Indexes[0].Created)
+}
+
+func TestBackupManagerSkipsWithoutLeaseUnit(t *testing.T) {
+	server := newFakeControlPlane(t)
+	lease := &fakeBackupLease{holder: "other-replica"}
+	manager, err := NewBackupManager(server.client(t), BackupManagerParams{
+		Policies: map[string]BackupRetentionPolicy{"docs": {KeepLast: 1}},
+		Lease:    lease,
+		Holder:   "this-replica",
+	})
+	require.NoError(t, err)
+
+	report, err := manager.RunOnce(context.Background())
+	require.NoError(t, err)
+	assert.True(t, report.Skipped)
+	assert.Empty(t, report.Indexes)
+	assert.Empty(t, server.backups)
+}
+
+func TestBackupManagerRunUnit(t *testing.T) {
+	server := newFakeControlPlane(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var runs []*BackupRunReport
+	manager, err := NewBackupManager(server.client(t), BackupManagerParams{
+		Policies: map[string]BackupRetentionPolicy{"docs": {KeepLast: 1}},
+		Interval: time.Hour,
+		OnRun: func(report *BackupRunReport, err error) {
+			assert.NoError(t, err)
+			runs = append(runs, report)
+			cancel()
+		},
+	})
+	require.NoError(t, err)
+
+	err = manager.Run(ctx)
+	assert.ErrorIs(t, err, context.Canceled)
+	require.Len(t, runs, 1)
+	assert.True(t, slices.ContainsFunc(server.backups, func(backup map[string]any) bool { return backup["source_index_name"] == "docs" }))
+}
@@ -0,0 +1,170 @@
+package pinecone
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// [CallOption] configures a single [Client], [InferenceService], or [IndexConnection] method call, without
+// changing the client or connection it is called on. Pass any number of options as the last argument:
+//
+//	res, err := idxConnection.QueryByVectorValues(ctx, req,
+//		pinecone.WithTimeout(2*time.Second),
+//		pinecone.WithHeader("X-Trace-Id", traceId),
+//		pinecone.WithNamespace("tenant-a"),
+//	)
+//
+// See [WithTimeout], [WithHeader], [WithNamespace], [WithRetryPolicy], and [WithGRPCCallOptions].
+type CallOption func(*callOptions)
+
+type callOptions struct {
+	timeout     time.Duration
+	headers     map[string]string
+	namespace   *string
+	retryPolicy *RetryPolicy
+	grpcOptions []grpc.CallOption
+}
+
+// [WithTimeout] bounds the call, including any retries, by d. It is applied on top of any deadline already set
+// on the context.
+func WithTimeout(d time.Duration) CallOption {
+	return func(o *callOptions) {
+		o.timeout = d
+	}
+}
+
+// [WithHeader] sends an additional HTTP header (or gRPC metadata entry for data plane calls) with the call.
+// A header set this way overrides the same header set in [NewClientParams.Headers] or
+// [NewIndexConnParams.AdditionalMetadata].
+func WithHeader(key, value string) CallOption {
+	return func(o *callOptions) {
+		if o.headers == nil {
+			o.headers = make(map[string]string)
+		}
+		o.headers[key] = value
+	}
+}
+
+// [WithNamespace] runs an [IndexConnection] call against namespace instead of the connection's namespace.
+// It has no effect on [Client] and [InferenceService] calls, or on methods that take a namespace argument.
+func WithNamespace(namespace string) CallOption {
+	return func(o *callOptions) {
+		o.namespace = &namespace
+	}
+}
+
+// [WithRetryPolicy] replaces the [RetryPolicy] configured in [NewClientParams] for the call. Pass
+// &RetryPolicy{} to disable retries for the call. An invalid policy causes the call to fail.
+func WithRetryPolicy(policy *RetryPolicy) CallOption {
+	return func(o *callOptions) {
+		o.retryPolicy = policy
+	}
+}
+
+// [WithGRPCCallOptions] passes gRPC call options, such as grpc.MaxCallRecvMsgSize, to the underlying RPC of an
+// [IndexConnection] call. It has no effect on calls made over REST.
+func WithGRPCCallOptions(opts ...grpc.CallOption) CallOption {
+	return func(o *callOptions) {
+		o.grpcOptions = append(o.grpcOptions, opts...)
+	}
+}
+
+type callOptionsKey struct{}
+
+// withCallOptions applies opts to ctx: the timeout bounds ctx, headers are added to the outgoing gRPC
+// metadata, and the options are stored on ctx for the REST request editor, the retry transport, and the
+// gRPC interceptor. The returned cancel func must always be called.
+func withCallOptions(ctx context.Context, opts []CallOption) (context.Context, context.CancelFunc) {
+	if len(opts) == 0 {
+		return ctx, func() {}
+	}
+	o := &callOptions{}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(o)
+		}
+	}
+
+	cancel := context.CancelFunc(func() {})
+	if o.timeout > 0 {
+		ctx, cancel = context.WithTimeout(ctx, o.timeout)
+	}
+	if len(o.headers) > 0 {
+		kv := make([]string, 0, 2*len(o.headers))
+		for key, value := range o.headers {
+			kv = append(kv, key, value)
+		}
+		ctx = metadata.AppendToOutgoingContext(ctx, kv...)
+	}
+	return context.WithValue(ctx, callOptionsKey{}, o), cancel
+}
+
+// withCallOptions applies opts to ctx like the package-level withCallOptions, and returns a copy of the
+// connection targeting the namespace set by [WithNamespace], if any.
+func (idx *IndexConnection) withCallOptions(ctx context.Context, opts []CallOption) (*IndexConnection, context.Context, context.CancelFunc) {
+	ctx, cancel := withCallOptions(ctx, opts)
+	if o := callOptionsFrom(ctx); o != nil && o.namespace != nil {
+		idx = idx.WithNamespace(*o.namespace)
+	}
+	return idx, ctx, cancel
+}
+
+// withClientCallOptions applies opts to ctx for a [Client] method that makes several calls, so that each of
+// them sends the headers and uses the retry policy of opts. [WithNamespace] is dropped: it has no effect on
+// Client calls, and must not redirect the [IndexConnection] calls the method makes.
+func withClientCallOptions(ctx context.Context, opts []CallOption) (context.Context, context.CancelFunc) {
+	ctx, cancel := withCallOptions(ctx, opts)
+	if o := callOptionsFrom(ctx); o != nil && o.namespace != nil {
+		withoutNamespace := *o
+		withoutNamespace.namespace = nil
+		ctx = context.WithValue(ctx, callOptionsKey{}, &withoutNamespace)
+	}
+	return ctx, cancel
+}
+
+func callOptionsFrom(ctx context.Context) *callOptions {
+	o, _ := ctx.Value(callOptionsKey{}).(*callOptions)
+	return o
+}
+
+// applyCallHeaders is a REST request editor setting the headers passed with [WithHeader].
+func applyCallHeaders(ctx context.Context, req *http.Request) error {
+	if o := callOptionsFrom(ctx); o != nil {
+		for key, value := range o.headers {
+			req.Header.Set(key, value)
+		}
+	}
+	return nil
+}
+
+// hasCallHeader reports whether key was passed with [WithHeader] for the call on ctx.
+func hasCallHeader(ctx context.Context, key string) bool {
+	if o := callOptionsFrom(ctx); o != nil {
+		for k := range o.headers {
+			if strings.EqualFold(k, key) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// [CallNamespace] returns the namespace set by [WithNamespace] in opts, and whether one was set. It lets other
+// [VectorStore] implementations honor [WithNamespace] the way [IndexConnection] does.
+func CallNamespace(opts ...CallOption) (string, bool) {
+	o := &callOptions{}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(o)
+		}
+	}
+	if o.namespace == nil {
+		return "", false
+	}
+	return *o.namespace, true
+}
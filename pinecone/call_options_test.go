@@ -0,0 +1,184 @@
+package pinecone
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	db_data_grpc "github.com/pinecone-io/go-pinecone/v6/internal/gen/db_data/grpc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// callOptionsVectorService records the namespace and metadata of each Fetch, failing the first `failures` calls
+// with UNAVAILABLE.
+type callOptionsVectorService struct {
+	db_data_grpc.UnimplementedVectorServiceServer
+
+	failures  int32
+	calls     int32
+	namespace string
+	md        metadata.MD
+}
+
+func (s *callOptionsVectorService) Fetch(ctx context.Context, req *db_data_grpc.FetchRequest) (*db_data_grpc.FetchResponse, error) {
+	if atomic.AddInt32(&s.calls, 1) <= s.failures {
+		return nil, status.Error(codes.Unavailable, "unavailable")
+	}
+	s.namespace = req.Namespace
+	s.md, _ = metadata.FromIncomingContext(ctx)
+	_ = grpc.SetHeader(ctx, metadata.Pairs("x-served-by", "mock"))
+	return &db_data_grpc.FetchResponse{Namespace: req.Namespace}, nil
+}
+
+func startCallOptionsServer(t *testing.T, svc *callOptionsVectorService) string {
+	t.Helper()
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	srv := grpc.NewServer()
+	db_data_grpc.RegisterVectorServiceServer(srv, svc)
+	go func() { _ = srv.Serve(lis) }()
+	t.Cleanup(srv.Stop)
+	return "http://" + lis.Addr().String()
+}
+
+// Unit tests:
+func TestCallOptionsRestHeaderAndRetryUnit(t *testing.T) {
+	var calls int32
+	var headers []http.Header
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		headers = append(headers, r.Header.Clone())
+		if atomic.AddInt32(&calls, 1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"indexes":[]}`))
+	}))
+	defer srv.Close()
+
+	// No client-wide retry policy: the per-call policy enables retries for this call only.
+	pc, err := NewClient(NewClientParams{ApiKey: "test-key", Host: srv.URL, Headers: map[string]string{"X-Tenant": "client"}})
+	require.NoError(t, err)
+
+	_, err = pc.ListIndexes(context.Background(),
+		WithHeader("X-Trace-Id", "trace-1"),
+		WithHeader("X-Tenant", "call"),
+		WithRetryPolicy(fastPolicy(2)),
+	)
+	require.NoError(t, err)
+
+	require.Len(t, headers, 2)
+	assert.Equal(t, "trace-1", headers[1].Get("X-Trace-Id"))
+	assert.Equal(t, "call", headers[1].Get("X-Tenant"), "a per-call header should override the client header")
+
+	// Without options the client is unchanged: no retries, no per-call headers.
+	atomic.StoreInt32(&calls, 0)
+	headers = nil
+	_, err = pc.ListIndexes(context.Background())
+	require.Error(t, err)
+	require.Len(t, headers, 1)
+	assert.Empty(t, headers[0].Get("X-Trace-Id"))
+	assert.Equal(t, "client", headers[0].Get("X-Tenant"))
+}
+
+func TestCallOptionsRestTimeoutUnit(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(2 * time.Second):
+		}
+	}))
+	defer srv.Close()
+
+	pc, err := NewClient(NewClientParams{ApiKey: "test-key", Host: srv.URL})
+	require.NoError(t, err)
+
+	start := time.Now()
+	_, err = pc.ListIndexes(context.Background(), WithTimeout(20*time.Millisecond))
+	require.Error(t, err)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Less(t, time.Since(start), time.Second)
+}
+
+func TestCallOptionsRestInvalidRetryPolicyUnit(t *testing.T) {
+	pc, err := NewClient(NewClientParams{ApiKey: "test-key", Host: "http://127.0.0.1:0"})
+	require.NoError(t, err)
+	_, err = pc.ListIndexes(context.Background(), WithRetryPolicy(&RetryPolicy{MaxRetries: -1}))
+	require.ErrorContains(t, err, "MaxRetries")
+}
+
+func TestCallOptionsGrpcUnit(t *testing.T) {
+	svc := &callOptionsVectorService{failures: 1}
+	host := startCallOptionsServer(t, svc)
+
+	pc, err := NewClient(NewClientParams{ApiKey: "test-key"})
+	require.NoError(t, err)
+	idx, err := pc.Index(NewIndexConnParams{Host: host, Namespace: "default-ns", AdditionalMetadata: map[string]string{"x-tenant": "conn"}})
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = idx.Close() })
+
+	var respHeader metadata.MD
+	res, err := idx.FetchVectors(context.Background(), []string{"v1"},
+		WithNamespace("tenant-ns"),
+		WithHeader("x-tenant", "call"),
+		WithHeader("x-trace-id", "trace-1"),
+		WithRetryPolicy(fastPolicy(2)),
+		WithGRPCCallOptions(grpc.Header(&respHeader)),
+		WithTimeout(5*time.Second),
+	)
+	require.NoError(t, err)
+
+	assert.Equal(t, int32(2), atomic.LoadInt32(&svc.calls), "the per-call policy should retry UNAVAILABLE")
+	assert.Equal(t, "tenant-ns", svc.namespace)
+	assert.Equal(t, "tenant-ns", res.Namespace)
+	assert.Equal(t, []string{"call"}, svc.md.Get("x-tenant"), "a per-call header should replace the connection metadata")
+	assert.Equal(t, []string{"trace-1"}, svc.md.Get("x-trace-id"))
+	assert.Equal(t, []string{"mock"}, respHeader.Get("x-served-by"), "gRPC call options should reach the RPC")
+	assert.Equal(t, "default-ns", idx.Namespace(), "the connection's namespace should be unchanged")
+
+	// Without a retry policy the same failure is returned immediately.
+	svc.failures, svc.calls = 1, 0
+	_, err = idx.FetchVectors(context.Background(), []string{"v1"})
+	require.Error(t, err)
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+	assert.Equal(t, int32(1), atomic.LoadInt32(&svc.calls))
+}
+
+func TestCallOptionsClientHelperUnit(t *testing.T) {
+	var describes int32
+	var traceIds []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		traceIds = append(traceIds, r.Header.Get("X-Trace-Id"))
+		status := "Initializing"
+		if atomic.AddInt32(&describes, 1) > 1 {
+			status = "Ready"
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"name":"my-collection","status":"` + status + `","environment":"us-east1-gcp"}`))
+	}))
+	defer srv.Close()
+	defer func(interval time.Duration) { indexReadyPollInterval = interval }(indexReadyPollInterval)
+	indexReadyPollInterval = time.Millisecond
+
+	pc, err := NewClient(NewClientParams{ApiKey: "test-key", Host: srv.URL})
+	require.NoError(t, err)
+	_, err = pc.WaitForCollectionReady(context.Background(), "my-collection", WithHeader("X-Trace-Id", "trace-1"))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"trace-1", "trace-1"}, traceIds, "every request of the helper should carry the per-call header")
+
+	ctx, cancel := withClientCallOptions(context.Background(), []CallOption{WithNamespace("ns"), WithHeader("X-Trace-Id", "trace-1")})
+	defer cancel()
+	o := callOptionsFrom(ctx)
+	require.NotNil(t, o)
+	assert.Nil(t, o.namespace, "WithNamespace should not reach the calls of a Client helper")
+	assert.Equal(t, "trace-1", o.headers["X-Trace-Id"])
+}
@@ -15,8 +15,10 @@ import (
 	"os"
 	"slices"
 	"strings"
+	"sync"
 
 	"github.com/pinecone-io/go-pinecone/v6/internal/gen"
+	"github.com/pinecone-io/go-pinecone/v6/internal/gen/admin"
 	"github.com/pinecone-io/go-pinecone/v6/internal/gen/db_control"
 	db_data_rest "github.com/pinecone-io/go-pinecone/v6/internal/gen/db_data/rest"
 	"github.com/pinecone-io/go-pinecone/v6/internal/gen/inference"
@@ -74,27 +76,52 @@ type Client struct {
 	Inference  *InferenceService
 	restClient *db_control.Client
 	baseParams *NewClientBaseParams
+	tokens     *reuseTokenSource
+	projects   *projectIdSource
 }
 
-// [NewClientParams] holds the parameters for creating a new [Client] instance while authenticating via an API key.
+// [NewClientParams] holds the parameters for creating a new [Client] instance while authenticating via an API key
+// or a service account.
 //
 // Fields:
 //   - ApiKey: (Required) The API key used to authenticate with the Pinecone API.
-//     This value must be passed by the user unless it is set as an environment variable ("PINECONE_API_KEY").
+//     This value must be passed by the user unless it is set as an environment variable ("PINECONE_API_KEY"),
+//     or the client authenticates with a service account through ClientId and ClientSecret or TokenSource.
+//   - ClientId: (Optional) The OAuth client ID of a service account, used when no API key is provided.
+//     Falls back to the "PINECONE_CLIENT_ID" environment variable.
+//   - ClientSecret: (Optional) The OAuth client secret of the service account.
+//     Falls back to the "PINECONE_CLIENT_SECRET" environment variable.
+//   - TokenSource: (Optional) A [TokenSource] supplying bearer tokens, used when no API key is provided.
+//     Takes precedence over ClientId and ClientSecret.
+//   - ProjectId: (Optional) The project to operate on when authenticating with a service account, sent as the
+//     "X-Project-Id" header. Falls back to the "PINECONE_PROJECT_ID" environment variable; if neither is set, the
+//     service account must have access to exactly one project, which is looked up with the Admin API on the first
+//     request.
 //   - Headers: (Optional) An optional map of HTTP headers to include in each API request.
 //   - Host: (Optional) The host URL of the Pinecone API. If not provided, the default value is "https://api.pinecone.io".
 //   - RestClient: An optional HTTP client to use for communication with the Pinecone API.
 //   - SourceTag: An optional string used to help Pinecone attribute API activity.
 //   - RetryPolicy: An optional [RetryPolicy] enabling retries on rate-limit/transient errors for REST and gRPC.
+//   - Hooks: An optional [Hooks] object notified of each request, response, retry, and final failure.
+//   - EmbeddingCache: An optional [EmbeddingCache] consulted by [InferenceService.Embed] before calling the API.
+//   - ValidateParameters: If true, model parameters are checked against the model's [ModelInfo.SupportedParameters]
+//     before each request, and missing defaults are filled in. See [InferenceService.ValidateParameters].
 //
 // See [Client] for code example.
 type NewClientParams struct {
-	ApiKey      string            // required - provide through NewClientParams or environment variable PINECONE_API_KEY
-	Headers     map[string]string // optional
-	Host        string            // optional
-	RestClient  *http.Client      // optional
-	SourceTag   string            // optional
-	RetryPolicy *RetryPolicy      // optional
+	ApiKey             string            // required - provide through NewClientParams or environment variable PINECONE_API_KEY
+	ClientId           string            // optional - service account authentication, instead of ApiKey
+	ClientSecret       string            // optional - service account authentication, instead of ApiKey
+	TokenSource        TokenSource       // optional - bearer token authentication, instead of ApiKey
+	ProjectId          string            // optional - with ClientId and ClientSecret or TokenSource
+	Headers            map[string]string // optional
+	Host               string            // optional
+	RestClient         *http.Client      // optional
+	SourceTag          string            // optional
+	RetryPolicy        *RetryPolicy      // optional
+	Hooks              *Hooks            // optional
+	EmbeddingCache     EmbeddingCache    // optional
+	ValidateParameters bool              // optional
 }
 
 // [NewClientBaseParams] holds the parameters for creating a new [Client] instance while passing custom authentication
@@ -108,14 +135,26 @@ type NewClientParams struct {
 //   - RestClient: (Optional) An *http.Client object to use for communication with the Pinecone API.
 //   - SourceTag: (Optional) A string used to help Pinecone attribute API activity.
 //   - RetryPolicy: (Optional) A [RetryPolicy] enabling retries on rate-limit/transient errors for REST and gRPC.
+//   - Hooks: (Optional) A [Hooks] object notified of each request, response, retry, and final failure.
+//   - EmbeddingCache: (Optional) An [EmbeddingCache] consulted by [InferenceService.Embed] before calling the API.
+//   - ValidateParameters: (Optional) If true, model parameters are checked against the model's
+//     [ModelInfo.SupportedParameters] before each request. See [InferenceService.ValidateParameters].
+//   - TokenSource: (Optional) A [TokenSource] supplying the bearer tokens sent in the "Authorization" header of
+//     REST requests and the authorization metadata of gRPC requests. Tokens are refreshed before they expire.
+//     If Headers has no "X-Project-Id", the project is looked up with the Admin API. Neither the first token nor
+//     the project is fetched until the first request, which bounds both with its context.
 //
 // See [Client] for code example.
 type NewClientBaseParams struct {
-	Headers     map[string]string
-	Host        string
-	RestClient  *http.Client
-	SourceTag   string
-	RetryPolicy *RetryPolicy
+	Headers            map[string]string
+	Host               string
+	RestClient         *http.Client
+	SourceTag          string
+	RetryPolicy        *RetryPolicy
+	Hooks              *Hooks
+	EmbeddingCache     EmbeddingCache
+	ValidateParameters bool
+	TokenSource        TokenSource
 }
 
 // [NewIndexConnParams] holds the parameters for creating an [IndexConnection] to a Pinecone index.
@@ -125,12 +164,17 @@ type NewClientBaseParams struct {
 //     Alternatively, the host is displayed in the Pinecone web console.
 //   - Namespace: (Optional) The index namespace to use for operations. If not provided, the default namespace of "" will be used.
 //   - AdditionalMetadata: (Optional) Metadata to be sent with each RPC request.
+//   - Transport: (Optional) The [IndexTransport] used for vector and namespace operations. Defaults to
+//     [IndexTransportGRPC]. Use [IndexTransportREST] where gRPC traffic is blocked; the [IndexConnection]
+//     methods and the types they return are the same for both transports. gRPC dial options cannot be passed
+//     to [Client.Index] with [IndexTransportREST].
 //
 // See [Client.Index] for code example.
 type NewIndexConnParams struct {
 	Host               string            // required - obtained through DescribeIndex or ListIndexes
 	Namespace          string            // optional - if not provided the default namespace of "" will be used
 	AdditionalMetadata map[string]string // optional
+	Transport          IndexTransport    // optional - defaults to IndexTransportGRPC
 }
 
 // [NewClient] creates and initializes a new instance of [Client].
@@ -158,25 +202,36 @@ type NewIndexConnParams struct {
 //			panic(fmt.Errorf("Failed to create Client: %v", err))
 //		}
 func NewClient(in NewClientParams) (*Client, error) {
-	osApiKey := os.Getenv("PINECONE_API_KEY")
-	hasApiKey := valueOrFallback(in.ApiKey, osApiKey) != ""
-
-	if !hasApiKey {
-		return nil, fmt.Errorf("no API key provided, please pass an API key for authorization through NewClientParams or set the PINECONE_API_KEY environment variable")
-	}
-
-	apiKeyHeader := struct{ Key, Value string }{"Api-Key", valueOrFallback(in.ApiKey, osApiKey)}
-
 	clientHeaders := in.Headers
 	if clientHeaders == nil {
 		clientHeaders = make(map[string]string)
-		clientHeaders[apiKeyHeader.Key] = apiKeyHeader.Value
+	}
+	baseParams := NewClientBaseParams{Headers: clientHeaders, Host: in.Host, RestClient: in.RestClient, SourceTag: in.SourceTag, RetryPolicy: in.RetryPolicy, Hooks: in.Hooks, EmbeddingCache: in.EmbeddingCache, ValidateParameters: in.ValidateParameters}
 
-	} else {
-		clientHeaders[apiKeyHeader.Key] = apiKeyHeader.Value
+	apiKey := valueOrFallback(in.ApiKey, os.Getenv("PINECONE_API_KEY"))
+	if apiKey != "" {
+		clientHeaders["Api-Key"] = apiKey
+		return NewClientBase(baseParams)
 	}
 
-	return NewClientBase(NewClientBaseParams{Headers: clientHeaders, Host: in.Host, RestClient: in.RestClient, SourceTag: in.SourceTag, RetryPolicy: in.RetryPolicy})
+	// Without an API key, authenticate with a service account.
+	tokenSource := in.TokenSource
+	if tokenSource == nil {
+		clientId := valueOrFallback(in.ClientId, os.Getenv("PINECONE_CLIENT_ID"))
+		clientSecret := valueOrFallback(in.ClientSecret, os.Getenv("PINECONE_CLIENT_SECRET"))
+		if clientId == "" && clientSecret == "" {
+			return nil, fmt.Errorf("no API key provided, please pass an API key for authorization through NewClientParams or set the PINECONE_API_KEY environment variable, or authenticate with a service account through ClientId and ClientSecret or TokenSource")
+		}
+		if clientId == "" || clientSecret == "" {
+			return nil, fmt.Errorf("both ClientId and ClientSecret are required to authenticate with a service account, pass them through NewClientParams or set the PINECONE_CLIENT_ID and PINECONE_CLIENT_SECRET environment variables")
+		}
+		tokenSource = NewClientCredentialsTokenSource(clientId, clientSecret, in.RestClient)
+	}
+	if projectId := valueOrFallback(in.ProjectId, os.Getenv("PINECONE_PROJECT_ID")); projectId != "" {
+		clientHeaders["X-Project-Id"] = projectId
+	}
+	baseParams.TokenSource = tokenSource
+	return NewClientBase(baseParams)
 }
 
 // [NewClientBase] creates and initializes a new instance of [Client] with custom authentication headers.
@@ -211,15 +266,13 @@ func NewClientBase(in NewClientBaseParams) (*Client, error) {
 	if err := in.RetryPolicy.validate(); err != nil {
 		return nil, err
 	}
-	// Retries apply to all REST clients (control/data/inference) via a wrapped transport.
-	if in.RetryPolicy != nil {
-		in.RestClient = NewRetryHTTPClient(in.RetryPolicy, in.RestClient)
+	// Retries and hooks apply to all REST clients (control/data/inference) via a wrapped transport. The transport
+	// is always installed so a per-call WithRetryPolicy works even without a client-wide policy.
+	policy := in.RetryPolicy
+	if policy == nil {
+		policy = &RetryPolicy{}
 	}
-
-	controlOptions := buildClientBaseOptions(in)
-	inferenceOptions := buildInferenceBaseOptions(in)
 	var err error
-
 	controlHostOverride := valueOrFallback(in.Host, os.Getenv("PINECONE_CONTROLLER_HOST"))
 	if controlHostOverride != "" {
 		controlHostOverride, err = ensureURLScheme(controlHostOverride)
@@ -227,6 +280,31 @@ func NewClientBase(in NewClientBaseParams) (*Client, error) {
 			return nil, err
 		}
 	}
+	controlURL, err := url.Parse(valueOrFallback(controlHostOverride, "https://api.pinecone.io"))
+	if err != nil {
+		return nil, err
+	}
+	userRestClient := in.RestClient
+	in.RestClient = newRetryHTTPClient(policy, in.Hooks, in.RestClient, controlURL)
+
+	// With a token source, bearer tokens are set per request rather than through a static header. The first token,
+	// and the project when Headers has none, are fetched by the first request, under its context.
+	var tokens *reuseTokenSource
+	var projects *projectIdSource
+	if in.TokenSource != nil {
+		tokens = newReuseTokenSource(in.TokenSource)
+		in.RestClient = newTokenHTTPClient(tokens, in.RestClient)
+		if !hasHeader(in.Headers, "X-Project-Id") {
+			adminHost, sourceTag := valueOrFallback(controlHostOverride, "https://api.pinecone.io"), in.SourceTag
+			projects = &projectIdSource{resolve: func(ctx context.Context) (string, error) {
+				return resolveProjectId(ctx, adminHost, tokens, userRestClient, sourceTag)
+			}}
+			in.RestClient = newProjectIdHTTPClient(projects, in.RestClient)
+		}
+	}
+
+	controlOptions := buildClientBaseOptions(in)
+	inferenceOptions := buildInferenceBaseOptions(in)
 
 	dbControlClient, err := db_control.NewClient(valueOrFallback(controlHostOverride, "https://api.pinecone.io"), controlOptions...)
 	if err != nil {
@@ -238,9 +316,11 @@ func NewClientBase(in NewClientBaseParams) (*Client, error) {
 	}
 
 	c := Client{
-		Inference:  &InferenceService{client: inferenceClient},
+		Inference:  &InferenceService{client: inferenceClient, cache: in.EmbeddingCache, validate: in.ValidateParameters, retryPolicy: in.RetryPolicy},
 		restClient: dbControlClient,
 		baseParams: &in,
+		tokens:     tokens,
+		projects:   projects,
 	}
 	return &c, nil
 }
@@ -299,6 +379,12 @@ func (c *Client) Index(in NewIndexConnParams, dialOpts ...grpc.DialOption) (*Ind
 		return nil, fmt.Errorf("field Host is required to create an IndexConnection. Find your Host from calling DescribeIndex or via the Pinecone console")
 	}
 
+	switch in.Transport {
+	case "", IndexTransportGRPC, IndexTransportREST:
+	default:
+		return nil, fmt.Errorf("invalid Transport %q: must be %q or %q", in.Transport, IndexTransportGRPC, IndexTransportREST)
+	}
+
 	// add api version header if not provided
 	if _, ok := in.AdditionalMetadata["X-Pinecone-Api-Version"]; !ok {
 		in.AdditionalMetadata["X-Pinecone-Api-Version"] = gen.PineconeApiVersion
@@ -317,17 +403,19 @@ func (c *Client) Index(in NewIndexConnParams, dialOpts ...grpc.DialOption) (*Ind
 		return nil, err
 	}
 
-	// Enable gRPC data-plane retries; user-supplied dialOpts come after and win on conflict.
-	if c.baseParams.RetryPolicy != nil {
-		dialOpts = append(RetryDialOptions(c.baseParams.RetryPolicy), dialOpts...)
-	}
-
+	// gRPC data-plane retries and hooks are driven by the interceptor installed in newIndexConnection.
 	idx, err := newIndexConnection(newIndexParameters{
 		host:               in.Host,
 		namespace:          in.Namespace,
 		sourceTag:          c.baseParams.SourceTag,
 		additionalMetadata: in.AdditionalMetadata,
 		dbDataClient:       dbDataClient,
+		retryPolicy:        c.baseParams.RetryPolicy,
+		hooks:              c.baseParams.Hooks,
+		transport:          in.Transport,
+		inference:          c.Inference,
+		tokens:             c.tokens,
+		projects:           c.projects,
 	}, dialOpts...)
 	if err != nil {
 		return nil, err
@@ -350,6 +438,7 @@ func ensureHostHasHttps(host string) string {
 // Parameters:
 //   - ctx: A context.Context object controls the request's lifetime, allowing for the request
 //     to be canceled or to timeout according to the context's deadline.
+//   - opts: (Optional) [CallOption] values that apply to this call only, e.g. [WithTimeout] or [WithHeader].
 //
 // Returns a slice of pointers to [Index] objects or an error.
 //
@@ -376,7 +465,10 @@ func ensureHostHasHttps(host string) string {
 //	    }
 //
 // [project]: https://docs.pinecone.io/guides/projects/understanding-projects
-func (c *Client) ListIndexes(ctx context.Context) ([]*Index, error) {
+func (c *Client) ListIndexes(ctx context.Context, opts ...CallOption) ([]*Index, error) {
+	ctx, cancel := withCallOptions(ctx, opts)
+	defer cancel()
+
 	res, err := c.restClient.ListIndexes(ctx, &db_control.ListIndexesParams{XPineconeApiVersion: gen.PineconeApiVersion})
 	if err != nil {
 		return nil, err
@@ -516,6 +608,7 @@ func (req CreatePodIndexRequest) TotalCount() int {
 //   - ctx: A context.Context object controls the request's lifetime, allowing for the request
 //     to be canceled or to timeout according to the context's deadline.
 //   - in: A pointer to a [CreatePodIndexRequest] object. See [CreatePodIndexRequest] for more information.
+//   - opts: (Optional) [CallOption] values that apply to this call only, e.g. [WithTimeout] or [WithHeader].
 //
 // Returns a pointer to an [Index] object or an error.
 //
@@ -553,7 +646,10 @@ func (req CreatePodIndexRequest) TotalCount() int {
 //		} else {
 //			   fmt.Printf("Successfully created pod index: %s", idx.Name)
 //		}
-func (c *Client) CreatePodIndex(ctx context.Context, in *CreatePodIndexRequest) (*Index, error) {
+func (c *Client) CreatePodIndex(ctx context.Context, in *CreatePodIndexRequest, opts ...CallOption) (*Index, error) {
+	ctx, cancel := withCallOptions(ctx, opts)
+	defer cancel()
+
 	if in == nil {
 		return nil, fmt.Errorf("in (*CreatePodIndexRequest) cannot be nil")
 	}
@@ -732,6 +828,7 @@ type ReadCapacityOnDemandConfig struct{}
 //   - ctx: A context.Context object controls the request's lifetime, allowing for the request
 //     to be canceled or to timeout according to the context's deadline.
 //   - in: A pointer to a [CreateServerlessIndexRequest] object. See [CreateServerlessIndexRequest] for more information.
+//   - opts: (Optional) [CallOption] values that apply to this call only, e.g. [WithTimeout] or [WithHeader].
 //
 // Returns a pointer to an [Index] object or an error.
 //
@@ -764,7 +861,10 @@ type ReadCapacityOnDemandConfig struct{}
 //		} else {
 //		    fmt.Printf("Successfully created serverless index: %s", idx.Name)
 //		}
-func (c *Client) CreateServerlessIndex(ctx context.Context, in *CreateServerlessIndexRequest) (*Index, error) {
+func (c *Client) CreateServerlessIndex(ctx context.Context, in *CreateServerlessIndexRequest, opts ...CallOption) (*Index, error) {
+	ctx, cancel := withCallOptions(ctx, opts)
+	defer cancel()
+
 	if in == nil {
 		return nil, fmt.Errorf("in (*CreateServerlessIndexRequest) cannot be nil")
 	}
@@ -936,6 +1036,7 @@ type CreateIndexForModelEmbed struct {
 //   - ctx: A context.Context object controls the request's lifetime, allowing for the request
 //     to be canceled or to timeout according to the context's deadline.
 //   - in: A pointer to a [CreateIndexForModelRequest] object. See [CreateIndexForModelRequest] for more information.
+//   - opts: (Optional) [CallOption] values that apply to this call only, e.g. [WithTimeout] or [WithHeader].
 //
 // Returns a pointer to an [Index] object or an error.
 //
@@ -971,7 +1072,10 @@ type CreateIndexForModelEmbed struct {
 //		} else {
 //		    fmt.Printf("Successfully created serverless index: %s", idx.Name)
 //		}
-func (c *Client) CreateIndexForModel(ctx context.Context, in *CreateIndexForModelRequest) (*Index, error) {
+func (c *Client) CreateIndexForModel(ctx context.Context, in *CreateIndexForModelRequest, opts ...CallOption) (*Index, error) {
+	ctx, cancel := withCallOptions(ctx, opts)
+	defer cancel()
+
 	if in == nil {
 		return nil, fmt.Errorf("in (*CreateIndexForModelRequest) cannot be nil")
 	}
@@ -991,6 +1095,16 @@ func (c *Client) CreateIndexForModel(ctx context.Context, in *CreateIndexForMode
 		return nil, err
 	}
 
+	readParameters, writeParameters := in.Embed.ReadParameters, in.Embed.WriteParameters
+	if c.baseParams.ValidateParameters {
+		if readParameters, err = c.Inference.validateParametersPtr(ctx, in.Embed.Model, readParameters, false); err != nil {
+			return nil, fmt.Errorf("invalid Embed.ReadParameters: %w", err)
+		}
+		if writeParameters, err = c.Inference.validateParametersPtr(ctx, in.Embed.Model, writeParameters, false); err != nil {
+			return nil, fmt.Errorf("invalid Embed.WriteParameters: %w", err)
+		}
+	}
+
 	req := db_control.CreateIndexForModelRequest{
 		Name:   in.Name,
 		Region: in.Region,
@@ -1007,8 +1121,8 @@ func (c *Client) CreateIndexForModel(ctx context.Context, in *CreateIndexForMode
 			FieldMap:        in.Embed.FieldMap,
 			Metric:          (*string)(in.Embed.Metric),
 			Model:           in.Embed.Model,
-			ReadParameters:  in.Embed.ReadParameters,
-			WriteParameters: in.Embed.WriteParameters,
+			ReadParameters:  readParameters,
+			WriteParameters: writeParameters,
 		},
 		DeletionProtection: (*db_control.DeletionProtection)(&deletionProtection),
 		Schema:             fromMetadataSchemaToRest(in.Schema),
@@ -1098,6 +1212,7 @@ type CreateBYOCIndexRequest struct {
 //   - ctx: A context.Context object controls the request's lifetime, allowing for the request
 //     to be canceled or to timeout according to the context's deadline.
 //   - in: A pointer to a [CreateBYOCIndexRequest] object. See [CreateBYOCIndexRequest] for more information.
+//   - opts: (Optional) [CallOption] values that apply to this call only, e.g. [WithTimeout] or [WithHeader].
 //
 // Returns a pointer to an [Index] object or an error.
 //
@@ -1129,7 +1244,10 @@ type CreateBYOCIndexRequest struct {
 //		} else {
 //		    fmt.Printf("Successfully created BYOC index: %s", idx.Name)
 //		}
-func (c *Client) CreateBYOCIndex(ctx context.Context, in *CreateBYOCIndexRequest) (*Index, error) {
+func (c *Client) CreateBYOCIndex(ctx context.Context, in *CreateBYOCIndexRequest, opts ...CallOption) (*Index, error) {
+	ctx, cancel := withCallOptions(ctx, opts)
+	defer cancel()
+
 	if in == nil {
 		return nil, fmt.Errorf("in (*CreateBYOCIndexRequest) cannot be nil")
 	}
@@ -1196,6 +1314,7 @@ func (c *Client) CreateBYOCIndex(ctx context.Context, in *CreateBYOCIndexRequest
 //   - ctx: A context.Context object controls the request's lifetime, allowing for the request
 //     to be canceled or to timeout according to the context's deadline.
 //   - idxName: The name of the [Index] to describe.
+//   - opts: (Optional) [CallOption] values that apply to this call only, e.g. [WithTimeout] or [WithHeader].
 //
 // Returns a pointer to an [Index] object or an error.
 //
@@ -1226,7 +1345,10 @@ func (c *Client) CreateBYOCIndex(ctx context.Context, in *CreateBYOCIndexRequest
 //
 //		    fmt.Println(desc)
 //	    }
-func (c *Client) DescribeIndex(ctx context.Context, idxName string) (*Index, error) {
+func (c *Client) DescribeIndex(ctx context.Context, idxName string, opts ...CallOption) (*Index, error) {
+	ctx, cancel := withCallOptions(ctx, opts)
+	defer cancel()
+
 	res, err := c.restClient.DescribeIndex(ctx, idxName, &db_control.DescribeIndexParams{XPineconeApiVersion: gen.PineconeApiVersion})
 	if err != nil {
 		return nil, err
@@ -1246,6 +1368,7 @@ func (c *Client) DescribeIndex(ctx context.Context, idxName string) (*Index, err
 //   - ctx: A context.Context object controls the request's lifetime, allowing for the request
 //     to be canceled or to timeout according to the context's deadline.
 //   - idxName: The name of the [Index] to delete.
+//   - opts: (Optional) [CallOption] values that apply to this call only, e.g. [WithTimeout] or [WithHeader].
 //
 // Returns an error if the deletion fails.
 //
@@ -1271,7 +1394,10 @@ func (c *Client) DescribeIndex(ctx context.Context, idxName string) (*Index, err
 //	    } else {
 //	        fmt.Printf("Index \"%s\" deleted successfully", indexName)
 //	    }
-func (c *Client) DeleteIndex(ctx context.Context, idxName string) error {
+func (c *Client) DeleteIndex(ctx context.Context, idxName string, opts ...CallOption) error {
+	ctx, cancel := withCallOptions(ctx, opts)
+	defer cancel()
+
 	res, err := c.restClient.DeleteIndex(ctx, idxName, &db_control.DeleteIndexParams{XPineconeApiVersion: gen.PineconeApiVersion})
 	if err != nil {
 		return err
@@ -1358,6 +1484,7 @@ type ConfigureIndexEmbed struct {
 //     to be canceled or to timeout according to the context's deadline.
 //   - name: The name of the [Index] to configure.
 //   - in: A pointer to a ConfigureIndexParams object that contains the parameters for configuring the [Index].
+//   - opts: (Optional) [CallOption] values that apply to this call only, e.g. [WithTimeout] or [WithHeader].
 //
 // Note: You can only scale an [Index] up, not down. If you want to scale an [Index] down,
 // you must create a new index with the desired configuration.
@@ -1391,7 +1518,10 @@ type ConfigureIndexEmbed struct {
 //		 }
 //
 // [scale a pods-based index]: https://docs.pinecone.io/guides/indexes/configure-pod-based-indexes
-func (c *Client) ConfigureIndex(ctx context.Context, name string, in ConfigureIndexParams) (*Index, error) {
+func (c *Client) ConfigureIndex(ctx context.Context, name string, in ConfigureIndexParams, opts ...CallOption) (*Index, error) {
+	ctx, cancel := withCallOptions(ctx, opts)
+	defer cancel()
+
 	if in.PodType == "" && in.Replicas == 0 && in.DeletionProtection == "" && in.Tags == nil && in.ReadCapacity == nil && in.Embed == nil {
 		return nil, fmt.Errorf("must specify PodType, Replicas, DeletionProtection, ReadCapacity, Embed, or Tags when configuring an index")
 	}
@@ -1525,6 +1655,7 @@ func (c *Client) ConfigureIndex(ctx context.Context, name string, in ConfigureIn
 // Parameters:
 //   - ctx: A context.Context object controls the request's lifetime, allowing for the request
 //     to be canceled or to timeout according to the context's deadline.
+//   - opts: (Optional) [CallOption] values that apply to this call only, e.g. [WithTimeout] or [WithHeader].
 //
 // Returns a slice of pointers to [Collection] objects or an error.
 //
@@ -1560,7 +1691,10 @@ func (c *Client) ConfigureIndex(ctx context.Context, name string, in ConfigureIn
 //
 // [project]: https://docs.pinecone.io/guides/projects/understanding-projects
 // [understanding collections]: https://docs.pinecone.io/guides/indexes/understanding-collections
-func (c *Client) ListCollections(ctx context.Context) ([]*Collection, error) {
+func (c *Client) ListCollections(ctx context.Context, opts ...CallOption) ([]*Collection, error) {
+	ctx, cancel := withCallOptions(ctx, opts)
+	defer cancel()
+
 	res, err := c.restClient.ListCollections(ctx, &db_control.ListCollectionsParams{XPineconeApiVersion: gen.PineconeApiVersion})
 	if err != nil {
 		return nil, err
@@ -1591,6 +1725,7 @@ func (c *Client) ListCollections(ctx context.Context) ([]*Collection, error) {
 //   - ctx: A context.Context object controls the request's lifetime, allowing for the request
 //     to be canceled or to timeout according to the context's deadline.
 //   - collectionName: The name of the [Collection] to describe.
+//   - opts: (Optional) [CallOption] values that apply to this call only, e.g. [WithTimeout] or [WithHeader].
 //
 // Returns a pointer to a [Collection] object or an error.
 //
@@ -1627,7 +1762,10 @@ func (c *Client) ListCollections(ctx context.Context) ([]*Collection, error) {
 //
 // [dimensionality]: https://docs.pinecone.io/guides/indexes/choose-a-pod-type-and-size#dimensionality-of-vectors
 // [understanding collections]: https://docs.pinecone.io/guides/indexes/understanding-collections
-func (c *Client) DescribeCollection(ctx context.Context, collectionName string) (*Collection, error) {
+func (c *Client) DescribeCollection(ctx context.Context, collectionName string, opts ...CallOption) (*Collection, error) {
+	ctx, cancel := withCallOptions(ctx, opts)
+	defer cancel()
+
 	res, err := c.restClient.DescribeCollection(ctx, collectionName, &db_control.DescribeCollectionParams{XPineconeApiVersion: gen.PineconeApiVersion})
 	if err != nil {
 		return nil, err
@@ -1685,6 +1823,7 @@ type CreateCollectionRequest struct {
 //   - ctx: A context.Context object controls the request's lifetime, allowing for the request
 //     to be canceled or to timeout according to the context's deadline.
 //   - in: A pointer to a [CreateCollectionRequest] object.
+//   - opts: (Optional) [CallOption] values that apply to this call only, e.g. [WithTimeout] or [WithHeader].
 //
 // Note: Collections are only available for pods-based Indexes.
 //
@@ -1713,7 +1852,10 @@ type CreateCollectionRequest struct {
 //	    } else {
 //		       fmt.Printf("Successfully created collection \"%s\".", collection.Name)
 //	    }
-func (c *Client) CreateCollection(ctx context.Context, in *CreateCollectionRequest) (*Collection, error) {
+func (c *Client) CreateCollection(ctx context.Context, in *CreateCollectionRequest, opts ...CallOption) (*Collection, error) {
+	ctx, cancel := withCallOptions(ctx, opts)
+	defer cancel()
+
 	if in == nil {
 		return nil, fmt.Errorf("in (*CreateCollectionRequest) cannot be nil")
 	}
@@ -1745,6 +1887,7 @@ func (c *Client) CreateCollection(ctx context.Context, in *CreateCollectionReque
 //   - ctx: A context.Context object controls the request's lifetime, allowing for the request
 //     to be canceled or to timeout according to the context's deadline.
 //   - collectionName: The name of the [Collection] to delete.
+//   - opts: (Optional) [CallOption] values that apply to this call only, e.g. [WithTimeout] or [WithHeader].
 //
 // Note: Collections are only available for pods-based Indexes.
 //
@@ -1772,7 +1915,10 @@ func (c *Client) CreateCollection(ctx context.Context, in *CreateCollectionReque
 //	    } else {
 //		       log.Printf("Successfully deleted collection \"%s\"\n", collectionName)
 //	    }
-func (c *Client) DeleteCollection(ctx context.Context, collectionName string) error {
+func (c *Client) DeleteCollection(ctx context.Context, collectionName string, opts ...CallOption) error {
+	ctx, cancel := withCallOptions(ctx, opts)
+	defer cancel()
+
 	res, err := c.restClient.DeleteCollection(ctx, collectionName, &db_control.DeleteCollectionParams{XPineconeApiVersion: gen.PineconeApiVersion})
 	if err != nil {
 		return err
@@ -1804,6 +1950,7 @@ type CreateBackupParams struct {
 //   - ctx: A context.Context object controls the request's lifetime, allowing for the request
 //     to be canceled or to timeout according to the context's deadline.
 //   - in: A pointer to a [CreateBackupParams] object.
+//   - opts: (Optional) [CallOption] values that apply to this call only, e.g. [WithTimeout] or [WithHeader].
 //
 // Note: Backups are only available for serverless Indexes.
 //
@@ -1839,7 +1986,10 @@ type CreateBackupParams struct {
 //		 } else {
 //			    fmt.Printf("Successfully created backup \"%s\" of index \"%s\".", backup.BackupId, index.Name)
 //		 }
-func (c *Client) CreateBackup(ctx context.Context, in *CreateBackupParams) (*Backup, error) {
+func (c *Client) CreateBackup(ctx context.Context, in *CreateBackupParams, opts ...CallOption) (*Backup, error) {
+	ctx, cancel := withCallOptions(ctx, opts)
+	defer cancel()
+
 	if in == nil {
 		return nil, fmt.Errorf("in (*CreateBackupRequest) cannot be nil")
 	}
@@ -1894,6 +2044,7 @@ type CreateIndexFromBackupResponse struct {
 //   - ctx: A context.Context object controls the request's lifetime, allowing for the request
 //     to be canceled or to timeout according to the context's deadline.
 //   - in: A pointer to a [CreateIndexFromBackupParams] object.
+//   - opts: (Optional) [CallOption] values that apply to this call only, e.g. [WithTimeout] or [WithHeader].
 //
 // Note: Backups are only available for serverless Indexes.
 //
@@ -1923,7 +2074,10 @@ type CreateIndexFromBackupResponse struct {
 //	    if err != nil {
 //	      	   log.Fatalf("Failed to describe restore job: %v", err)
 //	    }
-func (c *Client) CreateIndexFromBackup(ctx context.Context, in *CreateIndexFromBackupParams) (*CreateIndexFromBackupResponse, error) {
+func (c *Client) CreateIndexFromBackup(ctx context.Context, in *CreateIndexFromBackupParams, opts ...CallOption) (*CreateIndexFromBackupResponse, error) {
+	ctx, cancel := withCallOptions(ctx, opts)
+	defer cancel()
+
 	if in == nil {
 		return nil, fmt.Errorf("in (*CreateIndexFromBackupRequest) cannot be nil")
 	}
@@ -1967,6 +2121,7 @@ func (c *Client) CreateIndexFromBackup(ctx context.Context, in *CreateIndexFromB
 //   - ctx: A context.Context object controls the request's lifetime, allowing for the request
 //     to be canceled or to timeout according to the context's deadline.
 //   - in: A string representing the ID of the [Backup] to describe.
+//   - opts: (Optional) [CallOption] values that apply to this call only, e.g. [WithTimeout] or [WithHeader].
 //
 // Returns a pointer to a [Backup] object or an error.
 //
@@ -1985,7 +2140,10 @@ func (c *Client) CreateIndexFromBackup(ctx context.Context, in *CreateIndexFromB
 //		if err != nil {
 //			   log.Fatalf("Failed to describe backup ID %s: %w", "my-backup-id", err)
 //		}
-func (c *Client) DescribeBackup(ctx context.Context, backupId string) (*Backup, error) {
+func (c *Client) DescribeBackup(ctx context.Context, backupId string, opts ...CallOption) (*Backup, error) {
+	ctx, cancel := withCallOptions(ctx, opts)
+	defer cancel()
+
 	if backupId == "" {
 		return nil, fmt.Errorf("you must provide a backupId to describe a backup")
 	}
@@ -2021,6 +2179,7 @@ type ListBackupsParams struct {
 //   - ctx: A context.Context object controls the request's lifetime, allowing for the request
 //     to be canceled or to timeout according to the context's deadline.
 //   - in: A pointer to a [ListBackupsParams] object.
+//   - opts: (Optional) [CallOption] values that apply to this call only, e.g. [WithTimeout] or [WithHeader].
 //
 // Returns a pointer to a [BackupList] object or an error.
 //
@@ -2044,7 +2203,10 @@ type ListBackupsParams struct {
 //	    if err != nil {
 //			   log.Fatalf("Failed to list backups: %w", err)
 //		}
-func (c *Client) ListBackups(ctx context.Context, in *ListBackupsParams) (*BackupList, error) {
+func (c *Client) ListBackups(ctx context.Context, in *ListBackupsParams, opts ...CallOption) (*BackupList, error) {
+	ctx, cancel := withCallOptions(ctx, opts)
+	defer cancel()
+
 	var response *http.Response
 	var err error
 	if in == nil {
@@ -2084,6 +2246,7 @@ func (c *Client) ListBackups(ctx context.Context, in *ListBackupsParams) (*Backu
 //   - ctx: A context.Context object controls the request's lifetime, allowing for the request
 //     to be canceled or to timeout according to the context's deadline.
 //   - in: A string representing the ID of the [Backup] to delete.
+//   - opts: (Optional) [CallOption] values that apply to this call only, e.g. [WithTimeout] or [WithHeader].
 //
 // Returns an error if the deletion fails.
 //
@@ -2102,7 +2265,10 @@ func (c *Client) ListBackups(ctx context.Context, in *ListBackupsParams) (*Backu
 //	    if err != nil {
 //			   log.Fatalf("Failed to delete backup: %w", err)
 //		}
-func (c *Client) DeleteBackup(ctx context.Context, backupId string) error {
+func (c *Client) DeleteBackup(ctx context.Context, backupId string, opts ...CallOption) error {
+	ctx, cancel := withCallOptions(ctx, opts)
+	defer cancel()
+
 	if backupId == "" {
 		return fmt.Errorf("you must provide a backupId to delete a backup")
 	}
@@ -2126,6 +2292,7 @@ func (c *Client) DeleteBackup(ctx context.Context, backupId string) error {
 //   - ctx: A context.Context object controls the request's lifetime, allowing for the request
 //     to be canceled or to timeout according to the context's deadline.
 //   - in: A string representing the ID of the [Backup] to describe.
+//   - opts: (Optional) [CallOption] values that apply to this call only, e.g. [WithTimeout] or [WithHeader].
 //
 // Returns a pointer to a [Backup] object or an error.
 //
@@ -2144,7 +2311,10 @@ func (c *Client) DeleteBackup(ctx context.Context, backupId string) error {
 //		if err != nil {
 //			   log.Fatalf("Failed to describe restore job ID %s: %w", "my-restore-job-id", err)
 //		}
-func (c *Client) DescribeRestoreJob(ctx context.Context, restoreJobId string) (*RestoreJob, error) {
+func (c *Client) DescribeRestoreJob(ctx context.Context, restoreJobId string, opts ...CallOption) (*RestoreJob, error) {
+	ctx, cancel := withCallOptions(ctx, opts)
+	defer cancel()
+
 	if restoreJobId == "" {
 		return nil, fmt.Errorf("you must provide a restoreJobId to describe a restore job")
 	}
@@ -2178,6 +2348,7 @@ type ListRestoreJobsParams struct {
 //   - ctx: A context.Context object controls the request's lifetime, allowing for the request
 //     to be canceled or to timeout according to the context's deadline.
 //   - in: A pointer to a [ListRestoreJobsParams] object.
+//   - opts: (Optional) [CallOption] values that apply to this call only, e.g. [WithTimeout] or [WithHeader].
 //
 // Returns a pointer to a [RestoreJobList] object or an error.
 //
@@ -2201,7 +2372,10 @@ type ListRestoreJobsParams struct {
 //	    if err != nil {
 //			   log.Fatalf("Failed to list restore jobs: %w", err)
 //		}
-func (c *Client) ListRestoreJobs(ctx context.Context, in *ListRestoreJobsParams) (*RestoreJobList, error) {
+func (c *Client) ListRestoreJobs(ctx context.Context, in *ListRestoreJobsParams, opts ...CallOption) (*RestoreJobList, error) {
+	ctx, cancel := withCallOptions(ctx, opts)
+	defer cancel()
+
 	var response *http.Response
 	var err error
 	if in == nil {
@@ -2233,7 +2407,13 @@ func (c *Client) ListRestoreJobs(ctx context.Context, in *ListRestoreJobsParams)
 //
 // [Pinecone Inference API]: https://docs.pinecone.io/guides/inference/understanding-inference#embedding-models
 type InferenceService struct {
-	client *inference.Client
+	client      *inference.Client
+	cache       EmbeddingCache
+	validate    bool
+	retryPolicy *RetryPolicy
+
+	modelsMu sync.Mutex
+	models   map[string]*ModelInfo
 }
 
 // [EmbedRequest] holds the parameters for generating embeddings for a list of input strings.
@@ -2263,6 +2443,7 @@ type EmbedParameters map[string]interface{}
 //   - Model: The model used to generate the embeddings.
 //   - VectorType: Indicates whether the embeddings are dense or sparse.
 //   - Usage: Usage statistics ([Total Tokens]) for the request.
+//   - Cache: Cache hits and misses for the request. Only set when the client has an [EmbeddingCache].
 //
 // [Total Tokens]: https://docs.pinecone.io/guides/organizations/manage-cost/understanding-cost#embed
 type EmbedResponse struct {
@@ -2272,15 +2453,19 @@ type EmbedResponse struct {
 	Usage      struct {
 		TotalTokens *int32 `json:"total_tokens,omitempty"`
 	} `json:"usage"`
+	Cache *EmbedCacheInfo `json:"cache,omitempty"`
 }
 
 // [InferenceService.Embed] generates embeddings for a list of inputs using the specified model and (optional) parameters.
+// If the client has an [EmbeddingCache], inputs found in the cache are not sent to the API, and
+// [EmbedResponse.Cache] reports the number of hits and misses.
 //
 // Parameters:
 //   - ctx: A context.Context object controls the request's lifetime, allowing for the request
 //     to be canceled or to timeout according to the context's deadline.
 //   - in: A pointer to an EmbedRequest object that contains the model to use for embedding generation, the
 //     list of input strings to generate embeddings for, and any additional parameters to use for generation.
+//   - opts: (Optional) [CallOption] values that apply to this call only, e.g. [WithTimeout] or [WithHeader].
 //
 // Returns a pointer to an [EmbeddingsList] object or an error.
 //
@@ -2313,14 +2498,33 @@ type EmbedResponse struct {
 //	    } else {
 //		       fmt.Printf("Successfully generated embeddings: %+v", res)
 //	    }
-func (i *InferenceService) Embed(ctx context.Context, in *EmbedRequest) (*EmbedResponse, error) {
+func (i *InferenceService) Embed(ctx context.Context, in *EmbedRequest, opts ...CallOption) (*EmbedResponse, error) {
+	ctx, cancel := withCallOptions(ctx, opts)
+	defer cancel()
+
 	if in == nil {
 		return nil, fmt.Errorf("in (*EmbedRequest) cannot be nil")
 	}
 	if len(in.TextInputs) == 0 {
 		return nil, fmt.Errorf("TextInputs must contain at least one value")
 	}
+	if i.validate {
+		params, err := i.validateParameters(ctx, in.Model, in.Parameters, true)
+		if err != nil {
+			return nil, err
+		}
+		if len(params) == 0 {
+			params = nil
+		}
+		in = &EmbedRequest{Model: in.Model, TextInputs: in.TextInputs, Parameters: params}
+	}
+	if i.cache != nil {
+		return i.embedCached(ctx, in)
+	}
+	return i.embed(ctx, in)
+}
 
+func (i *InferenceService) embed(ctx context.Context, in *EmbedRequest) (*EmbedResponse, error) {
 	// Convert text inputs to the expected type
 	convertedInputs := make([]struct {
 		Text *string `json:"text,omitempty"`
@@ -2352,7 +2556,12 @@ func (i *InferenceService) Embed(ctx context.Context, in *EmbedRequest) (*EmbedR
 		return nil, handleErrorResponseBody(res, "failed to embed: ")
 	}
 
-	return decodeEmbedResponse(res.Body)
+	embedRes, err := decodeEmbedResponse(res.Body)
+	if err != nil {
+		return nil, err
+	}
+	recordUsage(ctx, nil, "Embed", "", UsageTotals{EmbedTokens: int64(derefOrDefault(embedRes.Usage.TotalTokens, 0))})
+	return embedRes, nil
 }
 
 // [Document] is a map representing the document to be reranked.
@@ -2417,6 +2626,7 @@ type RerankResponse struct {
 //   - ctx: A context.Context object controls the request's lifetime, allowing for the request
 //     to be canceled or to timeout according to the context's deadline.
 //   - in: A pointer to a [RerankRequest] object that contains the model, query, and documents to use for reranking.
+//   - opts: (Optional) [CallOption] values that apply to this call only, e.g. [WithTimeout] or [WithHeader].
 //
 // Returns a pointer to a [RerankResponse] object or an error.
 //
@@ -2456,10 +2666,22 @@ type RerankResponse struct {
 //		        log.Fatalf("Failed to rerank: %v", err)
 //	     }
 //	     fmt.Printf("Rerank result: %+v\n", ranking)
-func (i *InferenceService) Rerank(ctx context.Context, in *RerankRequest) (*RerankResponse, error) {
+func (i *InferenceService) Rerank(ctx context.Context, in *RerankRequest, opts ...CallOption) (*RerankResponse, error) {
+	ctx, cancel := withCallOptions(ctx, opts)
+	defer cancel()
+
 	if in == nil {
 		return nil, fmt.Errorf("in (*RerankRequest) cannot be nil")
 	}
+	if i.validate {
+		params, err := i.validateParametersPtr(ctx, in.Model, in.Parameters, true)
+		if err != nil {
+			return nil, err
+		}
+		validated := *in
+		validated.Parameters = params
+		in = &validated
+	}
 	convertedDocuments := make([]inference.Document, len(in.Documents))
 	for i, doc := range in.Documents {
 		convertedDocuments[i] = inference.Document(doc)
@@ -2481,7 +2703,12 @@ func (i *InferenceService) Rerank(ctx context.Context, in *RerankRequest) (*Rera
 	if res.StatusCode != http.StatusOK {
 		return nil, handleErrorResponseBody(res, "failed to rerank: ")
 	}
-	return decodeRerankResponse(res.Body)
+	rerankRes, err := decodeRerankResponse(res.Body)
+	if err != nil {
+		return nil, err
+	}
+	recordUsage(ctx, nil, "Rerank", "", UsageTotals{RerankUnits: int64(derefOrDefault(rerankRes.Usage.RerankUnits, 0))})
+	return rerankRes, nil
 }
 
 // [InferenceService.DescribeModel] gets a description of a model hosted by Pinecone.
@@ -2490,6 +2717,7 @@ func (i *InferenceService) Rerank(ctx context.Context, in *RerankRequest) (*Rera
 //   - ctx: A context.Context object controls the request's lifetime, allowing for the request
 //     to be canceled or to timeout according to the context's deadline.
 //   - modelName: The name of the model to retrieve information about.
+//   - opts: (Optional) [CallOption] values that apply to this call only, e.g. [WithTimeout] or [WithHeader].
 //
 // Returns a pointer to a [ModelInfo] object or an error.
 //
@@ -2513,7 +2741,10 @@ func (i *InferenceService) Rerank(ctx context.Context, in *RerankRequest) (*Rera
 //		 }
 //
 //	     fmt.Printf("Model (multilingual-e5-large): %+v\n", model)
-func (i *InferenceService) DescribeModel(ctx context.Context, modelName string) (*ModelInfo, error) {
+func (i *InferenceService) DescribeModel(ctx context.Context, modelName string, opts ...CallOption) (*ModelInfo, error) {
+	ctx, cancel := withCallOptions(ctx, opts)
+	defer cancel()
+
 	res, err := i.client.GetModel(ctx, modelName, &inference.GetModelParams{XPineconeApiVersion: gen.PineconeApiVersion})
 	if err != nil {
 		return nil, err
@@ -2547,6 +2778,7 @@ type ListModelsParams struct {
 //   - ctx: A context.Context object controls the request's lifetime, allowing for the request
 //     to be canceled or to timeout according to the context's deadline.
 //   - in: The name of the model to retrieve information about.
+//   - opts: (Optional) [CallOption] values that apply to this call only, e.g. [WithTimeout] or [WithHeader].
 //
 // Returns a pointer to a [ModelInfoList] object or an error.
 //
@@ -2571,7 +2803,10 @@ type ListModelsParams struct {
 //		}
 //
 //		fmt.Printf("Embed Models: %+v\n", embedModels)
-func (i *InferenceService) ListModels(ctx context.Context, in *ListModelsParams) (*ModelInfoList, error) {
+func (i *InferenceService) ListModels(ctx context.Context, in *ListModelsParams, opts ...CallOption) (*ModelInfoList, error) {
+	ctx, cancel := withCallOptions(ctx, opts)
+	defer cancel()
+
 	var params *inference.ListModelsParams
 	if in != nil {
 		params = &inference.ListModelsParams{
@@ -2603,13 +2838,49 @@ func (c *Client) extractAuthHeader() map[string]string {
 		"access_token",
 	}
 
+	var authHeader map[string]string
 	for key, value := range c.baseParams.Headers {
-		if slices.Contains(possibleAuthKeys, strings.ToLower(key)) {
-			return map[string]string{key: value}
+		lowerKey := strings.ToLower(key)
+		// A client with a token source authenticates gRPC requests with per-RPC credentials instead.
+		isAuthKey := slices.Contains(possibleAuthKeys, lowerKey) && c.tokens == nil
+		if isAuthKey || lowerKey == "x-project-id" {
+			if authHeader == nil {
+				authHeader = make(map[string]string)
+			}
+			authHeader[key] = value
 		}
 	}
 
-	return nil
+	return authHeader
+}
+
+// hasHeader reports whether headers has key, compared case-insensitively.
+func hasHeader(headers map[string]string, key string) bool {
+	for k := range headers {
+		if strings.EqualFold(k, key) {
+			return true
+		}
+	}
+	return false
+}
+
+// resolveProjectId returns the ID of the only project the token source's service account can access, listed
+// with the Admin API at host.
+func resolveProjectId(ctx context.Context, host string, tokens *reuseTokenSource, restClient *http.Client, sourceTag string) (string, error) {
+	adminOptions := buildAdminClientOptions(NewAdminClientParams{SourceTag: &sourceTag})
+	adminOptions = append(adminOptions, admin.WithHTTPClient(newTokenHTTPClient(tokens, restClient)))
+	adminClient, err := newAdminClient(host, adminOptions...)
+	if err != nil {
+		return "", err
+	}
+	projects, err := (&DefaultProjectClient{restClient: adminClient}).List(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to look up the project for the service account, set ProjectId or the PINECONE_PROJECT_ID environment variable: %w", err)
+	}
+	if len(projects) != 1 {
+		return "", fmt.Errorf("the service account has access to %d projects, set ProjectId or the PINECONE_PROJECT_ID environment variable to choose one", len(projects))
+	}
+	return projects[0].Id, nil
 }
 
 func getIndexSpecType(spec db_control.IndexModel_Spec) string {
@@ -2984,6 +3255,8 @@ func buildClientBaseOptions(in NewClientBaseParams) []db_control.ClientOption {
 	for _, provider := range headerProviders {
 		clientOptions = append(clientOptions, db_control.WithRequestEditorFn(provider.Intercept))
 	}
+	clientOptions = append(clientOptions, db_control.WithRequestEditorFn(provider.NewRequestIdProvider(RequestIdHeader).Intercept))
+	clientOptions = append(clientOptions, db_control.WithRequestEditorFn(applyCallHeaders))
 
 	// apply custom http client if provided
 	if in.RestClient != nil {
@@ -3000,6 +3273,8 @@ func buildInferenceBaseOptions(in NewClientBaseParams) []inference.ClientOption
 	for _, provider := range headerProviders {
 		clientOptions = append(clientOptions, inference.WithRequestEditorFn(provider.Intercept))
 	}
+	clientOptions = append(clientOptions, inference.WithRequestEditorFn(provider.NewRequestIdProvider(RequestIdHeader).Intercept))
+	clientOptions = append(clientOptions, inference.WithRequestEditorFn(applyCallHeaders))
 
 	// apply custom http client if provided
 	if in.RestClient != nil {
@@ -3016,6 +3291,8 @@ func buildDataClientBaseOptions(in NewClientBaseParams) []db_data_rest.ClientOpt
 	for _, provider := range headerProviders {
 		clientOptions = append(clientOptions, db_data_rest.WithRequestEditorFn(provider.Intercept))
 	}
+	clientOptions = append(clientOptions, db_data_rest.WithRequestEditorFn(provider.NewRequestIdProvider(RequestIdHeader).Intercept))
+	clientOptions = append(clientOptions, db_data_rest.WithRequestEditorFn(applyCallHeaders))
 
 	// apply custom http client if provided
 	if in.RestClient != nil {
@@ -1094,7 +1094,7 @@ func TestNewClientParamsSetUnit(t *testing.T) {
 	apiKeyHeader, ok := client.baseParams.Headers["Api-Key"]
 	require.True(t, ok, "Expected client to have an 'Api-Key' header")
 	require.Equal(t, apiKey, apiKeyHeader, "Expected 'Api-Key' header to match provided ApiKey")
-	require.Equal(t, 3, len(client.restClient.RequestEditors), "Expected client to have correct number of request editors")
+	require.Equal(t, 5, len(client.restClient.RequestEditors), "Expected client to have correct number of request editors")
 }
 
 func TestNewClientParamsSetSourceTagUnit(t *testing.T) {
@@ -1110,7 +1110,7 @@ func TestNewClientParamsSetSourceTagUnit(t *testing.T) {
 	require.True(t, ok, "Expected client to have an 'Api-Key' header")
 	require.Equal(t, apiKey, apiKeyHeader, "Expected 'Api-Key' header to match provided ApiKey")
 	require.Equal(t, sourceTag, client.baseParams.SourceTag, "Expected client to have sourceTag '%s', but got '%s'", sourceTag, client.baseParams.SourceTag)
-	require.Equal(t, 3, len(client.restClient.RequestEditors), "Expected client to have %s request editors, but got %s", 2, len(client.restClient.RequestEditors))
+	require.Equal(t, 5, len(client.restClient.RequestEditors), "Expected client to have %d request editors, but got %d", 5, len(client.restClient.RequestEditors))
 }
 
 func TestNewClientParamsSetHeadersUnit(t *testing.T) {
@@ -1123,7 +1123,7 @@ func TestNewClientParamsSetHeadersUnit(t *testing.T) {
 	require.True(t, ok, "Expected client to have an 'Api-Key' header")
 	require.Equal(t, apiKey, apiKeyHeader, "Expected 'Api-Key' header to match provided ApiKey")
 	require.Equal(t, client.baseParams.Headers, headers, "Expected client to have headers '%+v', but got '%+v'", headers, client.baseParams.Headers)
-	require.Equal(t, 4, len(client.restClient.RequestEditors), "Expected client to have %s request editors, but got %s", 3, len(client.restClient.RequestEditors))
+	require.Equal(t, 6, len(client.restClient.RequestEditors), "Expected client to have %d request editors, but got %d", 6, len(client.restClient.RequestEditors))
 }
 
 func TestNewClientParamsNoApiKeyNoAuthorizationHeaderUnit(t *testing.T) {
@@ -2060,6 +2060,8 @@ func TestBuildClientBaseOptionsUnit(t *testing.T) {
 				db_control.WithRequestEditorFn(provider.NewHeaderProvider("User-Agent", "test-user-agent").Intercept),
 				db_control.WithRequestEditorFn(provider.NewHeaderProvider("X-Pinecone-Api-Version", gen.PineconeApiVersion).Intercept),
 				db_control.WithRequestEditorFn(provider.NewHeaderProvider("Param-Header", "param-value").Intercept),
+				db_control.WithRequestEditorFn(provider.NewRequestIdProvider(RequestIdHeader).Intercept),
+				db_control.WithRequestEditorFn(applyCallHeaders),
 			},
 			expectEnvUnset: true,
 		},
@@ -2075,6 +2077,8 @@ func TestBuildClientBaseOptionsUnit(t *testing.T) {
 				db_control.WithRequestEditorFn(provider.NewHeaderProvider("X-Pinecone-Api-Version", gen.PineconeApiVersion).Intercept),
 				db_control.WithRequestEditorFn(provider.NewHeaderProvider("User-Agent", "test-user-agent").Intercept),
 				db_control.WithRequestEditorFn(provider.NewHeaderProvider("Param-Header", "param-value").Intercept),
+				db_control.WithRequestEditorFn(provider.NewRequestIdProvider(RequestIdHeader).Intercept),
+				db_control.WithRequestEditorFn(applyCallHeaders),
 			},
 		},
 	}
@@ -0,0 +1,537 @@
+package pinecone
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"time"
+)
+
+// [CloneDataMode] selects how [Client.CloneIndexes] copies records into the indexes it creates.
+type CloneDataMode string
+
+const (
+	// Only the index configuration is cloned; the new indexes are empty.
+	CloneDataNone CloneDataMode = ""
+	// Each serverless index is backed up with [Client.CreateBackup] and the clone is created with
+	// [Client.CreateIndexFromBackup]. Backups can only be restored within the project they belong to, so the
+	// target [Client] must use the same project as the source, and indexes must be renamed with Rename. Unless
+	// KeepBackups is set, each backup is deleted once its restore job completes; a backup whose restore job fails
+	// is kept.
+	CloneDataBackup CloneDataMode = "backup"
+	// Records are listed, fetched, and upserted namespace by namespace. Works across projects. Listing record
+	// IDs is only supported for serverless indexes.
+	CloneDataStream CloneDataMode = "stream"
+)
+
+// [CloneIndexesParams] contains parameters for [Client.CloneIndexes].
+//
+// Fields:
+//   - Target: (Required) The [Client] of the project to create the indexes in.
+//   - Indexes: (Optional) The names of the indexes to clone. Defaults to every index of the source project.
+//   - Rename: (Optional) Returns the name of the clone of an index. Defaults to the same name. Required with
+//     [CloneDataBackup].
+//   - Data: (Optional) How to copy records. Defaults to [CloneDataNone].
+//   - StreamBatchSize: (Optional) The number of records listed, fetched, and upserted at a time with
+//     [CloneDataStream]. Default is 100.
+//   - ReadyTimeout: (Optional) How long to wait for a new index or backup to be ready, and for a restore job to
+//     complete before deleting its backup. Default is 5 minutes.
+//   - Transport: (Optional) The [IndexTransport] used to stream records. Defaults to [IndexTransportGRPC].
+//   - KeepBackups: (Optional) Keep the backups created with [CloneDataBackup] instead of deleting each once its
+//     restore job completes. Without it, cloning waits for each restore job to complete.
+type CloneIndexesParams struct {
+	Target          *Client
+	Indexes         []string
+	Rename          func(name string) string
+	Data            CloneDataMode
+	StreamBatchSize int
+	ReadyTimeout    time.Duration
+	Transport       IndexTransport
+	KeepBackups     bool
+}
+
+// [ClonedIndex] reports the cloning of one index.
+//
+// Fields:
+//   - Source: The name of the source index.
+//   - Target: The name of the index in the target project.
+//   - Created: Whether the target index was created. An index that already exists is left unchanged, and its
+//     differences from the source are listed in Differences.
+//   - BackupId: The ID of the backup the index was created from, with [CloneDataBackup].
+//   - BackupDeleted: Whether the backup was deleted, which it is unless KeepBackups is set or the restore job
+//     did not complete.
+//   - RestoreJobId: The ID of the restore job filling the index, with [CloneDataBackup].
+//   - Namespaces: The number of namespaces streamed, with [CloneDataStream].
+//   - RecordsCopied: The number of records streamed, with [CloneDataStream].
+//   - Differences: Settings of the source index that could not be reproduced in the target index.
+//   - Error: The error that stopped cloning the index, if any.
+type ClonedIndex struct {
+	Source        string   `json:"source"`
+	Target        string   `json:"target"`
+	Created       bool     `json:"created"`
+	BackupId      string   `json:"backup_id,omitempty"`
+	BackupDeleted bool     `json:"backup_deleted,omitempty"`
+	RestoreJobId  string   `json:"restore_job_id,omitempty"`
+	Namespaces    int      `json:"namespaces,omitempty"`
+	RecordsCopied int      `json:"records_copied,omitempty"`
+	Differences   []string `json:"differences,omitempty"`
+	Error         error    `json:"-"`
+}
+
+// [CloneIndexesReport] is returned by [Client.CloneIndexes], with one [ClonedIndex] per source index.
+type CloneIndexesReport struct {
+	Indexes []*ClonedIndex `json:"indexes"`
+}
+
+// indexReadyPollInterval is the delay between checks that a new index or backup is ready.
+var indexReadyPollInterval = 5 * time.Second
+
+// [Client.CloneIndexes] recreates the indexes of this client's project in the project of another [Client],
+// for example to give dev, staging, and prod projects the same indexes. Each index is recreated with the same
+// spec (serverless, pod-based, or BYOC), dimension, metric, vector type, integrated embedding, metadata
+// schema, read capacity, deletion protection, and tags. Records are copied if Data is set.
+//
+// Indexes that already exist in the target project are not modified; settings that differ are reported in
+// Differences. Settings that cannot be reproduced, such as the collection an index was created from, are
+// reported there too. Cloning continues past an index that fails; its error is recorded in the report and
+// joined into the returned error.
+//
+// Parameters:
+//   - ctx: A context.Context object controls the request's lifetime, allowing for the request
+//     to be canceled or to timeout according to the context's deadline.
+//   - in: A pointer to a [CloneIndexesParams] object.
+//   - opts: (Optional) [CallOption] values that apply to each underlying request, e.g. [WithHeader].
+//     [WithTimeout] bounds the whole call. [WithNamespace] has no effect.
+//
+// Returns a pointer to a [CloneIndexesReport], alongside an error if any index failed to clone.
+//
+// Example:
+//
+//	staging, err := pinecone.NewClient(pinecone.NewClientParams{ApiKey: os.Getenv("STAGING_API_KEY")})
+//	if err != nil {
+//		log.Fatalf("Failed to create Client: %v", err)
+//	}
+//	report, err := pc.CloneIndexes(ctx, &pinecone.CloneIndexesParams{Target: staging, Data: pinecone.CloneDataStream})
+//	if err != nil {
+//		log.Printf("some indexes failed to clone: %v", err)
+//	}
+//	for _, index := range report.Indexes {
+//		fmt.Printf("%s -> %s: created=%t records=%d differences=%v\n", index.Source, index.Target, index.Created, index.RecordsCopied, index.Differences)
+//	}
+func (c *Client) CloneIndexes(ctx context.Context, in *CloneIndexesParams, opts ...CallOption) (*CloneIndexesReport, error) {
+	if in == nil {
+		return nil, fmt.Errorf("in (*CloneIndexesParams) cannot be nil")
+	}
+	if in.Target == nil {
+		return nil, fmt.Errorf("Target is required to clone indexes")
+	}
+	switch in.Data {
+	case CloneDataNone, CloneDataBackup, CloneDataStream:
+	default:
+		return nil, fmt.Errorf("invalid Data %q: must be %q, %q, or empty", in.Data, CloneDataBackup, CloneDataStream)
+	}
+	if in.Data == CloneDataBackup && in.Rename == nil {
+		return nil, fmt.Errorf("Rename is required with Data %q: backups are restored within the source project, where each index already has its name", CloneDataBackup)
+	}
+	ctx, cancel := withClientCallOptions(ctx, opts)
+	defer cancel()
+
+	indexes, err := c.ListIndexes(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list source indexes: %w", err)
+	}
+	if len(in.Indexes) > 0 {
+		for _, name := range in.Indexes {
+			if !slices.ContainsFunc(indexes, func(idx *Index) bool { return idx.Name == name }) {
+				return nil, fmt.Errorf("source index %q does not exist", name)
+			}
+		}
+		indexes = slices.DeleteFunc(indexes, func(idx *Index) bool { return !slices.Contains(in.Indexes, idx.Name) })
+	}
+	existing, err := in.Target.ListIndexes(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list target indexes: %w", err)
+	}
+
+	report := &CloneIndexesReport{}
+	var errs []error
+	for _, source := range indexes {
+		cloned := &ClonedIndex{Source: source.Name, Target: source.Name}
+		if in.Rename != nil {
+			cloned.Target = in.Rename(source.Name)
+		}
+		report.Indexes = append(report.Indexes, cloned)
+
+		if err := c.cloneIndex(ctx, in, source, existing, cloned); err != nil {
+			cloned.Error = err
+			errs = append(errs, fmt.Errorf("failed to clone index %q: %w", source.Name, err))
+		}
+	}
+	return report, errors.Join(errs...)
+}
+
+func (c *Client) cloneIndex(ctx context.Context, in *CloneIndexesParams, source *Index, existing []*Index, cloned *ClonedIndex) error {
+	if i := slices.IndexFunc(existing, func(idx *Index) bool { return idx.Name == cloned.Target }); i >= 0 {
+		cloned.Differences = indexDifferences(source, existing[i])
+		return nil
+	}
+	cloned.Differences = unclonableSettings(source)
+
+	if in.Data == CloneDataBackup {
+		if source.Spec == nil || source.Spec.Serverless == nil {
+			cloned.Differences = append(cloned.Differences, "records not copied: backups are only available for serverless indexes")
+		} else {
+			return c.cloneIndexFromBackup(ctx, in, source, cloned)
+		}
+	}
+
+	if _, err := createIndexLike(ctx, in.Target, source, cloned.Target); err != nil {
+		return err
+	}
+	cloned.Created = true
+
+	if in.Data != CloneDataStream {
+		return nil
+	}
+	if source.Spec == nil || source.Spec.Serverless == nil {
+		cloned.Differences = append(cloned.Differences, "records not copied: listing record IDs is only available for serverless indexes")
+		return nil
+	}
+	target, err := waitForIndexReady(ctx, in.Target, cloned.Target, valueOrFallback(in.ReadyTimeout, 5*time.Minute))
+	if err != nil {
+		return err
+	}
+	return c.streamIndex(ctx, in, source, target, cloned)
+}
+
+// cloneIndexFromBackup backs up source and restores the backup as cloned.Target. Unless in.KeepBackups is set,
+// the backup is deleted once the restore job completes, or right away if no restore job was created. A backup
+// whose restore job fails or times out is kept, so the restore can be retried from it.
+func (c *Client) cloneIndexFromBackup(ctx context.Context, in *CloneIndexesParams, source *Index, cloned *ClonedIndex) (err error) {
+	description := fmt.Sprintf("Clone of %s to %s", source.Name, cloned.Target)
+	backup, err := c.CreateBackup(ctx, &CreateBackupParams{IndexName: source.Name, Description: &description})
+	if err != nil {
+		return err
+	}
+	cloned.BackupId = backup.BackupId
+	deleteBackup := !in.KeepBackups
+	defer func() {
+		if !deleteBackup {
+			return
+		}
+		// Delete with a fresh deadline, since ctx may be why cloning failed.
+		deleteCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), time.Minute)
+		defer cancel()
+		if deleteErr := c.DeleteBackup(deleteCtx, cloned.BackupId); deleteErr != nil {
+			err = errors.Join(err, fmt.Errorf("failed to delete backup %q: %w", cloned.BackupId, deleteErr))
+			return
+		}
+		cloned.BackupDeleted = true
+	}()
+
+	timeout := valueOrFallback(in.ReadyTimeout, 5*time.Minute)
+	waitCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	for backup.Status != "Ready" {
+		if backup.Status == "Failed" {
+			return fmt.Errorf("backup %q failed", backup.BackupId)
+		}
+		if !wait(waitCtx, indexReadyPollInterval) {
+			return fmt.Errorf("backup %q not ready after %s: %w", backup.BackupId, timeout, waitCtx.Err())
+		}
+		if backup, err = c.DescribeBackup(waitCtx, backup.BackupId); err != nil {
+			return err
+		}
+	}
+
+	restore, err := in.Target.CreateIndexFromBackup(ctx, &CreateIndexFromBackupParams{
+		BackupId:           backup.BackupId,
+		Name:               cloned.Target,
+		DeletionProtection: deletionProtectionOf(source),
+		Tags:               source.Tags,
+	})
+	if err != nil {
+		return err
+	}
+	cloned.Created = true
+	cloned.RestoreJobId = restore.RestoreJobId
+	if source.Spec.Serverless.ReadCapacity != nil && source.Spec.Serverless.ReadCapacity.Dedicated != nil {
+		cloned.Differences = append(cloned.Differences, "dedicated read capacity is not restored from backups; configure it with ConfigureIndex")
+	}
+	if !deleteBackup {
+		return nil
+	}
+
+	// The restore job reads the backup until it completes.
+	restoreCtx, cancelRestore := context.WithTimeout(ctx, timeout)
+	defer cancelRestore()
+	if _, err := in.Target.waitForRestoreJob(restoreCtx, restore.RestoreJobId, nil); err != nil {
+		deleteBackup = false
+		return fmt.Errorf("kept backup %q: %w", cloned.BackupId, err)
+	}
+	return nil
+}
+
+// streamIndex copies every namespace of source into target, a page of record IDs at a time.
+func (c *Client) streamIndex(ctx context.Context, in *CloneIndexesParams, source, target *Index, cloned *ClonedIndex) error {
+	src, err := c.Index(NewIndexConnParams{Host: source.Host, Transport: in.Transport})
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+	dst, err := in.Target.Index(NewIndexConnParams{Host: target.Host, Transport: in.Transport})
+	if err != nil {
+		return err
+	}
+	defer dst.Close()
+
+	stats, err := src.DescribeIndexStats(ctx)
+	if err != nil {
+		return err
+	}
+	limit := uint32(valueOrFallback(in.StreamBatchSize, 100))
+	for _, namespace := range slices.Sorted(maps.Keys(stats.Namespaces)) {
+		srcNs, dstNs := src.WithNamespace(namespace), dst.WithNamespace(namespace)
+		var token *string
+		for {
+			page, err := srcNs.ListVectors(ctx, &ListVectorsRequest{Limit: &limit, PaginationToken: token})
+			if err != nil {
+				return fmt.Errorf("failed to list records in namespace %q: %w", namespace, err)
+			}
+			ids := make([]string, 0, len(page.VectorIds))
+			for _, id := range page.VectorIds {
+				if id != nil {
+					ids = append(ids, *id)
+				}
+			}
+			if len(ids) > 0 {
+				fetched, err := srcNs.FetchVectors(ctx, ids)
+				if err != nil {
+					return fmt.Errorf("failed to fetch records in namespace %q: %w", namespace, err)
+				}
+				vectors := make([]*Vector, 0, len(fetched.Vectors))
+				for _, id := range ids {
+					if vector, ok := fetched.Vectors[id]; ok {
+						vectors = append(vectors, vector)
+					}
+				}
+				if _, err := dstNs.UpsertVectors(ctx, vectors); err != nil {
+					return fmt.Errorf("failed to upsert records in namespace %q: %w", namespace, err)
+				}
+				cloned.RecordsCopied += len(vectors)
+			}
+			if page.NextPaginationToken == nil || *page.NextPaginationToken == "" {
+				break
+			}
+			token = page.NextPaginationToken
+		}
+		cloned.Namespaces++
+	}
+	return nil
+}
+
+// createIndexLike creates an index named name with the configuration of source.
+func createIndexLike(ctx context.Context, target *Client, source *Index, name string) (*Index, error) {
+	if source.Spec == nil {
+		return nil, fmt.Errorf("index %q has no spec", source.Name)
+	}
+	deletionProtection := deletionProtectionOf(source)
+	switch {
+	case source.Spec.Serverless != nil && source.Embed != nil:
+		spec, embed := source.Spec.Serverless, source.Embed
+		modelEmbed := CreateIndexForModelEmbed{
+			Model:           embed.Model,
+			Metric:          embed.Metric,
+			ReadParameters:  embed.ReadParameters,
+			WriteParameters: embed.WriteParameters,
+		}
+		if embed.FieldMap != nil {
+			modelEmbed.FieldMap = *embed.FieldMap
+		}
+		if embed.Dimension != nil {
+			dimension := int(*embed.Dimension)
+			modelEmbed.Dimension = &dimension
+		}
+		return target.CreateIndexForModel(ctx, &CreateIndexForModelRequest{
+			Name:               name,
+			Cloud:              spec.Cloud,
+			Region:             spec.Region,
+			DeletionProtection: deletionProtection,
+			Embed:              modelEmbed,
+			ReadCapacity:       readCapacityParams(spec.ReadCapacity),
+			Schema:             spec.Schema,
+			Tags:               source.Tags,
+		})
+	case source.Spec.Serverless != nil:
+		spec := source.Spec.Serverless
+		return target.CreateServerlessIndex(ctx, &CreateServerlessIndexRequest{
+			Name:               name,
+			Cloud:              spec.Cloud,
+			Region:             spec.Region,
+			Metric:             &source.Metric,
+			DeletionProtection: deletionProtection,
+			Dimension:          source.Dimension,
+			VectorType:         &source.VectorType,
+			ReadCapacity:       readCapacityParams(spec.ReadCapacity),
+			Schema:             spec.Schema,
+			Tags:               source.Tags,
+		})
+	case source.Spec.Pod != nil:
+		spec := source.Spec.Pod
+		return target.CreatePodIndex(ctx, &CreatePodIndexRequest{
+			Name:               name,
+			Dimension:          derefOrDefault(source.Dimension, 0),
+			Environment:        spec.Environment,
+			PodType:            spec.PodType,
+			Shards:             spec.ShardCount,
+			Replicas:           spec.Replicas,
+			Metric:             &source.Metric,
+			DeletionProtection: deletionProtection,
+			MetadataConfig:     spec.MetadataConfig,
+			Tags:               source.Tags,
+		})
+	case source.Spec.BYOC != nil:
+		spec := source.Spec.BYOC
+		return target.CreateBYOCIndex(ctx, &CreateBYOCIndexRequest{
+			Name:               name,
+			Environment:        spec.Environment,
+			Dimension:          source.Dimension,
+			VectorType:         &source.VectorType,
+			Metric:             &source.Metric,
+			DeletionProtection: deletionProtection,
+			ReadCapacity:       readCapacityParams(spec.ReadCapacity),
+			Schema:             spec.Schema,
+			Tags:               source.Tags,
+		})
+	}
+	return nil, fmt.Errorf("index %q has an unsupported spec", source.Name)
+}
+
+// unclonableSettings lists the settings of source that creating an index like it does not reproduce.
+func unclonableSettings(source *Index) []string {
+	var differences []string
+	if source.Spec == nil {
+		return differences
+	}
+	if spec := source.Spec.Serverless; spec != nil && spec.SourceCollection != nil {
+		differences = append(differences, fmt.Sprintf("source collection %q is not cloned", *spec.SourceCollection))
+	}
+	if spec := source.Spec.Pod; spec != nil && spec.SourceCollection != nil {
+		differences = append(differences, fmt.Sprintf("source collection %q is not cloned", *spec.SourceCollection))
+	}
+	if source.Spec.Serverless == nil && source.Embed != nil {
+		differences = append(differences, fmt.Sprintf("integrated embedding with model %q is only cloned for serverless indexes", source.Embed.Model))
+	}
+	return differences
+}
+
+// indexDifferences lists the settings in which an existing target index differs from source.
+func indexDifferences(source, target *Index) []string {
+	var differences []string
+	differ := func(setting string, from, to any) {
+		differences = append(differences, fmt.Sprintf("%s differs: %v in source, %v in target", setting, from, to))
+	}
+	if derefOrDefault(source.Dimension, 0) != derefOrDefault(target.Dimension, 0) {
+		differ("dimension", derefOrDefault(source.Dimension, 0), derefOrDefault(target.Dimension, 0))
+	}
+	if source.Metric != target.Metric {
+		differ("metric", source.Metric, target.Metric)
+	}
+	if source.VectorType != target.VectorType {
+		differ("vector type", source.VectorType, target.VectorType)
+	}
+	if source.DeletionProtection != target.DeletionProtection {
+		differ("deletion protection", source.DeletionProtection, target.DeletionProtection)
+	}
+	if sourceKind, targetKind := specKind(source), specKind(target); sourceKind != targetKind {
+		differ("spec", sourceKind, targetKind)
+	} else if source.Spec != nil && target.Spec != nil {
+		switch {
+		case source.Spec.Serverless != nil:
+			s, t := source.Spec.Serverless, target.Spec.Serverless
+			if s.Cloud != t.Cloud || s.Region != t.Region {
+				differ("location", fmt.Sprintf("%s/%s", s.Cloud, s.Region), fmt.Sprintf("%s/%s", t.Cloud, t.Region))
+			}
+		case source.Spec.Pod != nil:
+			s, t := source.Spec.Pod, target.Spec.Pod
+			if s.PodType != t.PodType || s.Environment != t.Environment {
+				differ("pods", fmt.Sprintf("%s in %s", s.PodType, s.Environment), fmt.Sprintf("%s in %s", t.PodType, t.Environment))
+			}
+		}
+	}
+	var sourceModel, targetModel string
+	if source.Embed != nil {
+		sourceModel = source.Embed.Model
+	}
+	if target.Embed != nil {
+		targetModel = target.Embed.Model
+	}
+	if sourceModel != targetModel {
+		differ("embedding model", valueOrFallback(sourceModel, "none"), valueOrFallback(targetModel, "none"))
+	}
+	if !maps.Equal(derefOrDefault(source.Tags, nil), derefOrDefault(target.Tags, nil)) {
+		differ("tags", derefOrDefault(source.Tags, nil), derefOrDefault(target.Tags, nil))
+	}
+	return differences
+}
+
+func specKind(idx *Index) string {
+	switch {
+	case idx.Spec == nil:
+		return "none"
+	case idx.Spec.Serverless != nil:
+		return "serverless"
+	case idx.Spec.Pod != nil:
+		return "pod"
+	case idx.Spec.BYOC != nil:
+		return "byoc"
+	}
+	return "none"
+}
+
+func deletionProtectionOf(idx *Index) *DeletionProtection {
+	if idx.DeletionProtection == "" {
+		return nil
+	}
+	deletionProtection := idx.DeletionProtection
+	return &deletionProtection
+}
+
+// readCapacityParams converts the read capacity reported for an index to the parameters creating it.
+func readCapacityParams(readCapacity *ReadCapacity) *ReadCapacityParams {
+	switch {
+	case readCapacity == nil:
+		return nil
+	case readCapacity.Dedicated != nil:
+		return &ReadCapacityParams{Dedicated: &ReadCapacityDedicatedConfig{
+			NodeType: readCapacity.Dedicated.NodeType,
+			Scaling:  readCapacity.Dedicated.Scaling,
+		}}
+	case readCapacity.OnDemand != nil:
+		return &ReadCapacityParams{OnDemand: &ReadCapacityOnDemandConfig{}}
+	}
+	return nil
+}
+
+// waitForIndexReady describes the index name until it is ready or timeout elapses.
+func waitForIndexReady(ctx context.Context, c *Client, name string, timeout time.Duration) (*Index, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	for {
+		idx, err := c.DescribeIndex(ctx, name)
+		if err != nil {
+			return nil, err
+		}
+		if idx.Status != nil && idx.Status.Ready {
+			return idx, nil
+		}
+		if idx.Status != nil && idx.Status.State == InitializationFailed {
+			return nil, fmt.Errorf("index %q failed to initialize", name)
+		}
+		if !wait(ctx, indexReadyPollInterval) {
+			return nil, fmt.Errorf("index %q not ready after %s: %w", name, timeout, ctx.Err())
+		}
+	}
+}
@@ -0,0 +1,151 @@
+package pinecone
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Unit tests:
+func TestCloneIndexesUnit(t *testing.T) {
+	previous := indexReadyPollInterval
+	indexReadyPollInterval = time.Millisecond
+	defer func() { indexReadyPollInterval = previous }()
+
+	source := newFakeControlPlane(t)
+	source.addIndex(map[string]any{
+		"name": "docs", "metric": "dotproduct", "dimension": 2, "vector_type": "dense", "deletion_protection": "enabled",
+		"tags": map[string]any{"team": "search"},
+		"spec": map[string]any{"serverless": map[string]any{
+			"cloud": "aws", "region": "us-east-1", "source_collection": "seed",
+			"schema":        map[string]any{"fields": map[string]any{"genre": map[string]any{"filterable": true}}},
+			"read_capacity": map[string]any{"mode": "Dedicated", "dedicated": map[string]any{"node_type": "t1", "scaling": "Manual", "manual": map[string]any{"replicas": 1, "shards": 1}}, "status": map[string]any{"state": "Ready"}},
+		}},
+	})
+	source.addIndex(map[string]any{
+		"name": "legacy", "metric": "cosine", "dimension": 2, "vector_type": "dense",
+		"spec": map[string]any{"pod": map[string]any{"environment": "us-east1-gcp", "pod_type": "p1.x1", "pod_count": 1, "replicas": 1, "shard_count": 1}},
+	})
+	source.addIndex(map[string]any{
+		"name": "existing", "metric": "cosine", "dimension": 8, "vector_type": "dense",
+		"spec": map[string]any{"serverless": map[string]any{"cloud": "aws", "region": "us-east-1"}},
+	})
+	source.records["ns1"] = map[string][]float32{"a": {1, 0}, "b": {0, 1}, "c": {1, 1}}
+	source.records["ns2"] = map[string][]float32{"d": {2, 2}}
+
+	target := newFakeControlPlane(t)
+	target.addIndex(map[string]any{
+		"name": "existing", "metric": "euclidean", "dimension": 8, "vector_type": "dense",
+		"spec": map[string]any{"serverless": map[string]any{"cloud": "gcp", "region": "us-central1"}},
+	})
+
+	report, err := source.client(t).CloneIndexes(context.Background(), &CloneIndexesParams{
+		Target:          target.client(t),
+		Data:            CloneDataStream,
+		StreamBatchSize: 2,
+		Transport:       IndexTransportREST,
+	})
+	require.NoError(t, err)
+	require.Len(t, report.Indexes, 3)
+
+	docs := report.Indexes[0]
+	assert.True(t, docs.Created)
+	assert.Equal(t, 2, docs.Namespaces)
+	assert.Equal(t, 4, docs.RecordsCopied)
+	assert.Equal(t, []string{`source collection "seed" is not cloned`}, docs.Differences)
+	assert.Equal(t, source.records, target.records)
+
+	require.Len(t, target.creates, 2)
+	created := target.creates[0]
+	assert.Equal(t, "docs", created["name"])
+	assert.Equal(t, "dotproduct", created["metric"])
+	assert.Equal(t, "enabled", created["deletion_protection"])
+	assert.Equal(t, map[string]any{"team": "search"}, created["tags"])
+	serverless := created["spec"].(map[string]any)["serverless"].(map[string]any)
+	assert.Equal(t, map[string]any{"fields": map[string]any{"genre": map[string]any{"filterable": true}}}, serverless["schema"])
+	assert.Equal(t, "t1", serverless["read_capacity"].(map[string]any)["dedicated"].(map[string]any)["node_type"])
+	assert.NotContains(t, serverless, "source_collection")
+
+	legacy := report.Indexes[1]
+	assert.True(t, legacy.Created)
+	assert.Equal(t, "p1.x1", target.creates[1]["spec"].(map[string]any)["pod"].(map[string]any)["pod_type"])
+	require.Len(t, legacy.Differences, 1)
+	assert.Contains(t, legacy.Differences[0], "only available for serverless indexes")
+
+	existing := report.Indexes[2]
+	assert.False(t, existing.Created)
+	assert.Equal(t, []string{
+		"metric differs: cosine in source, euclidean in target",
+		"location differs: aws/us-east-1 in source, gcp/us-central1 in target",
+	}, existing.Differences)
+}
+
+func TestCloneIndexesFromBackupUnit(t *testing.T) {
+	previous := indexReadyPollInterval
+	indexReadyPollInterval = time.Millisecond
+	defer func() { indexReadyPollInterval = previous }()
+
+	project := newFakeControlPlane(t)
+	project.addIndex(map[string]any{
+		"name": "docs", "metric": "cosine", "dimension": 2, "vector_type": "dense", "deletion_protection": "disabled",
+		"spec": map[string]any{"serverless": map[string]any{"cloud": "aws", "region": "us-east-1"}},
+	})
+	project.restoreProgress = []map[string]any{{"status": "Pending"}, {"status": "Completed"}}
+	pc := project.client(t)
+
+	_, err := pc.CloneIndexes(context.Background(), &CloneIndexesParams{Target: pc, Data: CloneDataBackup})
+	assert.ErrorContains(t, err, "Rename is required", "an index cannot be restored onto itself")
+	assert.Empty(t, project.restores)
+
+	report, err := pc.CloneIndexes(context.Background(), &CloneIndexesParams{
+		Target:  pc,
+		Indexes: []string{"docs"},
+		Rename:  func(name string) string { return name + "-staging" },
+		Data:    CloneDataBackup,
+	})
+	require.NoError(t, err)
+	cloned := report.Indexes[0]
+	assert.Equal(t, "docs-staging", cloned.Target)
+	assert.True(t, cloned.Created)
+	assert.Equal(t, "backup-1", cloned.BackupId)
+	assert.Equal(t, "restore-1", cloned.RestoreJobId)
+	assert.True(t, cloned.BackupDeleted)
+	assert.Equal(t, 2, project.restorePolls, "the restore job should be followed until it completes")
+	assert.Equal(t, []string{"backup backup-1"}, project.deleted, "the backup should be deleted once the restore job completes")
+	require.Len(t, project.restores, 1)
+	assert.Equal(t, "docs-staging", project.restores[0]["name"])
+	assert.Empty(t, project.creates)
+
+	report, err = pc.CloneIndexes(context.Background(), &CloneIndexesParams{
+		Target:      pc,
+		Indexes:     []string{"docs"},
+		Rename:      func(name string) string { return name + "-qa" },
+		Data:        CloneDataBackup,
+		KeepBackups: true,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "backup-2", report.Indexes[0].BackupId)
+	assert.False(t, report.Indexes[0].BackupDeleted)
+	assert.Len(t, project.deleted, 1, "the backup should be kept with KeepBackups")
+
+	project.restoreProgress = []map[string]any{{"status": "Pending"}, {"status": "Failed"}}
+	report, err = pc.CloneIndexes(context.Background(), &CloneIndexesParams{
+		Target:  pc,
+		Indexes: []string{"docs"},
+		Rename:  func(name string) string { return name + "-dev" },
+		Data:    CloneDataBackup,
+	})
+	assert.ErrorContains(t, err, `kept backup "backup-3"`)
+	assert.ErrorContains(t, err, "ended with status Failed")
+	assert.Equal(t, "backup-3", report.Indexes[0].BackupId)
+	assert.False(t, report.Indexes[0].BackupDeleted)
+	assert.Len(t, project.deleted, 1, "the backup of a failed restore job should be kept")
+
+	_, err = pc.CloneIndexes(context.Background(), &CloneIndexesParams{Target: pc, Indexes: []string{"missing"}})
+	assert.ErrorContains(t, err, `source index "missing" does not exist`)
+	_, err = pc.CloneIndexes(context.Background(), &CloneIndexesParams{Target: pc, Data: "copy"})
+	assert.ErrorContains(t, err, "invalid Data")
+}
@@ -0,0 +1,348 @@
+package pinecone
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// [Client.WaitForCollectionReady] polls a [Collection] until its [CollectionStatus] is Ready. Set a deadline on
+// ctx to bound the wait; collections of large indexes can take a long time to build.
+//
+// Parameters:
+//   - ctx: A context.Context object controls the request's lifetime, allowing for the request
+//     to be canceled or to timeout according to the context's deadline.
+//   - collectionName: The name of the [Collection] to wait for.
+//   - opts: (Optional) [CallOption] values that apply to each underlying request, e.g. [WithHeader].
+//     [WithTimeout] bounds the whole call. [WithNamespace] has no effect.
+//
+// Returns a pointer to the ready [Collection], or an error if the collection is terminating, cannot be
+// described, or ctx is done first.
+//
+// Example:
+//
+//	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Minute)
+//	defer cancel()
+//
+//	collection, err := pc.WaitForCollectionReady(ctx, "my-collection")
+//	if err != nil {
+//		log.Fatalf("Collection did not become ready: %v", err)
+//	}
+//	fmt.Printf("Collection %s holds %d vectors\n", collection.Name, collection.VectorCount)
+func (c *Client) WaitForCollectionReady(ctx context.Context, collectionName string, opts ...CallOption) (*Collection, error) {
+	ctx, cancel := withClientCallOptions(ctx, opts)
+	defer cancel()
+	for {
+		collection, err := c.DescribeCollection(ctx, collectionName)
+		if err != nil {
+			return nil, err
+		}
+		switch collection.Status {
+		case CollectionStatusReady:
+			return collection, nil
+		case CollectionStatusTerminating:
+			return nil, fmt.Errorf("collection %q is terminating", collectionName)
+		}
+		if !wait(ctx, indexReadyPollInterval) {
+			return nil, fmt.Errorf("collection %q not ready: %w", collectionName, ctx.Err())
+		}
+	}
+}
+
+// [CreatePodIndexFromCollectionRequest] contains the parameters for [Client.CreatePodIndexFromCollection].
+//
+// Fields:
+//   - Collection: (Required) The name of the [Collection] to create the index from.
+//   - Name: (Required) The name of the index to create.
+//   - Environment: (Optional) The environment of the index. Defaults to the collection's environment, and
+//     must match it if set.
+//   - PodType: (Required) The type of pod to use.
+//   - Dimension: (Optional) The dimension of the index. Defaults to the collection's dimension, and must
+//     match it if set.
+//   - Metric: (Optional) The distance metric of the index. Collections do not record the metric of their
+//     source index, so set it to the source's metric unless you mean to change it. Default is cosine.
+//   - Shards: (Optional) The number of shards. Default is 1.
+//   - Replicas: (Optional) The number of replicas. Default is 1.
+//   - DeletionProtection: (Optional) Deletion protection for the index.
+//   - MetadataConfig: (Optional) The metadata fields to index.
+//   - Tags: (Optional) Custom user tags added to the index.
+type CreatePodIndexFromCollectionRequest struct {
+	Collection         string
+	Name               string
+	Environment        string
+	PodType            string
+	Dimension          int32
+	Metric             *IndexMetric
+	Shards             int32
+	Replicas           int32
+	DeletionProtection *DeletionProtection
+	MetadataConfig     *PodSpecMetadataConfig
+	Tags               *IndexTags
+}
+
+// [Client.CreatePodIndexFromCollection] creates a pod-based [Index] from a [Collection], once the collection
+// is ready. Unlike [Client.CreatePodIndex] with a SourceCollection, it checks the index's dimension and
+// environment against the collection, and that the metric is one Pinecone supports, before creating anything.
+//
+// Parameters:
+//   - ctx: A context.Context object controls the request's lifetime, allowing for the request
+//     to be canceled or to timeout according to the context's deadline.
+//   - in: A pointer to a [CreatePodIndexFromCollectionRequest] object.
+//   - opts: (Optional) [CallOption] values that apply to each underlying request, e.g. [WithHeader].
+//     [WithTimeout] bounds the whole call. [WithNamespace] has no effect.
+//
+// Returns a pointer to the created [Index] or an error.
+//
+// Example:
+//
+//	metric := pinecone.Dotproduct
+//	idx, err := pc.CreatePodIndexFromCollection(ctx, &pinecone.CreatePodIndexFromCollectionRequest{
+//		Collection: "my-collection",
+//		Name:       "my-index-copy",
+//		PodType:    "p1.x1",
+//		Metric:     &metric,
+//	})
+//	if err != nil {
+//		log.Fatalf("Failed to create index from collection: %v", err)
+//	}
+//	fmt.Printf("Creating index %s\n", idx.Name)
+func (c *Client) CreatePodIndexFromCollection(ctx context.Context, in *CreatePodIndexFromCollectionRequest, opts ...CallOption) (*Index, error) {
+	if in == nil {
+		return nil, fmt.Errorf("in (*CreatePodIndexFromCollectionRequest) cannot be nil")
+	}
+	if in.Collection == "" || in.Name == "" || in.PodType == "" {
+		return nil, fmt.Errorf("fields Collection, Name, and PodType must be included in CreatePodIndexFromCollectionRequest")
+	}
+	if in.Metric != nil && *in.Metric != Cosine && *in.Metric != Dotproduct && *in.Metric != Euclidean {
+		return nil, fmt.Errorf("invalid Metric %q: must be one of %q, %q, or %q", *in.Metric, Cosine, Dotproduct, Euclidean)
+	}
+	ctx, cancel := withClientCallOptions(ctx, opts)
+	defer cancel()
+
+	collection, err := c.WaitForCollectionReady(ctx, in.Collection)
+	if err != nil {
+		return nil, err
+	}
+	if in.Dimension != 0 && in.Dimension != collection.Dimension {
+		return nil, fmt.Errorf("dimension %d does not match the dimension %d of collection %q", in.Dimension, collection.Dimension, collection.Name)
+	}
+	if in.Environment != "" && collection.Environment != "" && in.Environment != collection.Environment {
+		return nil, fmt.Errorf("environment %q does not match the environment %q of collection %q", in.Environment, collection.Environment, collection.Name)
+	}
+
+	return c.CreatePodIndex(ctx, &CreatePodIndexRequest{
+		Name:               in.Name,
+		Dimension:          collection.Dimension,
+		Environment:        valueOrFallback(in.Environment, collection.Environment),
+		PodType:            in.PodType,
+		Shards:             in.Shards,
+		Replicas:           in.Replicas,
+		Metric:             in.Metric,
+		DeletionProtection: in.DeletionProtection,
+		SourceCollection:   &collection.Name,
+		MetadataConfig:     in.MetadataConfig,
+		Tags:               in.Tags,
+	})
+}
+
+// [MigratePodIndexToServerlessParams] contains the parameters for [Client.MigratePodIndexToServerless].
+//
+// Fields:
+//   - SourceIndex: (Required) The name of the pod-based index to migrate.
+//   - TargetIndex: (Required) The name of the serverless index to create.
+//   - Cloud: (Required) The cloud provider of the serverless index.
+//   - Region: (Required) The region of the serverless index.
+//   - Collection: (Optional) The name of the intermediate collection. Default is "<SourceIndex>-migration".
+//   - KeepCollection: (Optional) Whether to keep the collection after a successful migration.
+//   - DeletionProtection: (Optional) Deletion protection for the serverless index. Defaults to the source's.
+//   - Tags: (Optional) Custom user tags added to the serverless index. Defaults to the source's.
+//   - Timeout: (Optional) How long the whole migration may take. Default is 1 hour.
+//   - Transport: (Optional) The [IndexTransport] used to describe the serverless index's statistics.
+//     Defaults to [IndexTransportGRPC].
+//   - OnStep: (Optional) Called as each step of the migration starts, e.g. "creating collection".
+type MigratePodIndexToServerlessParams struct {
+	SourceIndex        string
+	TargetIndex        string
+	Cloud              Cloud
+	Region             string
+	Collection         string
+	KeepCollection     bool
+	DeletionProtection *DeletionProtection
+	Tags               *IndexTags
+	Timeout            time.Duration
+	Transport          IndexTransport
+	OnStep             func(step string)
+}
+
+// [PodIndexMigration] is the result of [Client.MigratePodIndexToServerless].
+//
+// Fields:
+//   - Collection: The collection the serverless index was created from.
+//   - Index: The ready serverless [Index].
+//   - ExpectedRecordCount: The number of records in the collection.
+//   - RecordCount: The number of records in the serverless index.
+//   - CollectionDeleted: Whether the collection was deleted after the migration.
+type PodIndexMigration struct {
+	Collection          *Collection `json:"collection"`
+	Index               *Index      `json:"index"`
+	ExpectedRecordCount int         `json:"expected_record_count"`
+	RecordCount         int         `json:"record_count"`
+	CollectionDeleted   bool        `json:"collection_deleted"`
+}
+
+// [Client.MigratePodIndexToServerless] copies a pod-based index into a new serverless index. It creates a
+// [Collection] of the source, waits for it to be ready, creates a serverless index with the source's dimension
+// and metric from the collection, waits for the index to be ready, and then waits until the index holds as many
+// records as the collection. The source index is left untouched; writes made to it after the collection is
+// created are not migrated.
+//
+// If any step fails, the serverless index and the collection created by the migration are deleted. After a
+// successful migration the collection is deleted unless KeepCollection is set.
+//
+// Parameters:
+//   - ctx: A context.Context object controls the request's lifetime, allowing for the request
+//     to be canceled or to timeout according to the context's deadline.
+//   - in: A pointer to a [MigratePodIndexToServerlessParams] object.
+//   - opts: (Optional) [CallOption] values that apply to each underlying request, e.g. [WithHeader].
+//     [WithTimeout] bounds the whole call. [WithNamespace] has no effect.
+//
+// Returns a pointer to a [PodIndexMigration] or an error.
+//
+// Example:
+//
+//	migration, err := pc.MigratePodIndexToServerless(ctx, &pinecone.MigratePodIndexToServerlessParams{
+//		SourceIndex: "my-pod-index",
+//		TargetIndex: "my-serverless-index",
+//		Cloud:       pinecone.Aws,
+//		Region:      "us-east-1",
+//		OnStep:      func(step string) { log.Println(step) },
+//	})
+//	if err != nil {
+//		log.Fatalf("Failed to migrate index: %v", err)
+//	}
+//	fmt.Printf("Migrated %d records to %s\n", migration.RecordCount, migration.Index.Name)
+func (c *Client) MigratePodIndexToServerless(ctx context.Context, in *MigratePodIndexToServerlessParams, opts ...CallOption) (*PodIndexMigration, error) {
+	if in == nil {
+		return nil, fmt.Errorf("in (*MigratePodIndexToServerlessParams) cannot be nil")
+	}
+	if in.SourceIndex == "" || in.TargetIndex == "" || in.Cloud == "" || in.Region == "" {
+		return nil, fmt.Errorf("fields SourceIndex, TargetIndex, Cloud, and Region must be included in MigratePodIndexToServerlessParams")
+	}
+	ctx, cancelOpts := withClientCallOptions(ctx, opts)
+	defer cancelOpts()
+	ctx, cancel := context.WithTimeout(ctx, valueOrFallback(in.Timeout, time.Hour))
+	defer cancel()
+	step := func(name string) {
+		if in.OnStep != nil {
+			in.OnStep(name)
+		}
+	}
+
+	source, err := c.DescribeIndex(ctx, in.SourceIndex)
+	if err != nil {
+		return nil, err
+	}
+	if source.Spec == nil || source.Spec.Pod == nil {
+		return nil, fmt.Errorf("index %q is not a pod-based index", in.SourceIndex)
+	}
+
+	migration := &PodIndexMigration{}
+	var createdCollection, createdIndex string
+	fail := func(err error) (*PodIndexMigration, error) {
+		// Clean up with a fresh deadline, since ctx may be why the migration failed.
+		cleanupCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), time.Minute)
+		defer cancel()
+		errs := []error{err}
+		if createdIndex != "" {
+			step("deleting index " + createdIndex)
+			if err := c.DeleteIndex(cleanupCtx, createdIndex); err != nil {
+				errs = append(errs, fmt.Errorf("failed to delete index %q: %w", createdIndex, err))
+			}
+		}
+		if createdCollection != "" {
+			step("deleting collection " + createdCollection)
+			if err := c.DeleteCollection(cleanupCtx, createdCollection); err != nil {
+				errs = append(errs, fmt.Errorf("failed to delete collection %q: %w", createdCollection, err))
+			}
+		}
+		return migration, errors.Join(errs...)
+	}
+
+	collectionName := valueOrFallback(in.Collection, in.SourceIndex+"-migration")
+	step("creating collection " + collectionName)
+	if _, err := c.CreateCollection(ctx, &CreateCollectionRequest{Name: collectionName, Source: in.SourceIndex}); err != nil {
+		return fail(err)
+	}
+	createdCollection = collectionName
+	if migration.Collection, err = c.WaitForCollectionReady(ctx, collectionName); err != nil {
+		return fail(err)
+	}
+	migration.ExpectedRecordCount = int(migration.Collection.VectorCount)
+
+	step("creating serverless index " + in.TargetIndex)
+	// Deletion protection is turned on only once the index is verified, so a failed migration can delete it.
+	metric := source.Metric
+	unprotected := DeletionProtectionDisabled
+	if _, err := c.CreateServerlessIndex(ctx, &CreateServerlessIndexRequest{
+		Name:               in.TargetIndex,
+		Cloud:              in.Cloud,
+		Region:             in.Region,
+		Metric:             &metric,
+		Dimension:          &migration.Collection.Dimension,
+		DeletionProtection: &unprotected,
+		Tags:               valueOrFallback(in.Tags, source.Tags),
+		SourceCollection:   &collectionName,
+	}); err != nil {
+		return fail(err)
+	}
+	createdIndex = in.TargetIndex
+	deadline, _ := ctx.Deadline()
+	if migration.Index, err = waitForIndexReady(ctx, c, in.TargetIndex, time.Until(deadline)); err != nil {
+		return fail(err)
+	}
+
+	step("verifying record counts")
+	if err := c.waitForRecordCount(ctx, migration, in.Transport); err != nil {
+		return fail(err)
+	}
+
+	if protection := valueOrFallback(in.DeletionProtection, deletionProtectionOf(source)); protection != nil && *protection == DeletionProtectionEnabled {
+		step("enabling deletion protection")
+		if migration.Index, err = c.ConfigureIndex(ctx, in.TargetIndex, ConfigureIndexParams{DeletionProtection: DeletionProtectionEnabled}); err != nil {
+			return fail(err)
+		}
+	}
+
+	if !in.KeepCollection {
+		step("deleting collection " + collectionName)
+		if err := c.DeleteCollection(ctx, collectionName); err != nil {
+			return migration, fmt.Errorf("migrated index %q, but failed to delete collection %q: %w", in.TargetIndex, collectionName, err)
+		}
+		migration.CollectionDeleted = true
+	}
+	return migration, nil
+}
+
+// waitForRecordCount polls the statistics of the migrated index until it holds as many records as its collection.
+func (c *Client) waitForRecordCount(ctx context.Context, migration *PodIndexMigration, transport IndexTransport) error {
+	conn, err := c.Index(NewIndexConnParams{Host: migration.Index.Host, Transport: transport})
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	for {
+		stats, err := conn.DescribeIndexStats(ctx)
+		if err == nil {
+			migration.RecordCount = int(stats.TotalVectorCount)
+			if migration.RecordCount == migration.ExpectedRecordCount {
+				return nil
+			}
+		} else if ctx.Err() == nil {
+			return err
+		}
+		if !wait(ctx, indexReadyPollInterval) {
+			return fmt.Errorf("index %q has %d records, but collection %q has %d", migration.Index.Name, migration.RecordCount, migration.Collection.Name, migration.ExpectedRecordCount)
+		}
+	}
+}
@@ -0,0 +1,145 @@
+package pinecone
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// podIndex returns a pod-based index named name, for [fakeControlPlane.addIndex].
+func podIndex(name string, deletionProtection DeletionProtection) map[string]any {
+	return map[string]any{"name": name, "metric": "dotproduct", "dimension": 3, "vector_type": "dense", "deletion_protection": deletionProtection,
+		"spec": map[string]any{"pod": map[string]any{"environment": "us-east1-gcp", "pod_type": "p1.x1", "pods": 1, "replicas": 1, "shards": 1}}}
+}
+
+// Unit tests:
+func TestWaitForCollectionReadyUnit(t *testing.T) {
+	previous := indexReadyPollInterval
+	indexReadyPollInterval = time.Millisecond
+	defer func() { indexReadyPollInterval = previous }()
+
+	server := newFakeControlPlane(t)
+	pc := server.client(t)
+	_, err := pc.CreateCollection(context.Background(), &CreateCollectionRequest{Name: "docs-collection", Source: "docs"})
+	require.NoError(t, err)
+
+	collection, err := pc.WaitForCollectionReady(context.Background(), "docs-collection")
+	require.NoError(t, err)
+	assert.Equal(t, CollectionStatusReady, collection.Status)
+	assert.Equal(t, 2, server.polls["docs-collection"])
+
+	server.collections["docs-collection"]["status"] = "Terminating"
+	_, err = pc.WaitForCollectionReady(context.Background(), "docs-collection")
+	assert.ErrorContains(t, err, `collection "docs-collection" is terminating`)
+
+	_, err = pc.WaitForCollectionReady(context.Background(), "missing")
+	assert.Error(t, err)
+}
+
+func TestCreatePodIndexFromCollectionUnit(t *testing.T) {
+	previous := indexReadyPollInterval
+	indexReadyPollInterval = time.Millisecond
+	defer func() { indexReadyPollInterval = previous }()
+
+	server := newFakeControlPlane(t)
+	pc := server.client(t)
+	_, err := pc.CreateCollection(context.Background(), &CreateCollectionRequest{Name: "docs-collection", Source: "docs"})
+	require.NoError(t, err)
+
+	_, err = pc.CreatePodIndexFromCollection(context.Background(), &CreatePodIndexFromCollectionRequest{Collection: "docs-collection", Name: "copy", PodType: "p1.x1", Dimension: 1536})
+	assert.ErrorContains(t, err, `dimension 1536 does not match the dimension 3 of collection "docs-collection"`)
+	_, err = pc.CreatePodIndexFromCollection(context.Background(), &CreatePodIndexFromCollectionRequest{Collection: "docs-collection", Name: "copy", PodType: "p1.x1", Environment: "us-west1-gcp"})
+	assert.ErrorContains(t, err, `environment "us-west1-gcp" does not match`)
+	metric := IndexMetric("hamming")
+	_, err = pc.CreatePodIndexFromCollection(context.Background(), &CreatePodIndexFromCollectionRequest{Collection: "docs-collection", Name: "copy", PodType: "p1.x1", Metric: &metric})
+	assert.ErrorContains(t, err, `invalid Metric "hamming"`)
+	assert.Empty(t, server.creates)
+
+	metric = Dotproduct
+	idx, err := pc.CreatePodIndexFromCollection(context.Background(), &CreatePodIndexFromCollectionRequest{Collection: "docs-collection", Name: "copy", PodType: "p1.x1", Metric: &metric})
+	require.NoError(t, err)
+	assert.Equal(t, "copy", idx.Name)
+	require.Len(t, server.creates, 1)
+	assert.Equal(t, float64(3), server.creates[0]["dimension"])
+	assert.Equal(t, "dotproduct", server.creates[0]["metric"])
+	pod := server.creates[0]["spec"].(map[string]any)["pod"].(map[string]any)
+	assert.Equal(t, "docs-collection", pod["source_collection"])
+	assert.Equal(t, "us-east1-gcp", pod["environment"])
+}
+
+func TestMigratePodIndexToServerlessUnit(t *testing.T) {
+	previous := indexReadyPollInterval
+	indexReadyPollInterval = time.Millisecond
+	defer func() { indexReadyPollInterval = previous }()
+
+	server := newFakeControlPlane(t)
+	server.addIndex(podIndex("docs", DeletionProtectionEnabled))
+	server.fillNamespace("", 5)
+	pc := server.client(t)
+
+	var steps []string
+	migration, err := pc.MigratePodIndexToServerless(context.Background(), &MigratePodIndexToServerlessParams{
+		SourceIndex: "docs",
+		TargetIndex: "docs-serverless",
+		Cloud:       Aws,
+		Region:      "us-east-1",
+		Transport:   IndexTransportREST,
+		OnStep:      func(step string) { steps = append(steps, step) },
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"creating collection docs-migration",
+		"creating serverless index docs-serverless",
+		"verifying record counts",
+		"enabling deletion protection",
+		"deleting collection docs-migration",
+	}, steps)
+	assert.Equal(t, 5, migration.ExpectedRecordCount)
+	assert.Equal(t, 5, migration.RecordCount)
+	assert.True(t, migration.CollectionDeleted)
+	assert.Equal(t, DeletionProtectionEnabled, migration.Index.DeletionProtection)
+
+	require.Len(t, server.creates, 1)
+	assert.Equal(t, "dotproduct", server.creates[0]["metric"])
+	assert.Equal(t, "disabled", server.creates[0]["deletion_protection"])
+	serverless := server.creates[0]["spec"].(map[string]any)["serverless"].(map[string]any)
+	assert.Equal(t, "docs-migration", serverless["source_collection"])
+	assert.Equal(t, []string{"collection docs-migration"}, server.deleted)
+}
+
+func TestMigratePodIndexToServerlessCleansUpUnit(t *testing.T) {
+	previous := indexReadyPollInterval
+	indexReadyPollInterval = time.Millisecond
+	defer func() { indexReadyPollInterval = previous }()
+
+	server := newFakeControlPlane(t)
+	server.addIndex(podIndex("docs", DeletionProtectionDisabled))
+	server.fillNamespace("", 5)
+	pc := server.client(t)
+
+	migration, err := pc.MigratePodIndexToServerless(context.Background(), &MigratePodIndexToServerlessParams{
+		SourceIndex: "docs",
+		TargetIndex: "docs-serverless",
+		Cloud:       Aws,
+		Region:      "us-east-1",
+		Transport:   IndexTransportREST,
+		Timeout:     100 * time.Millisecond,
+		OnStep: func(step string) {
+			// A record is lost between taking the collection and creating the index from it.
+			if step == "creating serverless index docs-serverless" {
+				server.fillNamespace("", 4)
+			}
+		},
+	})
+	assert.ErrorContains(t, err, `index "docs-serverless" has 4 records, but collection "docs-migration" has 5`)
+	assert.Equal(t, 4, migration.RecordCount)
+	assert.Equal(t, []string{"index docs-serverless", "collection docs-migration"}, server.deleted)
+	assert.Nil(t, server.index("docs-serverless"))
+
+	server.index("docs")["spec"] = map[string]any{"serverless": map[string]any{"cloud": "aws", "region": "us-east-1"}}
+	_, err = pc.MigratePodIndexToServerless(context.Background(), &MigratePodIndexToServerlessParams{SourceIndex: "docs", TargetIndex: "docs-serverless", Cloud: Aws, Region: "us-east-1"})
+	assert.ErrorContains(t, err, `index "docs" is not a pod-based index`)
+}
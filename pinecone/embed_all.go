@@ -0,0 +1,332 @@
+package pinecone
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"syscall"
+)
+
+// defaultEmbedAllConcurrency is the number of batches [InferenceService.EmbedAll] sends at once when
+// [EmbedAllRequest.MaxConcurrency] is not set.
+const defaultEmbedAllConcurrency = 4
+
+// [EmbedAllRequest] holds the parameters for [InferenceService.EmbedAll].
+//
+// Fields:
+//   - Model: (Required) The model to use for generating embeddings.
+//   - TextInputs: (Required) A list of strings to generate embeddings for. There is no limit on the number of inputs.
+//   - Parameters: (Optional) EmbedParameters object that contains additional parameters to use when generating embeddings.
+//   - BatchSize: (Optional) The number of inputs sent per request. Defaults to, and may not exceed, the model's
+//     [ModelInfo.MaxBatchSize].
+//   - MaxConcurrency: (Optional) The maximum number of batches in flight at once. Defaults to 4.
+//   - BatchRetryPolicy: (Optional) A [RetryPolicy] controlling how a failed batch is retried. Only that batch is
+//     resent. Defaults to [DefaultRetryPolicy]; pass &RetryPolicy{} to disable batch retries.
+type EmbedAllRequest struct {
+	Model            string
+	TextInputs       []string
+	Parameters       EmbedParameters
+	BatchSize        int
+	MaxConcurrency   int
+	BatchRetryPolicy *RetryPolicy
+}
+
+// [InferenceService.EmbedAll] generates embeddings for any number of inputs. It looks up the model's [ModelInfo]
+// through [InferenceService.DescribeModel] (caching it for later calls), splits the inputs into batches of at
+// most [ModelInfo.MaxBatchSize], and sends the batches concurrently through [InferenceService.Embed].
+// The embeddings are returned in the same order as [EmbedAllRequest.TextInputs], and Usage.TotalTokens and
+// Cache are summed across all batches.
+//
+// A batch that fails with a rate limit, server, or network error is retried on its own according to
+// [EmbedAllRequest.BatchRetryPolicy]. Errors the client's [RetryPolicy] already retried are not retried again:
+// rate limits whenever it allows retries, and server and network errors when [OperationEmbed] is one of its
+// IdempotentOperations. Other errors, such as invalid parameters, are returned at once. If a batch still fails,
+// the remaining batches are cancelled and the error is returned.
+//
+// When the "truncate" parameter is "NONE", inputs with more whitespace-separated words than the model's
+// [ModelInfo.MaxSequenceLength] are rejected before any request is sent, since each word is at least one token.
+//
+// Parameters:
+//   - ctx: A context.Context object controls the request's lifetime, allowing for the request
+//     to be canceled or to timeout according to the context's deadline.
+//   - in: A pointer to an [EmbedAllRequest] object.
+//   - opts: (Optional) [CallOption] values that apply to each underlying request, e.g. [WithHeader].
+//     [WithTimeout] bounds the whole call.
+//
+// Returns a pointer to an [EmbedResponse] object or an error.
+//
+// Example:
+//
+//	    ctx := context.Background()
+//
+//	    pc, err := pinecone.NewClient(pinecone.NewClientParams{
+//		       ApiKey: "YOUR_API_KEY",
+//	    })
+//	    if err != nil {
+//		       log.Fatalf("Failed to create Client: %v", err)
+//	    }
+//
+//	    res, err := pc.Inference.EmbedAll(ctx, &pinecone.EmbedAllRequest{
+//		       Model:          "multilingual-e5-large",
+//		       TextInputs:     documents,
+//		       Parameters:     pinecone.EmbedParameters{"input_type": "passage", "truncate": "END"},
+//		       MaxConcurrency: 8,
+//	    })
+//	    if err != nil {
+//		       log.Fatalf("Failed to embed: %v", err)
+//	    }
+//	    fmt.Printf("Generated %d embeddings using %d tokens", len(res.Data), *res.Usage.TotalTokens)
+func (i *InferenceService) EmbedAll(ctx context.Context, in *EmbedAllRequest, opts ...CallOption) (*EmbedResponse, error) {
+	if in == nil {
+		return nil, fmt.Errorf("in (*EmbedAllRequest) cannot be nil")
+	}
+	if len(in.TextInputs) == 0 {
+		return nil, fmt.Errorf("TextInputs must contain at least one value")
+	}
+	if in.BatchSize < 0 || in.MaxConcurrency < 0 {
+		return nil, fmt.Errorf("BatchSize and MaxConcurrency cannot be negative")
+	}
+	retryPolicy := in.BatchRetryPolicy
+	if retryPolicy == nil {
+		retryPolicy = DefaultRetryPolicy()
+	}
+	if err := retryPolicy.validate(); err != nil {
+		return nil, err
+	}
+
+	// The timeout bounds the whole call, so it is applied here rather than to each batch.
+	ctx, cancel := withCallOptions(ctx, opts)
+	defer cancel()
+
+	model, err := i.describeModelCached(ctx, in.Model)
+	if err != nil {
+		return nil, err
+	}
+	batchSize, err := embedBatchSize(model, in.BatchSize, len(in.TextInputs))
+	if err != nil {
+		return nil, err
+	}
+	if err := checkSequenceLength(model, in.Parameters, in.TextInputs); err != nil {
+		return nil, err
+	}
+
+	concurrency := in.MaxConcurrency
+	if concurrency == 0 {
+		concurrency = defaultEmbedAllConcurrency
+	}
+
+	type batch struct{ start, end int }
+	var batches []batch
+	for start := 0; start < len(in.TextInputs); start += batchSize {
+		batches = append(batches, batch{start, min(start+batchSize, len(in.TextInputs))})
+	}
+
+	responses := make([]*EmbedResponse, len(batches))
+	err = forEachBatch(ctx, len(batches), concurrency, func(ctx context.Context, n int) error {
+		b := batches[n]
+		res, err := i.embedBatch(ctx, retryPolicy, &EmbedRequest{
+			Model:      in.Model,
+			TextInputs: in.TextInputs[b.start:b.end],
+			Parameters: in.Parameters,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to embed inputs %d-%d: %w", b.start, b.end-1, err)
+		}
+		responses[n] = res
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	out := &EmbedResponse{Data: make([]Embedding, 0, len(in.TextInputs))}
+	var totalTokens int32
+	var hasTokens bool
+	for _, res := range responses {
+		out.Data = append(out.Data, res.Data...)
+		out.Model = res.Model
+		out.VectorType = res.VectorType
+		if res.Usage.TotalTokens != nil {
+			totalTokens += *res.Usage.TotalTokens
+			hasTokens = true
+		}
+		if res.Cache != nil {
+			if out.Cache == nil {
+				out.Cache = &EmbedCacheInfo{}
+			}
+			out.Cache.Hits += res.Cache.Hits
+			out.Cache.Misses += res.Cache.Misses
+		}
+	}
+	if hasTokens {
+		out.Usage.TotalTokens = &totalTokens
+	}
+	return out, nil
+}
+
+// embedBatch calls Embed for a single batch, retrying retryable failures according to policy.
+func (i *InferenceService) embedBatch(ctx context.Context, policy *RetryPolicy, req *EmbedRequest) (*EmbedResponse, error) {
+	return retryBatch(ctx, policy, i.transportRetryPolicy(ctx), OperationEmbed, func() (*EmbedResponse, error) {
+		res, err := i.Embed(ctx, req)
+		if err == nil && len(res.Data) != len(req.TextInputs) {
+			return nil, fmt.Errorf("expected %d embeddings, got %d", len(req.TextInputs), len(res.Data))
+		}
+		return res, err
+	})
+}
+
+// forEachBatch calls fn for batches 0 to count-1, running at most concurrency calls at once. The first error
+// returned by fn cancels the context passed to the remaining calls and is returned once all calls have finished.
+func forEachBatch(ctx context.Context, count int, concurrency int, fn func(ctx context.Context, n int) error) error {
+	ctx, cancelBatches := context.WithCancel(ctx)
+	defer cancelBatches()
+
+	var (
+		wg       sync.WaitGroup
+		errOnce  sync.Once
+		firstErr error
+	)
+	sem := make(chan struct{}, concurrency)
+	for n := 0; n < count; n++ {
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+		}
+		if ctx.Err() != nil {
+			break
+		}
+		wg.Add(1)
+		go func(n int) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			if err := fn(ctx, n); err != nil {
+				errOnce.Do(func() {
+					firstErr = err
+					cancelBatches()
+				})
+			}
+		}(n)
+	}
+	wg.Wait()
+
+	if firstErr != nil {
+		return firstErr
+	}
+	return ctx.Err()
+}
+
+// retryBatch calls fn, which performs op, retrying failures that [isRetryableError] accepts according to
+// policy. transport is the policy the REST transport already applied to each call of fn.
+func retryBatch[T any](ctx context.Context, policy, transport *RetryPolicy, op Operation, fn func() (T, error)) (T, error) {
+	for attempt := 0; ; attempt++ {
+		res, err := fn()
+		if err == nil {
+			return res, nil
+		}
+		if attempt >= policy.MaxRetries || !isRetryableError(ctx, err, transport, op) {
+			var zero T
+			return zero, err
+		}
+		if !wait(ctx, policy.backoff(attempt, 0)) {
+			var zero T
+			return zero, ctx.Err()
+		}
+	}
+}
+
+// isRetryableError reports whether a call performing op that failed with err should be sent again: a rate
+// limit or server error returned by the API, or a network error, that the REST transport did not already
+// retry under transport. Any other error, such as a validation or decoding error, is permanent.
+func isRetryableError(ctx context.Context, err error, transport *RetryPolicy, op Operation) bool {
+	if ctx.Err() != nil || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	// The transport retries rate limits under any policy with retries, but server and network errors only
+	// for operations opted in as idempotent. Retrying those again here would multiply the attempts.
+	transportRetries := transport != nil && transport.MaxRetries > 0
+	transportReplays := transportRetries && slices.Contains(transport.IdempotentOperations, op)
+	var perr *PineconeError
+	if errors.As(err, &perr) {
+		switch {
+		case perr.Code == http.StatusTooManyRequests:
+			return !transportRetries
+		case perr.Code >= http.StatusInternalServerError:
+			return !transportReplays
+		}
+		return false
+	}
+	return isNetworkError(err) && !transportReplays
+}
+
+// isNetworkError reports whether err is a transport-level failure: a network error, a connection reset, or a
+// response cut short.
+func isNetworkError(err error) bool {
+	var netErr net.Error
+	return errors.As(err, &netErr) || errors.Is(err, io.ErrUnexpectedEOF) || errors.Is(err, syscall.ECONNRESET)
+}
+
+// transportRetryPolicy returns the [RetryPolicy] the REST transport applies to calls made with ctx: the one
+// passed with [WithRetryPolicy], if any, and otherwise the client's.
+func (i *InferenceService) transportRetryPolicy(ctx context.Context) *RetryPolicy {
+	if o := callOptionsFrom(ctx); o != nil && o.retryPolicy != nil {
+		return o.retryPolicy
+	}
+	return i.retryPolicy
+}
+
+// describeModelCached returns the [ModelInfo] for model, calling DescribeModel only the first time.
+func (i *InferenceService) describeModelCached(ctx context.Context, model string) (*ModelInfo, error) {
+	i.modelsMu.Lock()
+	info, ok := i.models[model]
+	i.modelsMu.Unlock()
+	if ok {
+		return info, nil
+	}
+
+	info, err := i.DescribeModel(ctx, model)
+	if err != nil {
+		return nil, err
+	}
+
+	i.modelsMu.Lock()
+	defer i.modelsMu.Unlock()
+	if i.models == nil {
+		i.models = make(map[string]*ModelInfo)
+	}
+	i.models[model] = info
+	return info, nil
+}
+
+func embedBatchSize(model *ModelInfo, requested int, inputs int) (int, error) {
+	maxBatchSize := int(derefOrDefault(model.MaxBatchSize, 0))
+	switch {
+	case requested > 0 && maxBatchSize > 0 && requested > maxBatchSize:
+		return 0, fmt.Errorf("BatchSize %d exceeds the maximum batch size of %d for model %q", requested, maxBatchSize, model.Model)
+	case requested > 0:
+		return requested, nil
+	case maxBatchSize > 0:
+		return maxBatchSize, nil
+	default:
+		return inputs, nil
+	}
+}
+
+func checkSequenceLength(model *ModelInfo, params EmbedParameters, inputs []string) error {
+	maxLength := int(derefOrDefault(model.MaxSequenceLength, 0))
+	truncate, _ := params["truncate"].(string)
+	if maxLength == 0 || !strings.EqualFold(truncate, "NONE") {
+		return nil
+	}
+	for n, input := range inputs {
+		if words := len(strings.Fields(input)); words > maxLength {
+			return fmt.Errorf("TextInputs[%d] has %d words, which exceeds the maximum sequence length of %d tokens for model %q", n, words, maxLength, model.Model)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,255 @@
+package pinecone
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// embedServer serves /models/{model} and /embed, returning each input's number (parsed from the text) as its
+// single embedding value and one token per input.
+type embedServer struct {
+	maxBatchSize int
+	failFirst    map[string]int // first input of a batch -> number of 503s to return before succeeding
+
+	mu          sync.Mutex
+	modelCalls  int32
+	batches     [][]string
+	inFlight    int32
+	maxInFlight int32
+}
+
+func (s *embedServer) handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /models/{model}", func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&s.modelCalls, 1)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprintf(w, `{"model":%q,"type":"embed","short_description":"test","max_batch_size":%d,"max_sequence_length":3}`,
+			r.PathValue("model"), s.maxBatchSize)
+	})
+	mux.HandleFunc("POST /embed", func(w http.ResponseWriter, r *http.Request) {
+		inFlight := atomic.AddInt32(&s.inFlight, 1)
+		defer atomic.AddInt32(&s.inFlight, -1)
+		for {
+			max := atomic.LoadInt32(&s.maxInFlight)
+			if inFlight <= max || atomic.CompareAndSwapInt32(&s.maxInFlight, max, inFlight) {
+				break
+			}
+		}
+		time.Sleep(5 * time.Millisecond)
+
+		var body struct {
+			Model  string `json:"model"`
+			Inputs []struct {
+				Text string `json:"text"`
+			} `json:"inputs"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil || len(body.Inputs) == 0 {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		texts := make([]string, len(body.Inputs))
+		for i, input := range body.Inputs {
+			texts[i] = input.Text
+		}
+		s.mu.Lock()
+		s.batches = append(s.batches, texts)
+		fail := s.failFirst[texts[0]] > 0
+		if fail {
+			s.failFirst[texts[0]]--
+		}
+		s.mu.Unlock()
+		if fail {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			_, _ = w.Write([]byte(`{"error":{"code":"UNAVAILABLE","message":"try again"}}`))
+			return
+		}
+
+		data := make([]map[string]any, len(texts))
+		for i, text := range texts {
+			n, _ := strconv.Atoi(text)
+			data[i] = map[string]any{"vector_type": "dense", "values": []float32{float32(n)}}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"model": body.Model, "vector_type": "dense", "data": data, "usage": map[string]any{"total_tokens": len(texts)},
+		})
+	})
+	return mux
+}
+
+func numberedInputs(n int) []string {
+	inputs := make([]string, n)
+	for i := range inputs {
+		inputs[i] = strconv.Itoa(i)
+	}
+	return inputs
+}
+
+func newEmbedAllClient(t *testing.T, s *embedServer) *Client {
+	t.Helper()
+	srv := httptest.NewServer(s.handler())
+	t.Cleanup(srv.Close)
+	pc, err := NewClient(NewClientParams{ApiKey: "test-key", Host: srv.URL})
+	require.NoError(t, err)
+	return pc
+}
+
+// Unit tests:
+func TestEmbedAllBatchesInOrderUnit(t *testing.T) {
+	s := &embedServer{maxBatchSize: 4}
+	pc := newEmbedAllClient(t, s)
+
+	res, err := pc.Inference.EmbedAll(context.Background(), &EmbedAllRequest{
+		Model:          "test-model",
+		TextInputs:     numberedInputs(10),
+		MaxConcurrency: 2,
+	})
+	require.NoError(t, err)
+
+	require.Len(t, res.Data, 10)
+	for i, embedding := range res.Data {
+		require.NotNil(t, embedding.DenseEmbedding)
+		assert.Equal(t, []float32{float32(i)}, embedding.DenseEmbedding.Values, "embeddings should be in input order")
+	}
+	assert.Equal(t, "test-model", res.Model)
+	assert.Equal(t, "dense", res.VectorType)
+	require.NotNil(t, res.Usage.TotalTokens)
+	assert.Equal(t, int32(10), *res.Usage.TotalTokens)
+
+	require.Len(t, s.batches, 3)
+	for _, batch := range s.batches {
+		assert.LessOrEqual(t, len(batch), 4)
+	}
+	assert.LessOrEqual(t, atomic.LoadInt32(&s.maxInFlight), int32(2))
+
+	// The model info is cached on the InferenceService.
+	_, err = pc.Inference.EmbedAll(context.Background(), &EmbedAllRequest{Model: "test-model", TextInputs: numberedInputs(1)})
+	require.NoError(t, err)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&s.modelCalls))
+}
+
+func TestEmbedAllRetriesFailedBatchOnlyUnit(t *testing.T) {
+	s := &embedServer{maxBatchSize: 2, failFirst: map[string]int{"2": 2}}
+	pc := newEmbedAllClient(t, s)
+
+	res, err := pc.Inference.EmbedAll(context.Background(), &EmbedAllRequest{
+		Model:            "test-model",
+		TextInputs:       numberedInputs(6),
+		BatchRetryPolicy: fastPolicy(2),
+	})
+	require.NoError(t, err)
+	require.Len(t, res.Data, 6)
+	assert.Equal(t, []float32{2}, res.Data[2].DenseEmbedding.Values)
+
+	counts := make(map[string]int)
+	for _, batch := range s.batches {
+		counts[batch[0]]++
+	}
+	assert.Equal(t, map[string]int{"0": 1, "2": 3, "4": 1}, counts, "only the failing batch should be resent")
+}
+
+func TestEmbedAllGivesUpUnit(t *testing.T) {
+	s := &embedServer{maxBatchSize: 2, failFirst: map[string]int{"2": 5}}
+	pc := newEmbedAllClient(t, s)
+
+	_, err := pc.Inference.EmbedAll(context.Background(), &EmbedAllRequest{
+		Model:            "test-model",
+		TextInputs:       numberedInputs(4),
+		BatchRetryPolicy: fastPolicy(1),
+	})
+	require.ErrorContains(t, err, "failed to embed inputs 2-3")
+	var perr *PineconeError
+	require.ErrorAs(t, err, &perr)
+	assert.Equal(t, http.StatusServiceUnavailable, perr.Code)
+}
+
+func TestEmbedAllValidationUnit(t *testing.T) {
+	s := &embedServer{maxBatchSize: 2}
+	pc := newEmbedAllClient(t, s)
+	ctx := context.Background()
+
+	_, err := pc.Inference.EmbedAll(ctx, nil)
+	require.ErrorContains(t, err, "cannot be nil")
+
+	_, err = pc.Inference.EmbedAll(ctx, &EmbedAllRequest{Model: "test-model"})
+	require.ErrorContains(t, err, "TextInputs")
+
+	_, err = pc.Inference.EmbedAll(ctx, &EmbedAllRequest{Model: "test-model", TextInputs: numberedInputs(3), BatchSize: 3})
+	require.ErrorContains(t, err, "exceeds the maximum batch size of 2")
+
+	_, err = pc.Inference.EmbedAll(ctx, &EmbedAllRequest{
+		Model:      "test-model",
+		TextInputs: []string{"1", "one two three four"},
+		Parameters: EmbedParameters{"truncate": "NONE"},
+	})
+	require.ErrorContains(t, err, "TextInputs[1] has 4 words")
+	assert.Empty(t, s.batches, "no batch should be sent when an input is too long")
+}
+
+func TestIsRetryableErrorUnit(t *testing.T) {
+	ctx := context.Background()
+	transport := fastPolicy(3)
+	replaying := fastPolicy(3)
+	replaying.IdempotentOperations = []Operation{OperationEmbed}
+	netErr := &net.OpError{Op: "dial", Net: "tcp", Err: syscall.ECONNREFUSED}
+
+	tests := []struct {
+		name      string
+		err       error
+		transport *RetryPolicy
+		want      bool
+	}{
+		{"rate limit", &PineconeError{Code: http.StatusTooManyRequests}, nil, true},
+		{"rate limit retried by transport", &PineconeError{Code: http.StatusTooManyRequests}, transport, false},
+		{"server error", &PineconeError{Code: http.StatusServiceUnavailable}, transport, true},
+		{"server error replayed by transport", &PineconeError{Code: http.StatusServiceUnavailable}, replaying, false},
+		{"bad request", &PineconeError{Code: http.StatusBadRequest}, nil, false},
+		{"network error", fmt.Errorf("embed: %w", netErr), transport, true},
+		{"network error replayed by transport", netErr, replaying, false},
+		{"connection reset", fmt.Errorf("read: %w", syscall.ECONNRESET), nil, true},
+		{"truncated response", io.ErrUnexpectedEOF, nil, true},
+		{"parameter validation", &ParameterValidationError{}, nil, false},
+		{"count mismatch", fmt.Errorf("expected 2 embeddings, got 1"), nil, false},
+		{"decode failure", &json.SyntaxError{}, nil, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, isRetryableError(ctx, tt.err, tt.transport, OperationEmbed))
+		})
+	}
+}
+
+func TestEmbedAllDoesNotRetryPermanentErrorsUnit(t *testing.T) {
+	var calls int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.Method == http.MethodGet {
+			_, _ = w.Write([]byte(`{"model":"test-model","type":"embed","short_description":"test","max_batch_size":2}`))
+			return
+		}
+		atomic.AddInt32(&calls, 1)
+		_, _ = w.Write([]byte(`{"model":"test-model","vector_type":"dense","data":[],"usage":{"total_tokens":0}}`))
+	}))
+	defer srv.Close()
+	pc, err := NewClient(NewClientParams{ApiKey: "test-key", Host: srv.URL})
+	require.NoError(t, err)
+
+	_, err = pc.Inference.EmbedAll(context.Background(), &EmbedAllRequest{Model: "test-model", TextInputs: numberedInputs(2), BatchRetryPolicy: fastPolicy(3)})
+	require.ErrorContains(t, err, "expected 2 embeddings, got 0")
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls), "a count mismatch should not be retried")
+}
@@ -0,0 +1,308 @@
+package pinecone
+
+import (
+	"container/list"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+	"time"
+)
+
+// [EmbeddingCache] stores embeddings generated by [InferenceService.Embed] so that repeated inputs are not sent to
+// the API again. Set one through [NewClientParams.EmbeddingCache] or [NewClientBaseParams.EmbeddingCache].
+// Keys are opaque strings derived from the model, the embed parameters, the input type, and a hash of the
+// input text. Implementations must be safe for concurrent use.
+//
+// The SDK ships [LRUEmbeddingCache], an in-memory cache, and [FileEmbeddingCache], which persists embeddings
+// on disk.
+type EmbeddingCache interface {
+	// Get returns the embedding stored under key, and whether it was found.
+	Get(key string) (Embedding, bool)
+	// Set stores embedding under key.
+	Set(key string, embedding Embedding)
+}
+
+// [EmbedCacheInfo] reports how an [InferenceService.Embed] call used the client's [EmbeddingCache].
+//
+// Fields:
+//   - Hits: The number of inputs whose embeddings were served from the cache.
+//   - Misses: The number of inputs that were sent to the API.
+type EmbedCacheInfo struct {
+	Hits   int `json:"hits"`
+	Misses int `json:"misses"`
+}
+
+// embeddingCacheKey derives the cache key for text embedded by model with params. The input type is part of
+// params, but it is keyed separately so that it is never collapsed with other parameters.
+func embeddingCacheKey(model string, params EmbedParameters, text string) string {
+	// json.Marshal sorts map keys, so equal parameters always produce the same key.
+	paramsJson, _ := json.Marshal(params)
+	inputType, _ := params["input_type"].(string)
+	textHash := sha256.Sum256([]byte(text))
+
+	h := sha256.New()
+	for _, part := range []string{model, string(paramsJson), inputType, hex.EncodeToString(textHash[:])} {
+		h.Write([]byte(part))
+		h.Write([]byte{0})
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// [LRUEmbeddingCache] is an in-memory [EmbeddingCache] that holds up to a fixed number of embeddings, evicting
+// the least recently used entry when full. Entries older than the TTL are treated as misses. Embeddings are
+// copied in and out of the cache, so callers may modify the embeddings they set or get.
+type LRUEmbeddingCache struct {
+	capacity int
+	ttl      time.Duration
+
+	mu      sync.Mutex
+	entries map[string]*list.Element
+	order   *list.List
+}
+
+type lruEntry struct {
+	key       string
+	embedding Embedding
+	storedAt  time.Time
+}
+
+// [NewLRUEmbeddingCache] creates an [LRUEmbeddingCache].
+//
+// Parameters:
+//   - capacity: The maximum number of embeddings to hold. Must be greater than 0.
+//   - ttl: How long an entry stays valid. 0 means entries never expire.
+//
+// Example:
+//
+//	    pc, err := pinecone.NewClient(pinecone.NewClientParams{
+//		       ApiKey:         "YOUR_API_KEY",
+//		       EmbeddingCache: pinecone.NewLRUEmbeddingCache(10000, time.Hour),
+//	    })
+func NewLRUEmbeddingCache(capacity int, ttl time.Duration) *LRUEmbeddingCache {
+	if capacity <= 0 {
+		capacity = 1
+	}
+	return &LRUEmbeddingCache{
+		capacity: capacity,
+		ttl:      ttl,
+		entries:  make(map[string]*list.Element),
+		order:    list.New(),
+	}
+}
+
+// [LRUEmbeddingCache.Get] implements [EmbeddingCache].
+func (c *LRUEmbeddingCache) Get(key string) (Embedding, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	elem, ok := c.entries[key]
+	if !ok {
+		return Embedding{}, false
+	}
+	entry := elem.Value.(*lruEntry)
+	if c.ttl > 0 && time.Since(entry.storedAt) > c.ttl {
+		c.order.Remove(elem)
+		delete(c.entries, key)
+		return Embedding{}, false
+	}
+	c.order.MoveToFront(elem)
+	return copyEmbedding(entry.embedding), true
+}
+
+// [LRUEmbeddingCache.Set] implements [EmbeddingCache].
+func (c *LRUEmbeddingCache) Set(key string, embedding Embedding) {
+	embedding = copyEmbedding(embedding)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if elem, ok := c.entries[key]; ok {
+		elem.Value = &lruEntry{key: key, embedding: embedding, storedAt: time.Now()}
+		c.order.MoveToFront(elem)
+		return
+	}
+	c.entries[key] = c.order.PushFront(&lruEntry{key: key, embedding: embedding, storedAt: time.Now()})
+	for c.order.Len() > c.capacity {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*lruEntry).key)
+	}
+}
+
+// [LRUEmbeddingCache.Len] returns the number of entries in the cache, including expired entries that have not
+// been evicted yet.
+func (c *LRUEmbeddingCache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.order.Len()
+}
+
+// copyEmbedding returns a deep copy of e, sharing no pointers or slices with it.
+func copyEmbedding(e Embedding) Embedding {
+	var out Embedding
+	if e.DenseEmbedding != nil {
+		out.DenseEmbedding = &DenseEmbedding{VectorType: e.DenseEmbedding.VectorType, Values: slices.Clone(e.DenseEmbedding.Values)}
+	}
+	if e.SparseEmbedding != nil {
+		sparse := *e.SparseEmbedding
+		sparse.SparseValues = slices.Clone(sparse.SparseValues)
+		sparse.SparseIndices = slices.Clone(sparse.SparseIndices)
+		if sparse.SparseTokens != nil {
+			tokens := slices.Clone(*sparse.SparseTokens)
+			sparse.SparseTokens = &tokens
+		}
+		out.SparseEmbedding = &sparse
+	}
+	return out
+}
+
+// [FileEmbeddingCache] is an [EmbeddingCache] that stores each embedding as a JSON file in a directory, so
+// embeddings survive process restarts and can be shared by processes on the same machine. Entries older than
+// the TTL are treated as misses and removed. Errors reading or writing the directory are treated as misses.
+type FileEmbeddingCache struct {
+	dir string
+	ttl time.Duration
+}
+
+type fileCacheEntry struct {
+	Embedding Embedding `json:"embedding"`
+	StoredAt  time.Time `json:"stored_at"`
+}
+
+// [NewFileEmbeddingCache] creates a [FileEmbeddingCache] in dir, creating the directory if needed.
+//
+// Parameters:
+//   - dir: The directory to store embeddings in.
+//   - ttl: How long an entry stays valid. 0 means entries never expire.
+//
+// Returns a pointer to a [FileEmbeddingCache] or an error if the directory cannot be created.
+//
+// Example:
+//
+//	    cache, err := pinecone.NewFileEmbeddingCache(filepath.Join(os.TempDir(), "pinecone-embeddings"), 24*time.Hour)
+//	    if err != nil {
+//		       log.Fatalf("Failed to create embedding cache: %v", err)
+//	    }
+//
+//	    pc, err := pinecone.NewClient(pinecone.NewClientParams{
+//		       ApiKey:         "YOUR_API_KEY",
+//		       EmbeddingCache: cache,
+//	    })
+func NewFileEmbeddingCache(dir string, ttl time.Duration) (*FileEmbeddingCache, error) {
+	if dir == "" {
+		return nil, fmt.Errorf("dir cannot be empty")
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create embedding cache directory: %w", err)
+	}
+	return &FileEmbeddingCache{dir: dir, ttl: ttl}, nil
+}
+
+// [FileEmbeddingCache.Get] implements [EmbeddingCache].
+func (c *FileEmbeddingCache) Get(key string) (Embedding, bool) {
+	path := c.path(key)
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return Embedding{}, false
+	}
+	var entry fileCacheEntry
+	if err := json.Unmarshal(b, &entry); err != nil {
+		_ = os.Remove(path)
+		return Embedding{}, false
+	}
+	if c.ttl > 0 && time.Since(entry.StoredAt) > c.ttl {
+		_ = os.Remove(path)
+		return Embedding{}, false
+	}
+	return entry.Embedding, true
+}
+
+// [FileEmbeddingCache.Set] implements [EmbeddingCache].
+func (c *FileEmbeddingCache) Set(key string, embedding Embedding) {
+	b, err := json.Marshal(fileCacheEntry{Embedding: embedding, StoredAt: time.Now()})
+	if err != nil {
+		return
+	}
+	path := c.path(key)
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return
+	}
+	// Write to a temporary file and rename it so readers never see a partial entry.
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".tmp-*")
+	if err != nil {
+		return
+	}
+	_, writeErr := tmp.Write(b)
+	closeErr := tmp.Close()
+	if writeErr != nil || closeErr != nil || os.Rename(tmp.Name(), path) != nil {
+		_ = os.Remove(tmp.Name())
+	}
+}
+
+// path spreads entries over subdirectories named after the first two characters of the key.
+func (c *FileEmbeddingCache) path(key string) string {
+	prefix := "00"
+	if len(key) >= 2 {
+		prefix = key[:2]
+	}
+	return filepath.Join(c.dir, prefix, key+".json")
+}
+
+// embedCached serves the inputs of in found in the cache, and embeds the rest with a single API call, storing
+// the new embeddings in the cache.
+func (i *InferenceService) embedCached(ctx context.Context, in *EmbedRequest) (*EmbedResponse, error) {
+	embeddings := make([]Embedding, len(in.TextInputs))
+	keys := make([]string, len(in.TextInputs))
+	var missIndices []int
+	var missInputs []string
+	for n, text := range in.TextInputs {
+		keys[n] = embeddingCacheKey(in.Model, in.Parameters, text)
+		if embedding, ok := i.cache.Get(keys[n]); ok {
+			embeddings[n] = embedding
+			continue
+		}
+		missIndices = append(missIndices, n)
+		missInputs = append(missInputs, text)
+	}
+
+	res := &EmbedResponse{Model: in.Model}
+	if len(missInputs) > 0 {
+		apiRes, err := i.embed(ctx, &EmbedRequest{Model: in.Model, TextInputs: missInputs, Parameters: in.Parameters})
+		if err != nil {
+			return nil, err
+		}
+		if len(apiRes.Data) != len(missInputs) {
+			return nil, fmt.Errorf("expected %d embeddings, got %d", len(missInputs), len(apiRes.Data))
+		}
+		for j, n := range missIndices {
+			embeddings[n] = apiRes.Data[j]
+			i.cache.Set(keys[n], apiRes.Data[j])
+		}
+		res.Model = apiRes.Model
+		res.VectorType = apiRes.VectorType
+		res.Usage = apiRes.Usage
+	} else {
+		res.VectorType = embeddingVectorType(embeddings[0])
+		zero := int32(0)
+		res.Usage.TotalTokens = &zero
+	}
+
+	res.Data = embeddings
+	res.Cache = &EmbedCacheInfo{Hits: len(in.TextInputs) - len(missInputs), Misses: len(missInputs)}
+	return res, nil
+}
+
+func embeddingVectorType(e Embedding) string {
+	switch {
+	case e.DenseEmbedding != nil:
+		return e.DenseEmbedding.VectorType
+	case e.SparseEmbedding != nil:
+		return e.SparseEmbedding.VectorType
+	}
+	return ""
+}
@@ -0,0 +1,165 @@
+package pinecone
+
+import (
+	"context"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func denseEmbedding(values ...float32) Embedding {
+	return Embedding{DenseEmbedding: &DenseEmbedding{VectorType: "dense", Values: values}}
+}
+
+// Unit tests:
+func TestEmbeddingCacheKeyUnit(t *testing.T) {
+	base := embeddingCacheKey("model-a", EmbedParameters{"input_type": "query", "truncate": "END"}, "hello")
+
+	assert.Equal(t, base, embeddingCacheKey("model-a", EmbedParameters{"truncate": "END", "input_type": "query"}, "hello"),
+		"parameter order must not change the key")
+	assert.NotEqual(t, base, embeddingCacheKey("model-b", EmbedParameters{"input_type": "query", "truncate": "END"}, "hello"))
+	assert.NotEqual(t, base, embeddingCacheKey("model-a", EmbedParameters{"input_type": "passage", "truncate": "END"}, "hello"))
+	assert.NotEqual(t, base, embeddingCacheKey("model-a", EmbedParameters{"input_type": "query", "truncate": "NONE"}, "hello"))
+	assert.NotEqual(t, base, embeddingCacheKey("model-a", EmbedParameters{"input_type": "query", "truncate": "END"}, "hello!"))
+}
+
+func TestLRUEmbeddingCacheUnit(t *testing.T) {
+	cache := NewLRUEmbeddingCache(2, 0)
+	cache.Set("a", denseEmbedding(1))
+	cache.Set("b", denseEmbedding(2))
+
+	got, ok := cache.Get("a")
+	require.True(t, ok)
+	assert.Equal(t, []float32{1}, got.DenseEmbedding.Values)
+
+	// "b" is now the least recently used entry.
+	cache.Set("c", denseEmbedding(3))
+	_, ok = cache.Get("b")
+	assert.False(t, ok)
+	_, ok = cache.Get("a")
+	assert.True(t, ok)
+	assert.Equal(t, 2, cache.Len())
+
+	expiring := NewLRUEmbeddingCache(10, 10*time.Millisecond)
+	expiring.Set("a", denseEmbedding(1))
+	time.Sleep(20 * time.Millisecond)
+	_, ok = expiring.Get("a")
+	assert.False(t, ok)
+	assert.Equal(t, 0, expiring.Len(), "expired entries are evicted on access")
+}
+
+func TestLRUEmbeddingCacheCopiesUnit(t *testing.T) {
+	cache := NewLRUEmbeddingCache(2, 0)
+	stored := denseEmbedding(1)
+	cache.Set("a", stored)
+	stored.DenseEmbedding.Values[0] = 10
+
+	got, ok := cache.Get("a")
+	require.True(t, ok)
+	got.DenseEmbedding.Values[0] = 20
+
+	tokens := []string{"x"}
+	sparse := Embedding{SparseEmbedding: &SparseEmbedding{SparseValues: []float32{1}, SparseIndices: []int64{3}, SparseTokens: &tokens}}
+	cache.Set("b", sparse)
+	sparse.SparseEmbedding.SparseIndices[0] = 4
+	tokens[0] = "y"
+
+	again, _ := cache.Get("a")
+	assert.Equal(t, []float32{1}, again.DenseEmbedding.Values, "mutating a set or returned embedding should not change the cache")
+	gotSparse, _ := cache.Get("b")
+	assert.Equal(t, []int64{3}, gotSparse.SparseEmbedding.SparseIndices)
+	assert.Equal(t, []string{"x"}, *gotSparse.SparseEmbedding.SparseTokens)
+}
+
+func TestFileEmbeddingCacheUnit(t *testing.T) {
+	dir := t.TempDir()
+	cache, err := NewFileEmbeddingCache(dir, 0)
+	require.NoError(t, err)
+
+	key := embeddingCacheKey("model", nil, "text")
+	_, ok := cache.Get(key)
+	assert.False(t, ok)
+
+	sparse := Embedding{SparseEmbedding: &SparseEmbedding{VectorType: "sparse", SparseValues: []float32{0.5}, SparseIndices: []int64{42}}}
+	cache.Set(key, sparse)
+
+	// A second cache over the same directory sees the entry.
+	reopened, err := NewFileEmbeddingCache(dir, 0)
+	require.NoError(t, err)
+	got, ok := reopened.Get(key)
+	require.True(t, ok)
+	assert.Equal(t, sparse, got)
+
+	expiring, err := NewFileEmbeddingCache(dir, time.Nanosecond)
+	require.NoError(t, err)
+	time.Sleep(time.Millisecond)
+	_, ok = expiring.Get(key)
+	assert.False(t, ok)
+	_, err = os.Stat(expiring.path(key))
+	assert.True(t, os.IsNotExist(err), "expired entries are removed")
+
+	_, err = NewFileEmbeddingCache("", 0)
+	require.Error(t, err)
+	_, err = NewFileEmbeddingCache(filepath.Join(dir, "nested", "dir"), 0)
+	require.NoError(t, err)
+}
+
+func TestEmbedWithCacheUnit(t *testing.T) {
+	s := &embedServer{maxBatchSize: 10}
+	srv := httptest.NewServer(s.handler())
+	defer srv.Close()
+
+	pc, err := NewClient(NewClientParams{ApiKey: "test-key", Host: srv.URL, EmbeddingCache: NewLRUEmbeddingCache(100, 0)})
+	require.NoError(t, err)
+	ctx := context.Background()
+	params := EmbedParameters{"input_type": "passage"}
+
+	res, err := pc.Inference.Embed(ctx, &EmbedRequest{Model: "test-model", TextInputs: []string{"1", "2"}, Parameters: params})
+	require.NoError(t, err)
+	assert.Equal(t, &EmbedCacheInfo{Hits: 0, Misses: 2}, res.Cache)
+	assert.Equal(t, int32(2), *res.Usage.TotalTokens)
+
+	res, err = pc.Inference.Embed(ctx, &EmbedRequest{Model: "test-model", TextInputs: []string{"3", "2", "1"}, Parameters: params})
+	require.NoError(t, err)
+	assert.Equal(t, &EmbedCacheInfo{Hits: 2, Misses: 1}, res.Cache)
+	assert.Equal(t, int32(1), *res.Usage.TotalTokens, "only the miss should be billed")
+	require.Len(t, res.Data, 3)
+	for i, want := range []float32{3, 2, 1} {
+		assert.Equal(t, []float32{want}, res.Data[i].DenseEmbedding.Values)
+	}
+	require.Len(t, s.batches, 2)
+	assert.Equal(t, []string{"3"}, s.batches[1], "cached inputs should not be sent")
+
+	res, err = pc.Inference.Embed(ctx, &EmbedRequest{Model: "test-model", TextInputs: []string{"1"}, Parameters: params})
+	require.NoError(t, err)
+	assert.Equal(t, &EmbedCacheInfo{Hits: 1, Misses: 0}, res.Cache)
+	assert.Equal(t, "dense", res.VectorType)
+	assert.Len(t, s.batches, 2, "a full hit should not call the API")
+
+	// A different input type is a different cache entry.
+	res, err = pc.Inference.Embed(ctx, &EmbedRequest{Model: "test-model", TextInputs: []string{"1"}, Parameters: EmbedParameters{"input_type": "query"}})
+	require.NoError(t, err)
+	assert.Equal(t, &EmbedCacheInfo{Hits: 0, Misses: 1}, res.Cache)
+
+	// EmbedAll sums cache usage across batches.
+	all, err := pc.Inference.EmbedAll(ctx, &EmbedAllRequest{Model: "test-model", TextInputs: []string{"1", "2", "4"}, Parameters: params, BatchSize: 1})
+	require.NoError(t, err)
+	assert.Equal(t, &EmbedCacheInfo{Hits: 2, Misses: 1}, all.Cache)
+}
+
+func TestEmbedWithoutCacheUnit(t *testing.T) {
+	s := &embedServer{maxBatchSize: 10}
+	srv := httptest.NewServer(s.handler())
+	defer srv.Close()
+
+	pc, err := NewClient(NewClientParams{ApiKey: "test-key", Host: srv.URL})
+	require.NoError(t, err)
+	res, err := pc.Inference.Embed(context.Background(), &EmbedRequest{Model: "test-model", TextInputs: []string{"1"}})
+	require.NoError(t, err)
+	assert.Nil(t, res.Cache)
+}
@@ -0,0 +1,358 @@
+package pinecone
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// fakeControlPlane serves the control plane of a project and the data plane of its indexes, which all share one
+// record store. Indexes are ready as soon as they are created; collections and backups become ready on their
+// second describe. Each describe of a restore job advances it to the next entry of restoreProgress.
+type fakeControlPlane struct {
+	srv *httptest.Server
+
+	mu              sync.Mutex
+	indexes         []map[string]any
+	collections     map[string]map[string]any
+	backups         []map[string]any
+	records         map[string]map[string][]float32
+	polls           map[string]int
+	restoreJob      map[string]any
+	restoreProgress []map[string]any
+	restorePolls    int
+	backupsCreated  int
+
+	creates  []map[string]any
+	restores []map[string]any
+	deleted  []string
+}
+
+func newFakeControlPlane(t *testing.T) *fakeControlPlane {
+	t.Helper()
+	f := &fakeControlPlane{collections: map[string]map[string]any{}, records: map[string]map[string][]float32{}, polls: map[string]int{}}
+	mux := http.NewServeMux()
+
+	createIndex := func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]any
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		f.creates = append(f.creates, body)
+		index := map[string]any{"name": body["name"], "metric": valueOrFallback(body["metric"], any("cosine")), "dimension": body["dimension"],
+			"vector_type": "dense", "deletion_protection": body["deletion_protection"], "tags": body["tags"], "spec": body["spec"]}
+		writeFakeJSON(w, http.StatusCreated, f.putIndex(index))
+	}
+	mux.HandleFunc("GET /indexes", func(w http.ResponseWriter, r *http.Request) {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		writeFakeJSON(w, http.StatusOK, map[string]any{"indexes": f.indexes})
+	})
+	mux.HandleFunc("POST /indexes", createIndex)
+	mux.HandleFunc("POST /indexes/create-for-model", createIndex)
+	mux.HandleFunc("GET /indexes/{name}", func(w http.ResponseWriter, r *http.Request) {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		index := f.findIndex(r.PathValue("name"))
+		if index == nil {
+			writeFakeNotFound(w)
+			return
+		}
+		writeFakeJSON(w, http.StatusOK, index)
+	})
+	mux.HandleFunc("PATCH /indexes/{name}", func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]any
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		index := f.findIndex(r.PathValue("name"))
+		if index == nil {
+			writeFakeNotFound(w)
+			return
+		}
+		index["deletion_protection"] = body["deletion_protection"]
+		writeFakeJSON(w, http.StatusOK, index)
+	})
+	mux.HandleFunc("DELETE /indexes/{name}", func(w http.ResponseWriter, r *http.Request) {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		f.indexes = slices.DeleteFunc(f.indexes, func(index map[string]any) bool { return index["name"] == r.PathValue("name") })
+		f.deleted = append(f.deleted, "index "+r.PathValue("name"))
+		w.WriteHeader(http.StatusAccepted)
+	})
+
+	mux.HandleFunc("POST /collections", func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]any
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		collection := map[string]any{"name": body["name"], "status": "Initializing", "dimension": 3, "vector_count": f.recordCount(), "environment": "us-east1-gcp", "size": 100}
+		f.collections[body["name"].(string)] = collection
+		writeFakeJSON(w, http.StatusCreated, collection)
+	})
+	mux.HandleFunc("GET /collections/{name}", func(w http.ResponseWriter, r *http.Request) {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		collection, ok := f.collections[r.PathValue("name")]
+		if !ok {
+			writeFakeNotFound(w)
+			return
+		}
+		f.poll(r.PathValue("name"), collection)
+		writeFakeJSON(w, http.StatusOK, collection)
+	})
+	mux.HandleFunc("DELETE /collections/{name}", func(w http.ResponseWriter, r *http.Request) {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		delete(f.collections, r.PathValue("name"))
+		f.deleted = append(f.deleted, "collection "+r.PathValue("name"))
+		w.WriteHeader(http.StatusAccepted)
+	})
+
+	mux.HandleFunc("POST /indexes/{name}/backups", func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]any
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		f.backupsCreated++
+		backup := map[string]any{"backup_id": fmt.Sprintf("backup-%d", f.backupsCreated), "name": body["name"], "description": body["description"],
+			"source_index_name": r.PathValue("name"), "source_index_id": "id", "status": "Initializing", "cloud": "aws", "region": "us-east-1",
+			"created_at": time.Now().UTC().Format(time.RFC3339)}
+		f.backups = append(f.backups, backup)
+		writeFakeJSON(w, http.StatusOK, backup)
+	})
+	mux.HandleFunc("GET /indexes/{name}/backups", func(w http.ResponseWriter, r *http.Request) {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		var data []map[string]any
+		for _, backup := range f.backups {
+			if backup["source_index_name"] == r.PathValue("name") {
+				data = append(data, backup)
+			}
+		}
+		writeFakeJSON(w, http.StatusOK, map[string]any{"data": data})
+	})
+	mux.HandleFunc("GET /backups/{id}", func(w http.ResponseWriter, r *http.Request) {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		backup := f.findBackup(r.PathValue("id"))
+		if backup == nil {
+			writeFakeNotFound(w)
+			return
+		}
+		f.poll(r.PathValue("id"), backup)
+		writeFakeJSON(w, http.StatusOK, backup)
+	})
+	mux.HandleFunc("DELETE /backups/{id}", func(w http.ResponseWriter, r *http.Request) {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		f.backups = slices.DeleteFunc(f.backups, func(backup map[string]any) bool { return backup["backup_id"] == r.PathValue("id") })
+		f.deleted = append(f.deleted, "backup "+r.PathValue("id"))
+		w.WriteHeader(http.StatusAccepted)
+	})
+	mux.HandleFunc("POST /backups/{id}/create-index", func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]any
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		backup := f.findBackup(r.PathValue("id"))
+		if backup == nil {
+			writeFakeNotFound(w)
+			return
+		}
+		f.restores = append(f.restores, body)
+		f.putIndex(map[string]any{"name": body["name"], "metric": "cosine", "dimension": 2, "vector_type": "dense", "deletion_protection": body["deletion_protection"],
+			"tags": body["tags"], "spec": map[string]any{"serverless": map[string]any{"cloud": backup["cloud"], "region": backup["region"]}}})
+		f.restoreJob = map[string]any{"restore_job_id": "restore-1", "backup_id": backup["backup_id"], "target_index_name": body["name"], "target_index_id": "restored",
+			"created_at": time.Now().UTC().Format(time.RFC3339)}
+		f.restorePolls = 0
+		writeFakeJSON(w, http.StatusAccepted, map[string]any{"index_id": "restored", "restore_job_id": "restore-1"})
+	})
+	mux.HandleFunc("GET /restore-jobs/{id}", func(w http.ResponseWriter, r *http.Request) {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		if f.restoreJob == nil || f.restoreJob["restore_job_id"] != r.PathValue("id") {
+			writeFakeNotFound(w)
+			return
+		}
+		job := map[string]any{}
+		for k, v := range f.restoreJob {
+			job[k] = v
+		}
+		progress := map[string]any{"status": "Completed", "percent_complete": 100}
+		if len(f.restoreProgress) > 0 {
+			progress = f.restoreProgress[min(f.restorePolls, len(f.restoreProgress)-1)]
+		}
+		for k, v := range progress {
+			job[k] = v
+		}
+		f.restorePolls++
+		writeFakeJSON(w, http.StatusOK, job)
+	})
+
+	mux.HandleFunc("POST /describe_index_stats", func(w http.ResponseWriter, r *http.Request) {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		namespaces := map[string]any{}
+		for ns, records := range f.records {
+			namespaces[ns] = map[string]any{"vectorCount": len(records)}
+		}
+		writeFakeJSON(w, http.StatusOK, map[string]any{"totalVectorCount": f.recordCount(), "namespaces": namespaces})
+	})
+	mux.HandleFunc("GET /vectors/list", func(w http.ResponseWriter, r *http.Request) {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		ns := r.URL.Query().Get("namespace")
+		limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+		start, _ := strconv.Atoi(r.URL.Query().Get("paginationToken"))
+		ids := slices.Sorted(func(yield func(string) bool) {
+			for id := range f.records[ns] {
+				if !yield(id) {
+					return
+				}
+			}
+		})
+		end := min(start+limit, len(ids))
+		var vectors []map[string]any
+		for _, id := range ids[start:end] {
+			vectors = append(vectors, map[string]any{"id": id})
+		}
+		res := map[string]any{"namespace": ns, "vectors": vectors}
+		if end < len(ids) {
+			res["pagination"] = map[string]any{"next": strconv.Itoa(end)}
+		}
+		writeFakeJSON(w, http.StatusOK, res)
+	})
+	mux.HandleFunc("GET /vectors/fetch", func(w http.ResponseWriter, r *http.Request) {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		ns := r.URL.Query().Get("namespace")
+		vectors := map[string]any{}
+		for _, id := range r.URL.Query()["ids"] {
+			vectors[id] = map[string]any{"id": id, "values": f.records[ns][id]}
+		}
+		writeFakeJSON(w, http.StatusOK, map[string]any{"namespace": ns, "vectors": vectors})
+	})
+	mux.HandleFunc("POST /vectors/upsert", func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Namespace string `json:"namespace"`
+			Vectors   []struct {
+				Id     string    `json:"id"`
+				Values []float32 `json:"values"`
+			} `json:"vectors"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		if f.records[body.Namespace] == nil {
+			f.records[body.Namespace] = map[string][]float32{}
+		}
+		for _, vector := range body.Vectors {
+			f.records[body.Namespace][vector.Id] = vector.Values
+		}
+		writeFakeJSON(w, http.StatusOK, map[string]any{"upsertedCount": len(body.Vectors)})
+	})
+
+	f.srv = httptest.NewTLSServer(mux)
+	t.Cleanup(f.srv.Close)
+	return f
+}
+
+func (f *fakeControlPlane) client(t *testing.T) *Client {
+	t.Helper()
+	client, err := NewClient(NewClientParams{ApiKey: "test-key", Host: f.srv.URL, RestClient: f.srv.Client()})
+	require.NoError(t, err)
+	return client
+}
+
+// addIndex adds a ready index served by this server.
+func (f *fakeControlPlane) addIndex(index map[string]any) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.putIndex(index)
+}
+
+// index returns the index named name, or nil.
+func (f *fakeControlPlane) index(name string) map[string]any {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.findIndex(name)
+}
+
+func (f *fakeControlPlane) addBackups(backups ...map[string]any) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.backups = append(f.backups, backups...)
+}
+
+// fillNamespace replaces the records of namespace with count records.
+func (f *fakeControlPlane) fillNamespace(namespace string, count int) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.records[namespace] = map[string][]float32{}
+	for n := range count {
+		f.records[namespace][strconv.Itoa(n)] = []float32{float32(n)}
+	}
+}
+
+// putIndex makes index ready on this server and adds it, replacing any index of the same name. f.mu must be held.
+func (f *fakeControlPlane) putIndex(index map[string]any) map[string]any {
+	index["host"] = f.srv.URL
+	index["status"] = map[string]any{"ready": true, "state": "Ready"}
+	if i := slices.IndexFunc(f.indexes, func(existing map[string]any) bool { return existing["name"] == index["name"] }); i >= 0 {
+		f.indexes[i] = index
+	} else {
+		f.indexes = append(f.indexes, index)
+	}
+	return index
+}
+
+// findIndex returns the index named name, or nil. f.mu must be held.
+func (f *fakeControlPlane) findIndex(name string) map[string]any {
+	if i := slices.IndexFunc(f.indexes, func(index map[string]any) bool { return index["name"] == name }); i >= 0 {
+		return f.indexes[i]
+	}
+	return nil
+}
+
+// findBackup returns the backup with ID id, or nil. f.mu must be held.
+func (f *fakeControlPlane) findBackup(id string) map[string]any {
+	if i := slices.IndexFunc(f.backups, func(backup map[string]any) bool { return backup["backup_id"] == id }); i >= 0 {
+		return f.backups[i]
+	}
+	return nil
+}
+
+// poll counts a describe of the collection or backup key, making it ready on the second. f.mu must be held.
+func (f *fakeControlPlane) poll(key string, resource map[string]any) {
+	if f.polls[key]++; f.polls[key] > 1 && resource["status"] == "Initializing" {
+		resource["status"] = "Ready"
+	}
+}
+
+// recordCount returns the number of records in all namespaces. f.mu must be held.
+func (f *fakeControlPlane) recordCount() int {
+	count := 0
+	for _, records := range f.records {
+		count += len(records)
+	}
+	return count
+}
+
+func writeFakeJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+func writeFakeNotFound(w http.ResponseWriter) {
+	writeFakeJSON(w, http.StatusNotFound, map[string]any{"error": map[string]any{"code": "NOT_FOUND", "message": "not found"}, "status": 404})
+}
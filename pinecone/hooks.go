@@ -0,0 +1,105 @@
+package pinecone
+
+import (
+	"context"
+	"time"
+)
+
+// Protocols reported in [RequestInfo.Protocol].
+const (
+	ProtocolHTTP = "http"
+	ProtocolGRPC = "grpc"
+)
+
+// [Hooks] lets you observe the lifecycle of every request made by a [Client] and the [IndexConnection]
+// instances it creates, e.g. to feed a metrics or alerting system. Hooks fire from the REST transport
+// (control plane, inference, and REST data plane calls) and from a gRPC unary interceptor on the data plane.
+// Every hook is optional. Hooks are called synchronously on the request's goroutine, so they should
+// return quickly and must be safe for concurrent use.
+//
+// Fields:
+//   - OnRequest: Called before each attempt is sent.
+//   - OnResponse: Called after each attempt completes, with its status code, error, and duration.
+//   - OnRetry: Called when a failed attempt will be retried, with the number of the attempt that failed
+//     (starting at 1), the delay before the next attempt, and the cause of the failure.
+//   - OnGiveUp: Called when a request ends in a retryable failure (rate limit, transient server error, or
+//     network error) that will not be retried, because retries are exhausted, the request is not safe to
+//     replay, or the context was cancelled. The cause is the last failure.
+//
+// Example:
+//
+//	    pc, err := pinecone.NewClient(pinecone.NewClientParams{
+//		       ApiKey:      "YOUR_API_KEY",
+//		       RetryPolicy: pinecone.DefaultRetryPolicy(),
+//		       Hooks: &pinecone.Hooks{
+//			       OnRetry: func(ctx context.Context, info pinecone.RequestInfo, attempt int, delay time.Duration, cause error) {
+//				       log.Printf("retrying %s %s%s (attempt %d) in %s: %v", info.Protocol, info.Method, info.Path, attempt, delay, cause)
+//			       },
+//			       OnGiveUp: func(ctx context.Context, info pinecone.RequestInfo, cause error) {
+//				       log.Printf("request %s failed after %d attempts: %v", info.RequestId, info.Attempt, cause)
+//			       },
+//		       },
+//	    })
+type Hooks struct {
+	OnRequest  func(ctx context.Context, info RequestInfo)
+	OnResponse func(ctx context.Context, info ResponseInfo)
+	OnRetry    func(ctx context.Context, info RequestInfo, attempt int, delay time.Duration, cause error)
+	OnGiveUp   func(ctx context.Context, info RequestInfo, cause error)
+}
+
+// [RequestInfo] describes a single attempt of a request, as reported to [Hooks].
+//
+// Fields:
+//   - Protocol: [ProtocolHTTP] or [ProtocolGRPC].
+//   - Method: The HTTP method, or the full gRPC method name (e.g. "/VectorService/Upsert").
+//   - Path: The URL path of an HTTP request. Empty for gRPC.
+//   - RequestId: The value of the [RequestIdHeader] sent with the request, shared by all attempts.
+//   - Attempt: The attempt number, starting at 1.
+type RequestInfo struct {
+	Protocol  string
+	Method    string
+	Path      string
+	RequestId string
+	Attempt   int
+}
+
+// [ResponseInfo] describes the outcome of a single attempt, as reported to [Hooks.OnResponse].
+//
+// Fields:
+//   - RequestInfo: The attempt this outcome belongs to.
+//   - StatusCode: The HTTP status code, or the gRPC status code (0 is OK). 0 for an HTTP attempt that
+//     failed without a response.
+//   - Err: The error returned by the transport or the RPC, if any.
+//   - Duration: How long the attempt took.
+type ResponseInfo struct {
+	RequestInfo
+	StatusCode int
+	Err        error
+	Duration   time.Duration
+}
+
+func (h *Hooks) onRequest(ctx context.Context, info RequestInfo) {
+	if h != nil && h.OnRequest != nil {
+		h.OnRequest(ctx, info)
+	}
+}
+
+func (h *Hooks) onResponse(ctx context.Context, info ResponseInfo) {
+	if h != nil && h.OnResponse != nil {
+		h.OnResponse(ctx, info)
+	}
+}
+
+func (h *Hooks) onRetry(ctx context.Context, info RequestInfo, delay time.Duration, cause error) {
+	if h != nil && h.OnRetry != nil {
+		h.OnRetry(ctx, info, info.Attempt, delay, cause)
+	}
+}
+
+// onGiveUp reports a request that stopped with a retryable failure; a nil cause means the
+// request succeeded or failed in a way that is never retried, so nothing is reported.
+func (h *Hooks) onGiveUp(ctx context.Context, info RequestInfo, cause error) {
+	if cause != nil && h != nil && h.OnGiveUp != nil {
+		h.OnGiveUp(ctx, info, cause)
+	}
+}
@@ -0,0 +1,174 @@
+package pinecone
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// hookRecorder captures every hook invocation in order.
+type hookRecorder struct {
+	requests  []RequestInfo
+	responses []ResponseInfo
+	retries   []int
+	causes    []error
+	giveUps   []RequestInfo
+}
+
+func (r *hookRecorder) hooks() *Hooks {
+	return &Hooks{
+		OnRequest:  func(_ context.Context, info RequestInfo) { r.requests = append(r.requests, info) },
+		OnResponse: func(_ context.Context, info ResponseInfo) { r.responses = append(r.responses, info) },
+		OnRetry: func(_ context.Context, _ RequestInfo, attempt int, _ time.Duration, cause error) {
+			r.retries = append(r.retries, attempt)
+			r.causes = append(r.causes, cause)
+		},
+		OnGiveUp: func(_ context.Context, info RequestInfo, cause error) {
+			r.giveUps = append(r.giveUps, info)
+			r.causes = append(r.causes, cause)
+		},
+	}
+}
+
+// Unit tests:
+func TestHooksRestRetryUnit(t *testing.T) {
+	var calls int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"indexes":[]}`))
+	}))
+	defer srv.Close()
+
+	rec := &hookRecorder{}
+	pc, err := NewClient(NewClientParams{ApiKey: "test-key", Host: srv.URL, RetryPolicy: fastPolicy(3), Hooks: rec.hooks()})
+	require.NoError(t, err)
+	_, err = pc.ListIndexes(context.Background())
+	require.NoError(t, err)
+
+	require.Len(t, rec.requests, 2)
+	assert.Equal(t, ProtocolHTTP, rec.requests[0].Protocol)
+	assert.Equal(t, http.MethodGet, rec.requests[0].Method)
+	assert.Equal(t, "/indexes", rec.requests[0].Path)
+	assert.NotEmpty(t, rec.requests[0].RequestId)
+	assert.Equal(t, rec.requests[0].RequestId, rec.requests[1].RequestId)
+	assert.Equal(t, []int{1, 2}, []int{rec.requests[0].Attempt, rec.requests[1].Attempt})
+
+	require.Len(t, rec.responses, 2)
+	assert.Equal(t, http.StatusServiceUnavailable, rec.responses[0].StatusCode)
+	assert.Equal(t, http.StatusOK, rec.responses[1].StatusCode)
+
+	assert.Equal(t, []int{1}, rec.retries)
+	var perr *PineconeError
+	require.ErrorAs(t, rec.causes[0], &perr)
+	assert.Equal(t, http.StatusServiceUnavailable, perr.Code)
+	assert.Empty(t, rec.giveUps)
+}
+
+func TestHooksRestGiveUpUnit(t *testing.T) {
+	srv, calls, _ := serverReturning(http.StatusTooManyRequests, 100)
+	defer srv.Close()
+
+	rec := &hookRecorder{}
+	client := newRetryHTTPClient(fastPolicy(1), rec.hooks(), nil, nil)
+	resp, err := client.Get(srv.URL)
+	require.NoError(t, err)
+	resp.Body.Close()
+
+	assert.Equal(t, int32(2), atomic.LoadInt32(calls))
+	assert.Equal(t, []int{1}, rec.retries)
+	require.Len(t, rec.giveUps, 1)
+	assert.Equal(t, 2, rec.giveUps[0].Attempt)
+}
+
+func TestHooksRestNoGiveUpOn4xxUnit(t *testing.T) {
+	srv, _, _ := serverReturning(http.StatusNotFound, 100)
+	defer srv.Close()
+
+	rec := &hookRecorder{}
+	client := newRetryHTTPClient(fastPolicy(3), rec.hooks(), nil, nil)
+	resp, err := client.Get(srv.URL)
+	require.NoError(t, err)
+	resp.Body.Close()
+
+	assert.Len(t, rec.responses, 1)
+	assert.Empty(t, rec.retries)
+	assert.Empty(t, rec.giveUps, "a non-retryable 4xx is not a give-up")
+}
+
+func TestHooksWithoutRetryPolicyUnit(t *testing.T) {
+	rec := &hookRecorder{}
+	pc, err := NewClient(NewClientParams{ApiKey: "test-key", Hooks: rec.hooks()})
+	require.NoError(t, err)
+	rt, ok := pc.baseParams.RestClient.Transport.(*retryTransport)
+	require.True(t, ok, "REST client transport should be wrapped to fire hooks")
+	assert.Equal(t, 0, rt.policy.MaxRetries, "hooks alone must not enable retries")
+}
+
+func TestHooksInterceptorUnit(t *testing.T) {
+	rec := &hookRecorder{}
+	interceptor := retryInterceptor(fastPolicy(3), rec.hooks())
+
+	calls := 0
+	invoker := func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		calls++
+		if calls == 1 {
+			return status.Error(codes.Unavailable, "try again")
+		}
+		return nil
+	}
+	ctx := metadata.AppendToOutgoingContext(context.Background(), RequestIdHeader, "req-1")
+	require.NoError(t, interceptor(ctx, "/VectorService/Upsert", nil, nil, nil, invoker))
+
+	assert.Equal(t, 2, calls)
+	require.Len(t, rec.requests, 2)
+	assert.Equal(t, ProtocolGRPC, rec.requests[0].Protocol)
+	assert.Equal(t, "/VectorService/Upsert", rec.requests[0].Method)
+	assert.Equal(t, "req-1", rec.requests[1].RequestId)
+	require.Len(t, rec.responses, 2)
+	assert.Equal(t, int(codes.Unavailable), rec.responses[0].StatusCode)
+	assert.Equal(t, int(codes.OK), rec.responses[1].StatusCode)
+	assert.Equal(t, []int{1}, rec.retries)
+	assert.Equal(t, codes.Unavailable, status.Code(rec.causes[0]))
+	assert.Empty(t, rec.giveUps)
+}
+
+func TestHooksInterceptorGiveUpUnit(t *testing.T) {
+	rec := &hookRecorder{}
+	interceptor := retryInterceptor(fastPolicy(2), rec.hooks())
+
+	invoker := func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		return status.Error(codes.ResourceExhausted, "slow down")
+	}
+	err := interceptor(context.Background(), "/VectorService/Query", nil, nil, nil, invoker)
+	require.Error(t, err)
+
+	assert.Len(t, rec.requests, 3)
+	assert.Equal(t, []int{1, 2}, rec.retries)
+	require.Len(t, rec.giveUps, 1)
+	assert.Equal(t, 3, rec.giveUps[0].Attempt)
+
+	// Non-retryable codes are returned immediately and are not reported as a give-up.
+	rec = &hookRecorder{}
+	interceptor = retryInterceptor(fastPolicy(2), rec.hooks())
+	invoker = func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		return status.Error(codes.InvalidArgument, "bad request")
+	}
+	require.Error(t, interceptor(context.Background(), "/VectorService/Query", nil, nil, nil, invoker))
+	assert.Len(t, rec.requests, 1)
+	assert.Empty(t, rec.retries)
+	assert.Empty(t, rec.giveUps)
+}
@@ -32,12 +32,18 @@ import (
 //   - additionalMetadata: Additional metadata to be sent with each RPC request.
 //   - dataClient: The gRPC client for the index.
 //   - grpcConn: The gRPC connection.
+//   - host: The host of the index.
+//   - tokenKey: The per-connection key that signs delete-all confirmation tokens.
 type IndexConnection struct {
 	namespace          string
+	host               string
+	tokenKey           []byte
 	additionalMetadata map[string]string
 	restClient         *db_data_rest.Client
 	grpcClient         *db_data_grpc.VectorServiceClient
 	grpcConn           *grpc.ClientConn
+	usage              *UsageAccumulator
+	inference          *InferenceService
 }
 
 type newIndexParameters struct {
@@ -46,15 +52,48 @@ type newIndexParameters struct {
 	sourceTag          string
 	additionalMetadata map[string]string
 	dbDataClient       *db_data_rest.Client
+	retryPolicy        *RetryPolicy
+	hooks              *Hooks
+	transport          IndexTransport
+	inference          *InferenceService
+	tokens             *reuseTokenSource
+	projects           *projectIdSource
 }
 
 func newIndexConnection(in newIndexParameters, dialOpts ...grpc.DialOption) (*IndexConnection, error) {
+	// Over REST, retries, hooks, and request IDs come from the REST client's transport and request editors.
+	if in.transport == IndexTransportREST {
+		if len(dialOpts) > 0 {
+			return nil, fmt.Errorf("gRPC dial options cannot be used with Transport %q", IndexTransportREST)
+		}
+		dataClient := newRestVectorService(in.dbDataClient)
+		return &IndexConnection{
+			namespace:          in.namespace,
+			host:               in.host,
+			tokenKey:           newTokenKey(),
+			restClient:         in.dbDataClient,
+			grpcClient:         &dataClient,
+			additionalMetadata: in.additionalMetadata,
+			inference:          in.inference,
+		}, nil
+	}
+
 	target, isSecure := normalizeHost(in.host)
 
 	// configure default gRPC DialOptions
 	grpcOptions := []grpc.DialOption{
 		grpc.WithAuthority(target),
 		grpc.WithUserAgent(useragent.BuildUserAgentGRPC(in.sourceTag)),
+		grpc.WithChainUnaryInterceptor(requestIdInterceptor, retryInterceptor(in.retryPolicy, in.hooks)),
+	}
+	if in.tokens != nil {
+		grpcOptions = append(grpcOptions,
+			grpc.WithPerRPCCredentials(&tokenCredentials{tokens: in.tokens, requireTLS: isSecure}),
+			grpc.WithChainUnaryInterceptor(tokenInterceptor(in.tokens)),
+		)
+	}
+	if in.projects != nil {
+		grpcOptions = append(grpcOptions, grpc.WithChainUnaryInterceptor(projectIdInterceptor(in.projects)))
 	}
 
 	if isSecure {
@@ -80,15 +119,19 @@ func newIndexConnection(in newIndexParameters, dialOpts ...grpc.DialOption) (*In
 
 	idx := IndexConnection{
 		namespace:          in.namespace,
+		host:               in.host,
+		tokenKey:           newTokenKey(),
 		restClient:         in.dbDataClient,
 		grpcClient:         &dataClient,
 		grpcConn:           conn,
 		additionalMetadata: in.additionalMetadata,
+		inference:          in.inference,
 	}
 	return &idx, nil
 }
 
-// [IndexConnection.Close] closes the grpc.ClientConn to a Pinecone [Index].
+// [IndexConnection.Close] closes the grpc.ClientConn to a Pinecone [Index]. It is a no-op for a connection
+// using [IndexTransportREST].
 //
 // Returns an error if the connection cannot be closed, otherwise returns nil.
 //
@@ -120,6 +163,9 @@ func newIndexConnection(in newIndexParameters, dialOpts ...grpc.DialOption) (*In
 //			log.Fatalf("Failed to close index connection. Error: %v", err)
 //	    }
 func (idx *IndexConnection) Close() error {
+	if idx.grpcConn == nil {
+		return nil
+	}
 	err := idx.grpcConn.Close()
 	return err
 }
@@ -182,10 +228,40 @@ func (idx *IndexConnection) Namespace() string {
 func (idx *IndexConnection) WithNamespace(namespace string) *IndexConnection {
 	return &IndexConnection{
 		namespace:          namespace,
+		host:               idx.host,
+		tokenKey:           idx.tokenKey,
+		additionalMetadata: idx.additionalMetadata,
+		restClient:         idx.restClient,
+		grpcClient:         idx.grpcClient,
+		grpcConn:           idx.grpcConn,
+		usage:              idx.usage,
+		inference:          idx.inference,
+	}
+}
+
+// [IndexConnection.WithUsageAccumulator] creates a new [IndexConnection] that records the usage of its calls
+// into acc, while sharing the underlying connection. Pass nil to stop recording. See [UsageAccumulator].
+//
+// Parameters:
+//   - acc: The [UsageAccumulator] to record usage into.
+//
+// Example:
+//
+//	usage := pinecone.NewUsageAccumulator()
+//	tracked := idxConnection.WithUsageAccumulator(usage)
+//	_, err := tracked.FetchVectors(ctx, []string{"v1", "v2"})
+//	fmt.Printf("read units so far: %d\n", usage.Total().ReadUnits)
+func (idx *IndexConnection) WithUsageAccumulator(acc *UsageAccumulator) *IndexConnection {
+	return &IndexConnection{
+		namespace:          idx.namespace,
+		host:               idx.host,
+		tokenKey:           idx.tokenKey,
 		additionalMetadata: idx.additionalMetadata,
 		restClient:         idx.restClient,
 		grpcClient:         idx.grpcClient,
 		grpcConn:           idx.grpcConn,
+		usage:              acc,
+		inference:          idx.inference,
 	}
 }
 
@@ -195,6 +271,7 @@ func (idx *IndexConnection) WithNamespace(namespace string) *IndexConnection {
 //   - ctx: A context.Context object controls the request's lifetime,
 //     allowing for the request to be canceled or to timeout according to the context's deadline.
 //   - in: The vectors to upsert.
+//   - opts: (Optional) [CallOption] values that apply to this call only, e.g. [WithTimeout] or [WithHeader].
 //
 // Returns the number of vectors upserted or an error if the request fails.
 //
@@ -249,7 +326,10 @@ func (idx *IndexConnection) WithNamespace(namespace string) *IndexConnection {
 //		} else {
 //				log.Printf("Successfully upserted %d vector(s)!\n", count)
 //		}
-func (idx *IndexConnection) UpsertVectors(ctx context.Context, in []*Vector) (uint32, error) {
+func (idx *IndexConnection) UpsertVectors(ctx context.Context, in []*Vector, opts ...CallOption) (uint32, error) {
+	idx, ctx, cancel := idx.withCallOptions(ctx, opts)
+	defer cancel()
+
 	vectors := make([]*db_data_grpc.Vector, len(in))
 	for i, v := range in {
 		vectors[i] = vecToGrpc(v)
@@ -291,6 +371,7 @@ type UpdateVectorRequest struct {
 //   - ctx: A context.Context object controls the request's lifetime,
 //     allowing for the request to be canceled or to timeout according to the context's deadline.
 //   - in: An [UpdateVectorRequest] object with the parameters for the request.
+//   - opts: (Optional) [CallOption] values that apply to this call only, e.g. [WithTimeout] or [WithHeader].
 //
 // Example:
 //
@@ -325,7 +406,10 @@ type UpdateVectorRequest struct {
 //	    if err != nil {
 //			log.Fatalf("Failed to update vector with ID %s. Error: %s", id, err)
 //	    }
-func (idx *IndexConnection) UpdateVector(ctx context.Context, in *UpdateVectorRequest) error {
+func (idx *IndexConnection) UpdateVector(ctx context.Context, in *UpdateVectorRequest, opts ...CallOption) error {
+	idx, ctx, cancel := idx.withCallOptions(ctx, opts)
+	defer cancel()
+
 	if in == nil {
 		return fmt.Errorf("in (*UpdateVectorRequest) cannot be nil")
 	}
@@ -386,6 +470,7 @@ type UpdateVectorsByMetadataResponse struct {
 //   - ctx: A context.Context object controls the request's lifetime,
 //     allowing for the request to be canceled or to timeout according to the context's deadline.
 //   - in: An [UpdateVectorsByMetadataRequest] object with the parameters for the request. The Filter and Metadata fields are required.
+//   - opts: (Optional) [CallOption] values that apply to this call only, e.g. [WithTimeout] or [WithHeader].
 //
 // Example:
 //
@@ -442,7 +527,10 @@ type UpdateVectorsByMetadataResponse struct {
 //	    }
 //
 //	    fmt.Printf("Updated %d vector(s)\n", res.MatchedRecords)
-func (idx *IndexConnection) UpdateVectorsByMetadata(ctx context.Context, in *UpdateVectorsByMetadataRequest) (*UpdateVectorsByMetadataResponse, error) {
+func (idx *IndexConnection) UpdateVectorsByMetadata(ctx context.Context, in *UpdateVectorsByMetadataRequest, opts ...CallOption) (*UpdateVectorsByMetadataResponse, error) {
+	idx, ctx, cancel := idx.withCallOptions(ctx, opts)
+	defer cancel()
+
 	if in == nil {
 		return nil, fmt.Errorf("in (*UpdateVectorsByMetadataRequest) cannot be nil")
 	}
@@ -496,6 +584,7 @@ type FetchVectorsResponse struct {
 //   - ctx: A context.Context object controls the request's lifetime,
 //     allowing for the request to be canceled or to timeout according to the context's deadline.
 //   - ids: The unique IDs of the vectors to fetch.
+//   - opts: (Optional) [CallOption] values that apply to this call only, e.g. [WithTimeout] or [WithHeader].
 //
 // Returns a pointer to any fetched vectors or an error if the request fails.
 //
@@ -532,7 +621,10 @@ type FetchVectorsResponse struct {
 //	    } else {
 //			fmt.Println("No vectors found")
 //	    }
-func (idx *IndexConnection) FetchVectors(ctx context.Context, ids []string) (*FetchVectorsResponse, error) {
+func (idx *IndexConnection) FetchVectors(ctx context.Context, ids []string, opts ...CallOption) (*FetchVectorsResponse, error) {
+	idx, ctx, cancel := idx.withCallOptions(ctx, opts)
+	defer cancel()
+
 	req := &db_data_grpc.FetchRequest{
 		Ids:       ids,
 		Namespace: idx.namespace,
@@ -547,6 +639,7 @@ func (idx *IndexConnection) FetchVectors(ctx context.Context, ids []string) (*Fe
 	for id, vector := range res.Vectors {
 		vectors[id] = toVector(vector)
 	}
+	recordUsage(ctx, idx.usage, "Fetch", idx.namespace, readUnitsOf(toUsage(res.Usage)))
 
 	return &FetchVectorsResponse{
 		Vectors:   vectors,
@@ -592,6 +685,7 @@ type FetchVectorsByMetadataResponse struct {
 //   - ctx: A context.Context object controls the request's lifetime,
 //     allowing for the request to be canceled or to timeout according to the context's deadline.
 //   - in: A [FetchVectorsByMetadataRequest] object with the parameters for the request. The Filter field is required.
+//   - opts: (Optional) [CallOption] values that apply to this call only, e.g. [WithTimeout] or [WithHeader].
 //
 // Example:
 //
@@ -642,7 +736,10 @@ type FetchVectorsByMetadataResponse struct {
 //	    } else {
 //			fmt.Println("No vectors found")
 //	    }
-func (idx *IndexConnection) FetchVectorsByMetadata(ctx context.Context, in *FetchVectorsByMetadataRequest) (*FetchVectorsByMetadataResponse, error) {
+func (idx *IndexConnection) FetchVectorsByMetadata(ctx context.Context, in *FetchVectorsByMetadataRequest, opts ...CallOption) (*FetchVectorsByMetadataResponse, error) {
+	idx, ctx, cancel := idx.withCallOptions(ctx, opts)
+	defer cancel()
+
 	if in == nil {
 		return nil, fmt.Errorf("in (*FetchVectorsByMetadataRequest) cannot be nil")
 	}
@@ -680,6 +777,7 @@ func (idx *IndexConnection) FetchVectorsByMetadata(ctx context.Context, in *Fetc
 			Next: res.Pagination.Next,
 		}
 	}
+	recordUsage(ctx, idx.usage, "FetchByMetadata", namespace, readUnitsOf(toUsage(res.Usage)))
 
 	return &FetchVectorsByMetadataResponse{
 		Vectors:    vectors,
@@ -727,6 +825,7 @@ type ListVectorsResponse struct {
 //   - ctx: A context.Context object controls the request's lifetime,
 //     allowing for the request to be canceled or to timeout according to the context's deadline.
 //   - in: A [ListVectorsRequest] object with the parameters for the request.
+//   - opts: (Optional) [CallOption] values that apply to this call only, e.g. [WithTimeout] or [WithHeader].
 //
 // Example:
 //
@@ -770,7 +869,10 @@ type ListVectorsResponse struct {
 //	    } else {
 //			fmt.Printf("Found %d vector(s)\n", len(res.VectorIds))
 //	    }
-func (idx *IndexConnection) ListVectors(ctx context.Context, in *ListVectorsRequest) (*ListVectorsResponse, error) {
+func (idx *IndexConnection) ListVectors(ctx context.Context, in *ListVectorsRequest, opts ...CallOption) (*ListVectorsResponse, error) {
+	idx, ctx, cancel := idx.withCallOptions(ctx, opts)
+	defer cancel()
+
 	if in == nil {
 		return nil, fmt.Errorf("in (*ListVectorsRequest) cannot be nil")
 	}
@@ -789,6 +891,7 @@ func (idx *IndexConnection) ListVectors(ctx context.Context, in *ListVectorsRequ
 	for i := 0; i < len(res.Vectors); i++ {
 		vectorIds[i] = &res.Vectors[i].Id
 	}
+	recordUsage(ctx, idx.usage, "List", idx.namespace, readUnitsOf(toUsage(res.Usage)))
 
 	return &ListVectorsResponse{
 		VectorIds:           vectorIds,
@@ -847,6 +950,7 @@ type QueryVectorsResponse struct {
 //   - ctx: A context.Context object controls the request's lifetime,
 //     allowing for the request to be canceled or to timeout according to the context's deadline.
 //   - in: A [QueryByVectorValuesRequest] object with the parameters for the request.
+//   - opts: (Optional) [CallOption] values that apply to this call only, e.g. [WithTimeout] or [WithHeader].
 //
 // Example:
 //
@@ -903,7 +1007,10 @@ type QueryVectorsResponse struct {
 //				fmt.Printf("Match vector `%s`, with score %f\n", match.Vector.Id, match.Score)
 //			}
 //	    }
-func (idx *IndexConnection) QueryByVectorValues(ctx context.Context, in *QueryByVectorValuesRequest) (*QueryVectorsResponse, error) {
+func (idx *IndexConnection) QueryByVectorValues(ctx context.Context, in *QueryByVectorValuesRequest, opts ...CallOption) (*QueryVectorsResponse, error) {
+	idx, ctx, cancel := idx.withCallOptions(ctx, opts)
+	defer cancel()
+
 	if in == nil {
 		return nil, fmt.Errorf("in (*QueryByVectorValuesRequest) cannot be nil")
 	}
@@ -960,6 +1067,7 @@ type QueryByVectorIdRequest struct {
 //   - ctx: A context.Context object controls the request's lifetime,
 //     allowing for the request to be canceled or to timeout according to the context's deadline.
 //   - in: A QueryByVectorIdRequest object with the parameters for the request.
+//   - opts: (Optional) [CallOption] values that apply to this call only, e.g. [WithTimeout] or [WithHeader].
 //
 // Example:
 //
@@ -1001,7 +1109,10 @@ type QueryByVectorIdRequest struct {
 //				fmt.Printf("Match vector with ID `%s`, with score %f\n", match.Vector.Id, match.Score)
 //			}
 //	    }
-func (idx *IndexConnection) QueryByVectorId(ctx context.Context, in *QueryByVectorIdRequest) (*QueryVectorsResponse, error) {
+func (idx *IndexConnection) QueryByVectorId(ctx context.Context, in *QueryByVectorIdRequest, opts ...CallOption) (*QueryVectorsResponse, error) {
+	idx, ctx, cancel := idx.withCallOptions(ctx, opts)
+	defer cancel()
+
 	if in == nil {
 		return nil, fmt.Errorf("in (*QueryByVectorIdRequest) cannot be nil")
 	}
@@ -1026,6 +1137,7 @@ func (idx *IndexConnection) QueryByVectorId(ctx context.Context, in *QueryByVect
 //   - ctx: A context.Context object controls the request's lifetime,
 //     allowing for the request to be canceled or to timeout according to the context's deadline.
 //   - in: The [IntegratedRecord] objects to upsert.
+//   - opts: (Optional) [CallOption] values that apply to this call only, e.g. [WithTimeout] or [WithHeader].
 //
 // Returns an error if the request fails.
 //
@@ -1103,7 +1215,10 @@ func (idx *IndexConnection) QueryByVectorId(ctx context.Context, in *QueryByVect
 //	    }
 //
 // [Pinecone Index]: https://docs.pinecone.io/reference/api/2025-01/control-plane/create_for_model
-func (idx *IndexConnection) UpsertRecords(ctx context.Context, records []*IntegratedRecord) error {
+func (idx *IndexConnection) UpsertRecords(ctx context.Context, records []*IntegratedRecord, opts ...CallOption) error {
+	idx, ctx, cancel := idx.withCallOptions(ctx, opts)
+	defer cancel()
+
 	var buffer bytes.Buffer
 	encoder := json.NewEncoder(&buffer)
 
@@ -1215,10 +1330,24 @@ func (idx *IndexConnection) UpsertRecords(ctx context.Context, records []*Integr
 //	    fmt.Printf("Search results: %+v\n", res)
 //
 // [Pinecone Index]: https://docs.pinecone.io/reference/api/2025-01/control-plane/create_for_model
-func (idx *IndexConnection) SearchRecords(ctx context.Context, in *SearchRecordsRequest) (*SearchRecordsResponse, error) {
+func (idx *IndexConnection) SearchRecords(ctx context.Context, in *SearchRecordsRequest, opts ...CallOption) (*SearchRecordsResponse, error) {
+	idx, ctx, cancel := idx.withCallOptions(ctx, opts)
+	defer cancel()
+
 	if in == nil {
 		return nil, fmt.Errorf("in (*SearchRecordsRequest) cannot be nil")
 	}
+	if idx.inference != nil && idx.inference.validate && in.Rerank != nil {
+		params, err := idx.inference.validateParametersPtr(ctx, in.Rerank.Model, in.Rerank.Parameters, true)
+		if err != nil {
+			return nil, err
+		}
+		rerank := *in.Rerank
+		rerank.Parameters = params
+		validated := *in
+		validated.Rerank = &rerank
+		in = &validated
+	}
 	var convertedVector *db_data_rest.SearchRecordsVector
 	if in.Query.Vector != nil {
 		convertedVector = &db_data_rest.SearchRecordsVector{
@@ -1290,7 +1419,16 @@ func (idx *IndexConnection) SearchRecords(ctx context.Context, in *SearchRecords
 	if res.StatusCode != http.StatusOK {
 		return nil, handleErrorResponseBody(res, "failed to search records: ")
 	}
-	return decodeSearchRecordsResponse(res.Body)
+	searchRes, err := decodeSearchRecordsResponse(res.Body)
+	if err != nil {
+		return nil, err
+	}
+	recordUsage(ctx, idx.usage, "SearchRecords", idx.namespace, UsageTotals{
+		ReadUnits:   int64(searchRes.Usage.ReadUnits),
+		EmbedTokens: int64(derefOrDefault(searchRes.Usage.EmbedTotalTokens, 0)),
+		RerankUnits: int64(derefOrDefault(searchRes.Usage.RerankUnits, 0)),
+	})
+	return searchRes, nil
 }
 
 // [IndexConnection.DeleteVectorsById] deletes vectors by ID from a Pinecone [Index].
@@ -1305,6 +1443,7 @@ func (idx *IndexConnection) SearchRecords(ctx context.Context, in *SearchRecords
 //   - ctx: A context.Context object controls the request's lifetime,
 //     allowing for the request to be canceled or to timeout according to the context's deadline.
 //   - ids: IDs of the vectors you want to delete.
+//   - opts: (Optional) [CallOption] values that apply to this call only, e.g. [WithTimeout] or [WithHeader].
 //
 // Example:
 //
@@ -1334,7 +1473,10 @@ func (idx *IndexConnection) SearchRecords(ctx context.Context, in *SearchRecords
 //	    if err != nil {
 //			log.Fatalf("Failed to delete vector with ID: %s. Error: %s\n", vectorId, err)
 //	    }
-func (idx *IndexConnection) DeleteVectorsById(ctx context.Context, ids []string) error {
+func (idx *IndexConnection) DeleteVectorsById(ctx context.Context, ids []string, opts ...CallOption) error {
+	idx, ctx, cancel := idx.withCallOptions(ctx, opts)
+	defer cancel()
+
 	req := db_data_grpc.DeleteRequest{
 		Ids:       ids,
 		Namespace: idx.namespace,
@@ -1355,6 +1497,7 @@ func (idx *IndexConnection) DeleteVectorsById(ctx context.Context, ids []string)
 //   - ctx: A context.Context object controls the request's lifetime,
 //     allowing for the request to be canceled or to timeout according to the context's deadline.
 //   - MetadataFilter: The filter to apply to the deletion.
+//   - opts: (Optional) [CallOption] values that apply to this call only, e.g. [WithTimeout] or [WithHeader].
 //
 // Example:
 //
@@ -1392,7 +1535,10 @@ func (idx *IndexConnection) DeleteVectorsById(ctx context.Context, ids []string)
 //	    if err != nil {
 //			log.Fatalf("Failed to delete vector(s) with filter: %+v. Error: %s\n", filter, err)
 //	    }
-func (idx *IndexConnection) DeleteVectorsByFilter(ctx context.Context, metadataFilter *MetadataFilter) error {
+func (idx *IndexConnection) DeleteVectorsByFilter(ctx context.Context, metadataFilter *MetadataFilter, opts ...CallOption) error {
+	idx, ctx, cancel := idx.withCallOptions(ctx, opts)
+	defer cancel()
+
 	req := db_data_grpc.DeleteRequest{
 		Filter:    metadataFilter,
 		Namespace: idx.namespace,
@@ -1411,6 +1557,7 @@ func (idx *IndexConnection) DeleteVectorsByFilter(ctx context.Context, metadataF
 // Parameters:
 //   - ctx: A context.Context object controls the request's lifetime,
 //     allowing for the request to be canceled or to timeout according to the context's deadline.
+//   - opts: (Optional) [CallOption] values that apply to this call only, e.g. [WithTimeout] or [WithHeader].
 //
 // Example:
 //
@@ -1439,7 +1586,10 @@ func (idx *IndexConnection) DeleteVectorsByFilter(ctx context.Context, metadataF
 //	    if err != nil {
 //			log.Fatalf("Failed to delete vectors in namespace: \"%s\". Error: %s", "your-namespace", err)
 //	    }
-func (idx *IndexConnection) DeleteAllVectorsInNamespace(ctx context.Context) error {
+func (idx *IndexConnection) DeleteAllVectorsInNamespace(ctx context.Context, opts ...CallOption) error {
+	idx, ctx, cancel := idx.withCallOptions(ctx, opts)
+	defer cancel()
+
 	req := db_data_grpc.DeleteRequest{
 		Namespace: idx.namespace,
 		DeleteAll: true,
@@ -1477,6 +1627,7 @@ type DescribeIndexStatsResponse struct {
 // Parameters:
 //   - ctx: A context.Context object controls the request's lifetime,
 //     allowing for the request to be canceled or to timeout according to the context's deadline.
+//   - opts: (Optional) [CallOption] values that apply to this call only, e.g. [WithTimeout] or [WithHeader].
 //
 // Example:
 //
@@ -1507,7 +1658,10 @@ type DescribeIndexStatsResponse struct {
 //	    } else {
 //			log.Fatalf("%+v", *res)
 //	    }
-func (idx *IndexConnection) DescribeIndexStats(ctx context.Context) (*DescribeIndexStatsResponse, error) {
+func (idx *IndexConnection) DescribeIndexStats(ctx context.Context, opts ...CallOption) (*DescribeIndexStatsResponse, error) {
+	idx, ctx, cancel := idx.withCallOptions(ctx, opts)
+	defer cancel()
+
 	return idx.DescribeIndexStatsFiltered(ctx, nil)
 }
 
@@ -1521,6 +1675,7 @@ func (idx *IndexConnection) DescribeIndexStats(ctx context.Context) (*DescribeIn
 //   - ctx: A context.Context object controls the request's lifetime,
 //     allowing for the request to be canceled or to timeout according to the context's deadline.
 //   - MetadataFilter: The filter to apply to the request.
+//   - opts: (Optional) [CallOption] values that apply to this call only, e.g. [WithTimeout] or [WithHeader].
 //
 // Example:
 //
@@ -1562,7 +1717,10 @@ func (idx *IndexConnection) DescribeIndexStats(ctx context.Context) (*DescribeIn
 //				fmt.Printf("Namespace: \"%s\", has %d vector(s) that match the given filter\n", name, summary.VectorCount)
 //			}
 //	    }
-func (idx *IndexConnection) DescribeIndexStatsFiltered(ctx context.Context, metadataFilter *MetadataFilter) (*DescribeIndexStatsResponse, error) {
+func (idx *IndexConnection) DescribeIndexStatsFiltered(ctx context.Context, metadataFilter *MetadataFilter, opts ...CallOption) (*DescribeIndexStatsResponse, error) {
+	idx, ctx, cancel := idx.withCallOptions(ctx, opts)
+	defer cancel()
+
 	req := &db_data_grpc.DescribeIndexStatsRequest{
 		Filter: metadataFilter,
 	}
@@ -1620,6 +1778,7 @@ type StartImportResponse struct {
 //     Pass nil if not required.
 //   - errorMode: If set to "continue", the import operation will continue even if some records fail to import.
 //     Pass "abort" to stop the import operation if any records fail. Will default to "continue" if nil is passed.
+//   - opts: (Optional) [CallOption] values that apply to this call only, e.g. [WithTimeout] or [WithHeader].
 //
 // Example:
 //
@@ -1653,7 +1812,10 @@ type StartImportResponse struct {
 //	    fmt.Printf("Import started with ID: %s", importRes.Id)
 //
 // [storage integration]: https://docs.pinecone.io/guides/operations/integrations/manage-storage-integrations
-func (idx *IndexConnection) StartImport(ctx context.Context, uri string, integrationId *string, errorMode *string) (*StartImportResponse, error) {
+func (idx *IndexConnection) StartImport(ctx context.Context, uri string, integrationId *string, errorMode *string, opts ...CallOption) (*StartImportResponse, error) {
+	idx, ctx, cancel := idx.withCallOptions(ctx, opts)
+	defer cancel()
+
 	if uri == "" {
 		return nil, fmt.Errorf("must specify a uri to start an import")
 	}
@@ -1691,6 +1853,7 @@ func (idx *IndexConnection) StartImport(ctx context.Context, uri string, integra
 //     allowing for the request to be canceled or to timeout according to the context's deadline.
 //   - id: The id of the import operation. This is returned when you call [IndexConnection.StartImport], or can be retrieved
 //     through the [IndexConnection.ListImports] method.
+//   - opts: (Optional) [CallOption] values that apply to this call only, e.g. [WithTimeout] or [WithHeader].
 //
 // Example:
 //
@@ -1719,7 +1882,10 @@ func (idx *IndexConnection) StartImport(ctx context.Context, uri string, integra
 //			log.Fatalf("Failed to describe import: %s - %v", "your-import-id", err)
 //	    }
 //	    fmt.Printf("Import ID: %s, Status: %s", importDesc.Id, importDesc.Status)
-func (idx *IndexConnection) DescribeImport(ctx context.Context, id string) (*Import, error) {
+func (idx *IndexConnection) DescribeImport(ctx context.Context, id string, opts ...CallOption) (*Import, error) {
+	idx, ctx, cancel := idx.withCallOptions(ctx, opts)
+	defer cancel()
+
 	res, err := (*idx.restClient).DescribeBulkImport(idx.akCtx(ctx), id, &db_data_rest.DescribeBulkImportParams{XPineconeApiVersion: gen.PineconeApiVersion})
 	if err != nil {
 		return nil, err
@@ -1766,6 +1932,7 @@ type ListImportsResponse struct {
 //   - ctx: A context.Context object controls the request's lifetime,
 //     allowing for the request to be canceled or to timeout according to the context's deadline.
 //   - req: A [ListImportsRequest] object containing pagination and filter options.
+//   - opts: (Optional) [CallOption] values that apply to this call only, e.g. [WithTimeout] or [WithHeader].
 //
 // Example:
 //
@@ -1803,7 +1970,10 @@ type ListImportsResponse struct {
 //			log.Fatalf("Failed to list imports: %v", err)
 //	    }
 //	    fmt.Printf("Second page of imports: %+v", nextImportPage.Imports)
-func (idx *IndexConnection) ListImports(ctx context.Context, limit *int32, paginationToken *string) (*ListImportsResponse, error) {
+func (idx *IndexConnection) ListImports(ctx context.Context, limit *int32, paginationToken *string, opts ...CallOption) (*ListImportsResponse, error) {
+	idx, ctx, cancel := idx.withCallOptions(ctx, opts)
+	defer cancel()
+
 	params := db_data_rest.ListBulkImportsParams{
 		Limit:           limit,
 		PaginationToken: paginationToken,
@@ -1835,6 +2005,7 @@ func (idx *IndexConnection) ListImports(ctx context.Context, limit *int32, pagin
 //   - ctx: A context.Context object controls the request's lifetime,
 //     allowing for the request to be canceled or to timeout according to the context's deadline.
 //   - id: The id of the [Import] operation to cancel.
+//   - opts: (Optional) [CallOption] values that apply to this call only, e.g. [WithTimeout] or [WithHeader].
 //
 // Example:
 //
@@ -1863,7 +2034,10 @@ func (idx *IndexConnection) ListImports(ctx context.Context, limit *int32, pagin
 //	    if err != nil {
 //			log.Fatalf("Failed to cancel import: %s", "your-import-id")
 //	    }
-func (idx *IndexConnection) CancelImport(ctx context.Context, id string) error {
+func (idx *IndexConnection) CancelImport(ctx context.Context, id string, opts ...CallOption) error {
+	idx, ctx, cancel := idx.withCallOptions(ctx, opts)
+	defer cancel()
+
 	res, err := (*idx.restClient).CancelBulkImport(idx.akCtx(ctx), id, &db_data_rest.CancelBulkImportParams{XPineconeApiVersion: gen.PineconeApiVersion})
 	if err != nil {
 		return err
@@ -1896,6 +2070,7 @@ type CreateNamespaceParams struct {
 //   - ctx: A context.Context object controls the request's lifetime,
 //     allowing for the request to be canceled or to timeout according to the context's deadline.
 //   - in: A pointer to a [CreateNamespaceParams] object. See [CreateNamespaceParams] for more information.
+//   - opts: (Optional) [CallOption] values that apply to this call only, e.g. [WithTimeout] or [WithHeader].
 //
 // Example:
 //
@@ -1925,7 +2100,10 @@ type CreateNamespaceParams struct {
 //		} else {
 //		    fmt.Printf("Successfully created namespace: %s with %d records", namespace.Name, namespace.RecordCount)
 //		}
-func (idx *IndexConnection) CreateNamespace(ctx context.Context, in *CreateNamespaceParams) (*NamespaceDescription, error) {
+func (idx *IndexConnection) CreateNamespace(ctx context.Context, in *CreateNamespaceParams, opts ...CallOption) (*NamespaceDescription, error) {
+	idx, ctx, cancel := idx.withCallOptions(ctx, opts)
+	defer cancel()
+
 	if in == nil {
 		return nil, fmt.Errorf("in (*CreateNamespaceParams) cannot be nil")
 	}
@@ -1949,6 +2127,7 @@ func (idx *IndexConnection) CreateNamespace(ctx context.Context, in *CreateNames
 //   - ctx: A context.Context object controls the request's lifetime,
 //     allowing for the request to be canceled or to timeout according to the context's deadline.
 //   - namespace: The unique name of the namespace to describe.
+//   - opts: (Optional) [CallOption] values that apply to this call only, e.g. [WithTimeout] or [WithHeader].
 //
 // Example:
 //
@@ -1977,7 +2156,10 @@ func (idx *IndexConnection) CreateNamespace(ctx context.Context, in *CreateNames
 //	    if err != nil {
 //			log.Fatalf("Failed to describe namespace \"%s\". Error:%s", "your-namespace-name", err)
 //		}
-func (idx *IndexConnection) DescribeNamespace(ctx context.Context, namespace string) (*NamespaceDescription, error) {
+func (idx *IndexConnection) DescribeNamespace(ctx context.Context, namespace string, opts ...CallOption) (*NamespaceDescription, error) {
+	idx, ctx, cancel := idx.withCallOptions(ctx, opts)
+	defer cancel()
+
 	res, err := (*idx.grpcClient).DescribeNamespace(idx.akCtx(ctx), &db_data_grpc.DescribeNamespaceRequest{Namespace: namespace})
 	if err != nil {
 		return nil, err
@@ -2018,6 +2200,7 @@ type ListNamespacesParams struct {
 //   - ctx: A context.Context object controls the request's lifetime,
 //     allowing for the request to be canceled or to timeout according to the context's deadline.
 //   - in: A [ListNamespacesParams] object containing limit and pagination options.
+//   - opts: (Optional) [CallOption] values that apply to this call only, e.g. [WithTimeout] or [WithHeader].
 //
 // Example:
 //
@@ -2047,7 +2230,10 @@ type ListNamespacesParams struct {
 //	    if err != nil {
 //			log.Fatalf("Failed to list namespaces for index \"%s\". Error:%s", idx.Name, err)
 //		}
-func (idx *IndexConnection) ListNamespaces(ctx context.Context, in *ListNamespacesParams) (*ListNamespacesResponse, error) {
+func (idx *IndexConnection) ListNamespaces(ctx context.Context, in *ListNamespacesParams, opts ...CallOption) (*ListNamespacesResponse, error) {
+	idx, ctx, cancel := idx.withCallOptions(ctx, opts)
+	defer cancel()
+
 	var listRequest *db_data_grpc.ListNamespacesRequest
 	if in != nil {
 		listRequest = &db_data_grpc.ListNamespacesRequest{
@@ -2071,6 +2257,7 @@ func (idx *IndexConnection) ListNamespaces(ctx context.Context, in *ListNamespac
 //   - ctx: A context.Context object controls the request's lifetime,
 //     allowing for the request to be canceled or to timeout according to the context's deadline.
 //   - namespace: The unique name of the namespace to delete.
+//   - opts: (Optional) [CallOption] values that apply to this call only, e.g. [WithTimeout] or [WithHeader].
 //
 // Example:
 //
@@ -2099,7 +2286,10 @@ func (idx *IndexConnection) ListNamespaces(ctx context.Context, in *ListNamespac
 //	    if err != nil {
 //			log.Fatalf("Failed to delete namespace \"%s\". Error:%s", "your-namespace-name", err)
 //		}
-func (idx *IndexConnection) DeleteNamespace(ctx context.Context, namespace string) error {
+func (idx *IndexConnection) DeleteNamespace(ctx context.Context, namespace string, opts ...CallOption) error {
+	idx, ctx, cancel := idx.withCallOptions(ctx, opts)
+	defer cancel()
+
 	_, err := (*idx.grpcClient).DeleteNamespace(idx.akCtx(ctx), &db_data_grpc.DeleteNamespaceRequest{
 		Namespace: namespace,
 	})
@@ -2121,6 +2311,7 @@ func (idx *IndexConnection) query(ctx context.Context, req *db_data_grpc.QueryRe
 	for i, match := range res.Matches {
 		matches[i] = toScoredVector(match)
 	}
+	recordUsage(ctx, idx.usage, "Query", req.Namespace, readUnitsOf(toUsage(res.Usage)))
 
 	return &QueryVectorsResponse{
 		Matches:   matches,
@@ -2176,6 +2367,9 @@ func (idx *IndexConnection) akCtx(ctx context.Context) context.Context {
 	newMetadata := []string{}
 
 	for key, value := range idx.additionalMetadata {
+		if hasCallHeader(ctx, key) {
+			continue // overridden for this call with WithHeader
+		}
 		newMetadata = append(newMetadata, key, value)
 	}
 
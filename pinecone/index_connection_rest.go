@@ -0,0 +1,499 @@
+package pinecone
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/pinecone-io/go-pinecone/v6/internal/gen"
+	db_data_grpc "github.com/pinecone-io/go-pinecone/v6/internal/gen/db_data/grpc"
+	db_data_rest "github.com/pinecone-io/go-pinecone/v6/internal/gen/db_data/rest"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// [IndexTransport] selects the protocol an [IndexConnection] uses for vector and namespace operations.
+// Records, search, and bulk import operations always use REST.
+type IndexTransport string
+
+const (
+	// IndexTransportGRPC sends vector operations over gRPC. This is the default.
+	IndexTransportGRPC IndexTransport = "grpc"
+	// IndexTransportREST sends every operation over HTTPS, without opening a gRPC connection. Use it in
+	// environments that block HTTP/2 or gRPC egress.
+	IndexTransportREST IndexTransport = "rest"
+)
+
+// restVectorService implements the generated gRPC VectorServiceClient on top of the generated REST client,
+// so every [IndexConnection] method builds the same requests and returns the same types over either transport.
+// The outgoing gRPC metadata on the context (AdditionalMetadata and WithHeader values) is sent as HTTP headers.
+// grpc.CallOption values are ignored.
+type restVectorService struct {
+	client *db_data_rest.Client
+}
+
+var _ db_data_grpc.VectorServiceClient = (*restVectorService)(nil)
+
+func newRestVectorService(client *db_data_rest.Client) db_data_grpc.VectorServiceClient {
+	return &restVectorService{client: client}
+}
+
+func (s *restVectorService) Upsert(ctx context.Context, in *db_data_grpc.UpsertRequest, _ ...grpc.CallOption) (*db_data_grpc.UpsertResponse, error) {
+	vectors := make([]db_data_rest.Vector, len(in.Vectors))
+	for i, v := range in.Vectors {
+		vectors[i] = vecGrpcToRest(v)
+	}
+	req := db_data_rest.UpsertRequest{Vectors: vectors, Namespace: pointerOrNil(in.Namespace)}
+
+	res, err := s.client.UpsertVectors(ctx, &db_data_rest.UpsertVectorsParams{XPineconeApiVersion: gen.PineconeApiVersion}, req, metadataHeaders)
+	var out db_data_rest.UpsertResponse
+	if err := decodeRestResponse(res, err, "failed to upsert vectors: ", &out); err != nil {
+		return nil, err
+	}
+	return &db_data_grpc.UpsertResponse{UpsertedCount: uint32(derefOrDefault(out.UpsertedCount, 0))}, nil
+}
+
+func (s *restVectorService) Delete(ctx context.Context, in *db_data_grpc.DeleteRequest, _ ...grpc.CallOption) (*db_data_grpc.DeleteResponse, error) {
+	req := db_data_rest.DeleteRequest{
+		Namespace: pointerOrNil(in.Namespace),
+		DeleteAll: pointerOrNil(in.DeleteAll),
+		Filter:    structToMap(in.Filter),
+	}
+	if len(in.Ids) > 0 {
+		req.Ids = &in.Ids
+	}
+
+	res, err := s.client.DeleteVectors(ctx, &db_data_rest.DeleteVectorsParams{XPineconeApiVersion: gen.PineconeApiVersion}, req, metadataHeaders)
+	if err := decodeRestResponse(res, err, "failed to delete vectors: ", nil); err != nil {
+		return nil, err
+	}
+	return &db_data_grpc.DeleteResponse{}, nil
+}
+
+func (s *restVectorService) Fetch(ctx context.Context, in *db_data_grpc.FetchRequest, _ ...grpc.CallOption) (*db_data_grpc.FetchResponse, error) {
+	params := &db_data_rest.FetchVectorsParams{
+		Ids:                 in.Ids,
+		Namespace:           pointerOrNil(in.Namespace),
+		XPineconeApiVersion: gen.PineconeApiVersion,
+	}
+
+	res, err := s.client.FetchVectors(ctx, params, metadataHeaders)
+	var out db_data_rest.FetchResponse
+	if err := decodeRestResponse(res, err, "failed to fetch vectors: ", &out); err != nil {
+		return nil, err
+	}
+	vectors, err := vecMapRestToGrpc(out.Vectors)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch vectors: %w", err)
+	}
+	return &db_data_grpc.FetchResponse{
+		Vectors:   vectors,
+		Namespace: derefOrDefault(out.Namespace, ""),
+		Usage:     usageRestToGrpc(out.Usage),
+	}, nil
+}
+
+func (s *restVectorService) FetchByMetadata(ctx context.Context, in *db_data_grpc.FetchByMetadataRequest, _ ...grpc.CallOption) (*db_data_grpc.FetchByMetadataResponse, error) {
+	req := db_data_rest.FetchByMetadataRequest{
+		Namespace:       pointerOrNil(in.Namespace),
+		Filter:          structToMap(in.Filter),
+		PaginationToken: in.PaginationToken,
+	}
+	if in.Limit != nil {
+		limit := int64(*in.Limit)
+		req.Limit = &limit
+	}
+
+	res, err := s.client.FetchVectorsByMetadata(ctx, &db_data_rest.FetchVectorsByMetadataParams{XPineconeApiVersion: gen.PineconeApiVersion}, req, metadataHeaders)
+	var out db_data_rest.FetchByMetadataResponse
+	if err := decodeRestResponse(res, err, "failed to fetch vectors by metadata: ", &out); err != nil {
+		return nil, err
+	}
+	vectors, err := vecMapRestToGrpc(out.Vectors)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch vectors by metadata: %w", err)
+	}
+	return &db_data_grpc.FetchByMetadataResponse{
+		Vectors:    vectors,
+		Namespace:  derefOrDefault(out.Namespace, ""),
+		Usage:      usageRestToGrpc(out.Usage),
+		Pagination: paginationRestToGrpc(out.Pagination),
+	}, nil
+}
+
+func (s *restVectorService) List(ctx context.Context, in *db_data_grpc.ListRequest, _ ...grpc.CallOption) (*db_data_grpc.ListResponse, error) {
+	params := &db_data_rest.ListVectorsParams{
+		Prefix:              in.Prefix,
+		PaginationToken:     in.PaginationToken,
+		Namespace:           pointerOrNil(in.Namespace),
+		XPineconeApiVersion: gen.PineconeApiVersion,
+	}
+	if in.Limit != nil {
+		limit := int64(*in.Limit)
+		params.Limit = &limit
+	}
+
+	res, err := s.client.ListVectors(ctx, params, metadataHeaders)
+	var out db_data_rest.ListResponse
+	if err := decodeRestResponse(res, err, "failed to list vectors: ", &out); err != nil {
+		return nil, err
+	}
+
+	var items []*db_data_grpc.ListItem
+	if out.Vectors != nil {
+		items = make([]*db_data_grpc.ListItem, len(*out.Vectors))
+		for i, item := range *out.Vectors {
+			items[i] = &db_data_grpc.ListItem{Id: derefOrDefault(item.Id, "")}
+		}
+	}
+	return &db_data_grpc.ListResponse{
+		Vectors:    items,
+		Pagination: paginationRestToGrpc(out.Pagination),
+		Namespace:  derefOrDefault(out.Namespace, ""),
+		Usage:      usageRestToGrpc(out.Usage),
+	}, nil
+}
+
+func (s *restVectorService) Query(ctx context.Context, in *db_data_grpc.QueryRequest, _ ...grpc.CallOption) (*db_data_grpc.QueryResponse, error) {
+	req := db_data_rest.QueryRequest{
+		Namespace:       pointerOrNil(in.Namespace),
+		TopK:            int64(in.TopK),
+		Filter:          structToMap(in.Filter),
+		IncludeValues:   pointerOrNil(in.IncludeValues),
+		IncludeMetadata: pointerOrNil(in.IncludeMetadata),
+		Id:              pointerOrNil(in.Id),
+		SparseVector:    sparseGrpcToRest(in.SparseVector),
+		ScanFactor:      in.ScanFactor,
+	}
+	if len(in.Vector) > 0 {
+		req.Vector = &in.Vector
+	}
+	if in.MaxCandidates != nil {
+		maxCandidates := int64(*in.MaxCandidates)
+		req.MaxCandidates = &maxCandidates
+	}
+
+	res, err := s.client.QueryVectors(ctx, &db_data_rest.QueryVectorsParams{XPineconeApiVersion: gen.PineconeApiVersion}, req, metadataHeaders)
+	var out db_data_rest.QueryResponse
+	if err := decodeRestResponse(res, err, "failed to query vectors: ", &out); err != nil {
+		return nil, err
+	}
+
+	var matches []*db_data_grpc.ScoredVector
+	if out.Matches != nil {
+		matches = make([]*db_data_grpc.ScoredVector, len(*out.Matches))
+		for i, m := range *out.Matches {
+			metadata, err := mapToStruct(m.Metadata)
+			if err != nil {
+				return nil, fmt.Errorf("failed to query vectors: metadata of match %q: %w", m.Id, err)
+			}
+			matches[i] = &db_data_grpc.ScoredVector{
+				Id:           m.Id,
+				Score:        derefOrDefault(m.Score, 0),
+				Values:       derefOrDefault(m.Values, nil),
+				SparseValues: sparseRestToGrpc(m.SparseValues),
+				Metadata:     metadata,
+			}
+		}
+	}
+	return &db_data_grpc.QueryResponse{
+		Matches:   matches,
+		Namespace: derefOrDefault(out.Namespace, ""),
+		Usage:     usageRestToGrpc(out.Usage),
+	}, nil
+}
+
+func (s *restVectorService) Update(ctx context.Context, in *db_data_grpc.UpdateRequest, _ ...grpc.CallOption) (*db_data_grpc.UpdateResponse, error) {
+	req := db_data_rest.UpdateRequest{
+		Id:           pointerOrNil(in.Id),
+		SparseValues: sparseGrpcToRest(in.SparseValues),
+		SetMetadata:  structToMap(in.SetMetadata),
+		Namespace:    pointerOrNil(in.Namespace),
+		Filter:       structToMap(in.Filter),
+		DryRun:       in.DryRun,
+	}
+	if len(in.Values) > 0 {
+		req.Values = &in.Values
+	}
+
+	res, err := s.client.UpdateVector(ctx, &db_data_rest.UpdateVectorParams{XPineconeApiVersion: gen.PineconeApiVersion}, req, metadataHeaders)
+	var out db_data_rest.UpdateResponse
+	if err := decodeRestResponse(res, err, "failed to update vectors: ", &out); err != nil {
+		return nil, err
+	}
+	return &db_data_grpc.UpdateResponse{MatchedRecords: out.MatchedRecords}, nil
+}
+
+func (s *restVectorService) DescribeIndexStats(ctx context.Context, in *db_data_grpc.DescribeIndexStatsRequest, _ ...grpc.CallOption) (*db_data_grpc.DescribeIndexStatsResponse, error) {
+	req := db_data_rest.DescribeIndexStatsRequest{Filter: structToMap(in.Filter)}
+
+	res, err := s.client.DescribeIndexStats(ctx, &db_data_rest.DescribeIndexStatsParams{XPineconeApiVersion: gen.PineconeApiVersion}, req, metadataHeaders)
+	var out db_data_rest.IndexDescription
+	if err := decodeRestResponse(res, err, "failed to describe index stats: ", &out); err != nil {
+		return nil, err
+	}
+
+	namespaces := make(map[string]*db_data_grpc.NamespaceSummary)
+	if out.Namespaces != nil {
+		for name, summary := range *out.Namespaces {
+			namespaces[name] = &db_data_grpc.NamespaceSummary{VectorCount: uint32(derefOrDefault(summary.VectorCount, 0))}
+		}
+	}
+	var dimension *uint32
+	if out.Dimension != nil {
+		d := uint32(*out.Dimension)
+		dimension = &d
+	}
+	return &db_data_grpc.DescribeIndexStatsResponse{
+		Namespaces:       namespaces,
+		Dimension:        dimension,
+		IndexFullness:    derefOrDefault(out.IndexFullness, 0),
+		TotalVectorCount: uint32(derefOrDefault(out.TotalVectorCount, 0)),
+		Metric:           out.Metric,
+		VectorType:       out.VectorType,
+		MemoryFullness:   out.MemoryFullness,
+		StorageFullness:  out.StorageFullness,
+	}, nil
+}
+
+func (s *restVectorService) ListNamespaces(ctx context.Context, in *db_data_grpc.ListNamespacesRequest, _ ...grpc.CallOption) (*db_data_grpc.ListNamespacesResponse, error) {
+	if in == nil {
+		in = &db_data_grpc.ListNamespacesRequest{}
+	}
+	params := &db_data_rest.ListNamespacesOperationParams{
+		PaginationToken:     in.PaginationToken,
+		Prefix:              in.Prefix,
+		XPineconeApiVersion: gen.PineconeApiVersion,
+	}
+	if in.Limit != nil {
+		limit := int32(*in.Limit)
+		params.Limit = &limit
+	}
+
+	res, err := s.client.ListNamespacesOperation(ctx, params, metadataHeaders)
+	var out db_data_rest.ListNamespacesResponse
+	if err := decodeRestResponse(res, err, "failed to list namespaces: ", &out); err != nil {
+		return nil, err
+	}
+
+	var namespaces []*db_data_grpc.NamespaceDescription
+	if out.Namespaces != nil {
+		namespaces = make([]*db_data_grpc.NamespaceDescription, len(*out.Namespaces))
+		for i := range *out.Namespaces {
+			namespaces[i] = namespaceRestToGrpc(&(*out.Namespaces)[i])
+		}
+	}
+	return &db_data_grpc.ListNamespacesResponse{
+		Namespaces: namespaces,
+		Pagination: paginationRestToGrpc(out.Pagination),
+		TotalCount: derefOrDefault(out.TotalCount, 0),
+	}, nil
+}
+
+func (s *restVectorService) DescribeNamespace(ctx context.Context, in *db_data_grpc.DescribeNamespaceRequest, _ ...grpc.CallOption) (*db_data_grpc.NamespaceDescription, error) {
+	res, err := s.client.DescribeNamespace(ctx, restNamespace(in.Namespace), &db_data_rest.DescribeNamespaceParams{XPineconeApiVersion: gen.PineconeApiVersion}, metadataHeaders)
+	var out db_data_rest.NamespaceDescription
+	if err := decodeRestResponse(res, err, "failed to describe namespace: ", &out); err != nil {
+		return nil, err
+	}
+	return namespaceRestToGrpc(&out), nil
+}
+
+func (s *restVectorService) DeleteNamespace(ctx context.Context, in *db_data_grpc.DeleteNamespaceRequest, _ ...grpc.CallOption) (*db_data_grpc.DeleteResponse, error) {
+	res, err := s.client.DeleteNamespace(ctx, restNamespace(in.Namespace), &db_data_rest.DeleteNamespaceParams{XPineconeApiVersion: gen.PineconeApiVersion}, metadataHeaders)
+	if err := decodeRestResponse(res, err, "failed to delete namespace: ", nil); err != nil {
+		return nil, err
+	}
+	return &db_data_grpc.DeleteResponse{}, nil
+}
+
+func (s *restVectorService) CreateNamespace(ctx context.Context, in *db_data_grpc.CreateNamespaceRequest, _ ...grpc.CallOption) (*db_data_grpc.NamespaceDescription, error) {
+	// The schema types are anonymous structs in the generated REST client, so round-trip through JSON.
+	var req db_data_rest.CreateNamespaceRequest
+	if err := convertJSON(restNamespaceBody{Name: in.Name, Schema: schemaGrpcToRest(in.Schema)}, &req); err != nil {
+		return nil, err
+	}
+
+	res, err := s.client.CreateNamespace(ctx, &db_data_rest.CreateNamespaceParams{XPineconeApiVersion: gen.PineconeApiVersion}, req, metadataHeaders)
+	var out db_data_rest.NamespaceDescription
+	if err := decodeRestResponse(res, err, "failed to create namespace: ", &out); err != nil {
+		return nil, err
+	}
+	return namespaceRestToGrpc(&out), nil
+}
+
+// metadataHeaders is a REST request editor sending the outgoing gRPC metadata on ctx as HTTP headers.
+func metadataHeaders(ctx context.Context, req *http.Request) error {
+	md, ok := metadata.FromOutgoingContext(ctx)
+	if !ok {
+		return nil
+	}
+	for key, values := range md {
+		if key == "content-type" || len(values) == 0 {
+			continue
+		}
+		req.Header.Set(key, values[len(values)-1])
+	}
+	return nil
+}
+
+// decodeRestResponse closes res, returning a [PineconeError] for non-2xx statuses and otherwise decoding the
+// JSON body into out, if non-nil.
+func decodeRestResponse(res *http.Response, err error, errMsgPrefix string, out any) error {
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		return handleErrorResponseBody(res, errMsgPrefix)
+	}
+	if out == nil {
+		return nil
+	}
+	if err := json.NewDecoder(res.Body).Decode(out); err != nil {
+		return fmt.Errorf("%sfailed to decode response: %w", errMsgPrefix, err)
+	}
+	return nil
+}
+
+func convertJSON(in any, out any) error {
+	b, err := json.Marshal(in)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(b, out)
+}
+
+type restNamespaceBody struct {
+	Name   string              `json:"name,omitempty"`
+	Schema *restMetadataSchema `json:"schema,omitempty"`
+}
+
+type restMetadataSchema struct {
+	Fields map[string]restMetadataField `json:"fields"`
+}
+
+type restMetadataField struct {
+	Filterable bool `json:"filterable"`
+}
+
+func schemaGrpcToRest(schema *db_data_grpc.MetadataSchema) *restMetadataSchema {
+	if schema == nil {
+		return nil
+	}
+	fields := make(map[string]restMetadataField, len(schema.Fields))
+	for name, props := range schema.Fields {
+		fields[name] = restMetadataField{Filterable: props != nil && props.Filterable}
+	}
+	return &restMetadataSchema{Fields: fields}
+}
+
+func namespaceRestToGrpc(ns *db_data_rest.NamespaceDescription) *db_data_grpc.NamespaceDescription {
+	out := &db_data_grpc.NamespaceDescription{
+		Name:        derefOrDefault(ns.Name, ""),
+		RecordCount: uint64(derefOrDefault(ns.RecordCount, 0)),
+	}
+	if ns.Schema != nil {
+		fields := make(map[string]*db_data_grpc.MetadataFieldProperties, len(ns.Schema.Fields))
+		for name, props := range ns.Schema.Fields {
+			fields[name] = &db_data_grpc.MetadataFieldProperties{Filterable: derefOrDefault(props.Filterable, false)}
+		}
+		out.Schema = &db_data_grpc.MetadataSchema{Fields: fields}
+	}
+	if ns.IndexedFields != nil {
+		out.IndexedFields = &db_data_grpc.IndexedFields{Fields: derefOrDefault(ns.IndexedFields.Fields, nil)}
+	}
+	return out
+}
+
+func vecGrpcToRest(v *db_data_grpc.Vector) db_data_rest.Vector {
+	if v == nil {
+		return db_data_rest.Vector{}
+	}
+	out := db_data_rest.Vector{
+		Id:           v.Id,
+		SparseValues: sparseGrpcToRest(v.SparseValues),
+		Metadata:     structToMap(v.Metadata),
+	}
+	if v.Values != nil {
+		values := v.Values
+		out.Values = &values
+	}
+	return out
+}
+
+func vecMapRestToGrpc(vectors *map[string]db_data_rest.Vector) (map[string]*db_data_grpc.Vector, error) {
+	out := make(map[string]*db_data_grpc.Vector)
+	if vectors == nil {
+		return out, nil
+	}
+	for id, v := range *vectors {
+		metadata, err := mapToStruct(v.Metadata)
+		if err != nil {
+			return nil, fmt.Errorf("metadata of vector %q: %w", id, err)
+		}
+		out[id] = &db_data_grpc.Vector{
+			Id:           v.Id,
+			Values:       derefOrDefault(v.Values, nil),
+			SparseValues: sparseRestToGrpc(v.SparseValues),
+			Metadata:     metadata,
+		}
+	}
+	return out, nil
+}
+
+func sparseGrpcToRest(s *db_data_grpc.SparseValues) *db_data_rest.SparseValues {
+	if s == nil {
+		return nil
+	}
+	indices := make([]int64, len(s.Indices))
+	for i, idx := range s.Indices {
+		indices[i] = int64(idx)
+	}
+	return &db_data_rest.SparseValues{Indices: indices, Values: s.Values}
+}
+
+func sparseRestToGrpc(s *db_data_rest.SparseValues) *db_data_grpc.SparseValues {
+	if s == nil {
+		return nil
+	}
+	indices := make([]uint32, len(s.Indices))
+	for i, idx := range s.Indices {
+		indices[i] = uint32(idx)
+	}
+	return &db_data_grpc.SparseValues{Indices: indices, Values: s.Values}
+}
+
+func usageRestToGrpc(u *db_data_rest.Usage) *db_data_grpc.Usage {
+	if u == nil || u.ReadUnits == nil {
+		return nil
+	}
+	readUnits := uint32(*u.ReadUnits)
+	return &db_data_grpc.Usage{ReadUnits: &readUnits}
+}
+
+func paginationRestToGrpc(p *db_data_rest.Pagination) *db_data_grpc.Pagination {
+	if p == nil || p.Next == nil || strings.TrimSpace(*p.Next) == "" {
+		return nil
+	}
+	return &db_data_grpc.Pagination{Next: *p.Next}
+}
+
+func structToMap(s *structpb.Struct) *map[string]interface{} {
+	if s == nil {
+		return nil
+	}
+	m := s.AsMap()
+	return &m
+}
+
+func mapToStruct(m *map[string]interface{}) (*structpb.Struct, error) {
+	if m == nil {
+		return nil, nil
+	}
+	return structpb.NewStruct(*m)
+}
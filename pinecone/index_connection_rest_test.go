@@ -0,0 +1,430 @@
+package pinecone
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	db_data_grpc "github.com/pinecone-io/go-pinecone/v6/internal/gen/db_data/grpc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// memoryVectorService is a minimal in-memory index served over gRPC directly and over REST by restHandler,
+// so both transports can be checked against the same backend behavior.
+type memoryVectorService struct {
+	db_data_grpc.UnimplementedVectorServiceServer
+
+	mu      sync.Mutex
+	vectors map[string]map[string]*db_data_grpc.Vector
+	tenant  string
+}
+
+func newMemoryVectorService() *memoryVectorService {
+	return &memoryVectorService{vectors: make(map[string]map[string]*db_data_grpc.Vector)}
+}
+
+func (s *memoryVectorService) recordTenant(ctx context.Context) {
+	if md, ok := metadata.FromIncomingContext(ctx); ok && len(md.Get("x-tenant")) > 0 {
+		s.tenant = md.Get("x-tenant")[0]
+	}
+}
+
+func (s *memoryVectorService) Upsert(ctx context.Context, req *db_data_grpc.UpsertRequest) (*db_data_grpc.UpsertResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.recordTenant(ctx)
+	if s.vectors[req.Namespace] == nil {
+		s.vectors[req.Namespace] = make(map[string]*db_data_grpc.Vector)
+	}
+	for _, v := range req.Vectors {
+		s.vectors[req.Namespace][v.Id] = v
+	}
+	return &db_data_grpc.UpsertResponse{UpsertedCount: uint32(len(req.Vectors))}, nil
+}
+
+func (s *memoryVectorService) Fetch(_ context.Context, req *db_data_grpc.FetchRequest) (*db_data_grpc.FetchResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	out := make(map[string]*db_data_grpc.Vector)
+	for _, id := range req.Ids {
+		if v, ok := s.vectors[req.Namespace][id]; ok {
+			out[id] = v
+		}
+	}
+	readUnits := uint32(1)
+	return &db_data_grpc.FetchResponse{Vectors: out, Namespace: req.Namespace, Usage: &db_data_grpc.Usage{ReadUnits: &readUnits}}, nil
+}
+
+func (s *memoryVectorService) List(_ context.Context, req *db_data_grpc.ListRequest) (*db_data_grpc.ListResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var ids []string
+	for id := range s.vectors[req.Namespace] {
+		if req.Prefix == nil || strings.HasPrefix(id, *req.Prefix) {
+			ids = append(ids, id)
+		}
+	}
+	sort.Strings(ids)
+
+	start := 0
+	if req.PaginationToken != nil {
+		start, _ = strconv.Atoi(*req.PaginationToken)
+	}
+	end := len(ids)
+	if req.Limit != nil && start+int(*req.Limit) < end {
+		end = start + int(*req.Limit)
+	}
+	res := &db_data_grpc.ListResponse{Namespace: req.Namespace}
+	for _, id := range ids[start:end] {
+		res.Vectors = append(res.Vectors, &db_data_grpc.ListItem{Id: id})
+	}
+	if end < len(ids) {
+		res.Pagination = &db_data_grpc.Pagination{Next: strconv.Itoa(end)}
+	}
+	return res, nil
+}
+
+func (s *memoryVectorService) Query(_ context.Context, req *db_data_grpc.QueryRequest) (*db_data_grpc.QueryResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var matches []*db_data_grpc.ScoredVector
+	for _, v := range s.vectors[req.Namespace] {
+		var score float32
+		for i := range v.Values {
+			if i < len(req.Vector) {
+				score += v.Values[i] * req.Vector[i]
+			}
+		}
+		m := &db_data_grpc.ScoredVector{Id: v.Id, Score: score}
+		if req.IncludeValues {
+			m.Values = v.Values
+			m.SparseValues = v.SparseValues
+		}
+		if req.IncludeMetadata {
+			m.Metadata = v.Metadata
+		}
+		matches = append(matches, m)
+	}
+	sort.Slice(matches, func(i, j int) bool {
+		if matches[i].Score != matches[j].Score {
+			return matches[i].Score > matches[j].Score
+		}
+		return matches[i].Id < matches[j].Id
+	})
+	if len(matches) > int(req.TopK) {
+		matches = matches[:req.TopK]
+	}
+	readUnits := uint32(5)
+	return &db_data_grpc.QueryResponse{Matches: matches, Namespace: req.Namespace, Usage: &db_data_grpc.Usage{ReadUnits: &readUnits}}, nil
+}
+
+func (s *memoryVectorService) Update(_ context.Context, req *db_data_grpc.UpdateRequest) (*db_data_grpc.UpdateResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	v, ok := s.vectors[req.Namespace][req.Id]
+	if !ok {
+		return &db_data_grpc.UpdateResponse{}, nil
+	}
+	updated := proto.Clone(v).(*db_data_grpc.Vector)
+	if len(req.Values) > 0 {
+		updated.Values = req.Values
+	}
+	if req.SetMetadata != nil {
+		updated.Metadata = req.SetMetadata
+	}
+	s.vectors[req.Namespace][req.Id] = updated
+	return &db_data_grpc.UpdateResponse{}, nil
+}
+
+func (s *memoryVectorService) Delete(_ context.Context, req *db_data_grpc.DeleteRequest) (*db_data_grpc.DeleteResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if req.DeleteAll {
+		delete(s.vectors, req.Namespace)
+	}
+	for _, id := range req.Ids {
+		delete(s.vectors[req.Namespace], id)
+	}
+	return &db_data_grpc.DeleteResponse{}, nil
+}
+
+func (s *memoryVectorService) DescribeIndexStats(_ context.Context, _ *db_data_grpc.DescribeIndexStatsRequest) (*db_data_grpc.DescribeIndexStatsResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	dimension := uint32(3)
+	res := &db_data_grpc.DescribeIndexStatsResponse{Namespaces: make(map[string]*db_data_grpc.NamespaceSummary), Dimension: &dimension}
+	for ns, vectors := range s.vectors {
+		res.Namespaces[ns] = &db_data_grpc.NamespaceSummary{VectorCount: uint32(len(vectors))}
+		res.TotalVectorCount += uint32(len(vectors))
+	}
+	return res, nil
+}
+
+func (s *memoryVectorService) DescribeNamespace(_ context.Context, req *db_data_grpc.DescribeNamespaceRequest) (*db_data_grpc.NamespaceDescription, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return &db_data_grpc.NamespaceDescription{Name: req.Namespace, RecordCount: uint64(len(s.vectors[req.Namespace]))}, nil
+}
+
+// restHandler serves the REST data plane endpoints used by [IndexConnection] from s, translating JSON
+// bodies and query parameters to the gRPC request types.
+func (s *memoryVectorService) restHandler() http.Handler {
+	mux := http.NewServeMux()
+	unmarshal := protojson.UnmarshalOptions{DiscardUnknown: true}
+	post := func(path string, newReq func() proto.Message, call func(context.Context, proto.Message) (proto.Message, error)) {
+		mux.HandleFunc("POST "+path, func(w http.ResponseWriter, r *http.Request) {
+			body, _ := io.ReadAll(r.Body)
+			req := newReq()
+			if err := unmarshal.Unmarshal(body, req); err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			s.recordTenantHeader(r)
+			res, err := call(r.Context(), req)
+			writeProtoJSON(w, res, err)
+		})
+	}
+
+	post("/vectors/upsert", func() proto.Message { return &db_data_grpc.UpsertRequest{} },
+		func(ctx context.Context, m proto.Message) (proto.Message, error) {
+			return s.Upsert(ctx, m.(*db_data_grpc.UpsertRequest))
+		})
+	post("/query", func() proto.Message { return &db_data_grpc.QueryRequest{} },
+		func(ctx context.Context, m proto.Message) (proto.Message, error) {
+			return s.Query(ctx, m.(*db_data_grpc.QueryRequest))
+		})
+	post("/vectors/update", func() proto.Message { return &db_data_grpc.UpdateRequest{} },
+		func(ctx context.Context, m proto.Message) (proto.Message, error) {
+			return s.Update(ctx, m.(*db_data_grpc.UpdateRequest))
+		})
+	post("/vectors/delete", func() proto.Message { return &db_data_grpc.DeleteRequest{} },
+		func(ctx context.Context, m proto.Message) (proto.Message, error) {
+			return s.Delete(ctx, m.(*db_data_grpc.DeleteRequest))
+		})
+	post("/describe_index_stats", func() proto.Message { return &db_data_grpc.DescribeIndexStatsRequest{} },
+		func(ctx context.Context, m proto.Message) (proto.Message, error) {
+			return s.DescribeIndexStats(ctx, m.(*db_data_grpc.DescribeIndexStatsRequest))
+		})
+
+	mux.HandleFunc("GET /vectors/fetch", func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		res, err := s.Fetch(r.Context(), &db_data_grpc.FetchRequest{Ids: q["ids"], Namespace: q.Get("namespace")})
+		writeProtoJSON(w, res, err)
+	})
+	mux.HandleFunc("GET /vectors/list", func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		req := &db_data_grpc.ListRequest{Namespace: q.Get("namespace")}
+		if q.Has("prefix") {
+			req.Prefix = proto.String(q.Get("prefix"))
+		}
+		if q.Has("paginationToken") {
+			req.PaginationToken = proto.String(q.Get("paginationToken"))
+		}
+		if q.Has("limit") {
+			limit, _ := strconv.Atoi(q.Get("limit"))
+			req.Limit = proto.Uint32(uint32(limit))
+		}
+		res, err := s.List(r.Context(), req)
+		writeProtoJSON(w, res, err)
+	})
+	mux.HandleFunc("GET /namespaces/{namespace}", func(w http.ResponseWriter, r *http.Request) {
+		ns := r.PathValue("namespace")
+		if ns == "__default__" {
+			ns = ""
+		}
+		res, _ := s.DescribeNamespace(r.Context(), &db_data_grpc.DescribeNamespaceRequest{Namespace: ns})
+		// protojson encodes uint64 as a string, while the REST API returns a number.
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{"name": res.Name, "record_count": res.RecordCount})
+	})
+	return mux
+}
+
+func (s *memoryVectorService) recordTenantHeader(r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if tenant := r.Header.Get("X-Tenant"); tenant != "" {
+		s.tenant = tenant
+	}
+}
+
+func writeProtoJSON(w http.ResponseWriter, res proto.Message, err error) {
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	b, err := protojson.Marshal(res)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write(b)
+}
+
+// newTransportConnections returns an IndexConnection for each transport, each backed by its own
+// memoryVectorService.
+func newTransportConnections(t *testing.T) map[IndexTransport]*IndexConnection {
+	t.Helper()
+
+	grpcSvc := newMemoryVectorService()
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	grpcServer := grpc.NewServer()
+	db_data_grpc.RegisterVectorServiceServer(grpcServer, grpcSvc)
+	go func() { _ = grpcServer.Serve(lis) }()
+	t.Cleanup(grpcServer.Stop)
+
+	restSvc := newMemoryVectorService()
+	restServer := httptest.NewTLSServer(restSvc.restHandler())
+	t.Cleanup(restServer.Close)
+
+	pc, err := NewClient(NewClientParams{ApiKey: "test-key", RestClient: restServer.Client()})
+	require.NoError(t, err)
+
+	grpcIdx, err := pc.Index(NewIndexConnParams{Host: "http://" + lis.Addr().String(), Namespace: "ns1"})
+	require.NoError(t, err)
+	restIdx, err := pc.Index(NewIndexConnParams{Host: restServer.URL, Namespace: "ns1", Transport: IndexTransportREST})
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = grpcIdx.Close()
+		_ = restIdx.Close()
+	})
+
+	return map[IndexTransport]*IndexConnection{IndexTransportGRPC: grpcIdx, IndexTransportREST: restIdx}
+}
+
+// Unit tests:
+func TestIndexTransportParityUnit(t *testing.T) {
+	type results struct {
+		upserted    uint32
+		fetched     *FetchVectorsResponse
+		queried     *QueryVectorsResponse
+		listed      *ListVectorsResponse
+		nextPage    *ListVectorsResponse
+		stats       *DescribeIndexStatsResponse
+		namespace   *NamespaceDescription
+		afterUpdate *FetchVectorsResponse
+		afterDelete *DescribeIndexStatsResponse
+	}
+
+	metadata, err := NewMetadata(map[string]any{"genre": "drama", "year": 2020})
+	require.NoError(t, err)
+	updatedMetadata, err := NewMetadata(map[string]any{"genre": "comedy"})
+	require.NoError(t, err)
+	vectors := []*Vector{
+		{Id: "a1", Values: &[]float32{1, 0, 0}, Metadata: metadata},
+		{Id: "a2", Values: &[]float32{0, 1, 0}, SparseValues: &SparseValues{Indices: []uint32{1, 7}, Values: []float32{0.5, 0.25}}},
+		{Id: "b1", Values: &[]float32{0.5, 0.5, 0}},
+	}
+
+	out := make(map[IndexTransport]results)
+	for transport, idx := range newTransportConnections(t) {
+		ctx := context.Background()
+		var r results
+
+		r.upserted, err = idx.UpsertVectors(ctx, vectors)
+		require.NoError(t, err, transport)
+		r.fetched, err = idx.FetchVectors(ctx, []string{"a1", "a2", "missing"})
+		require.NoError(t, err, transport)
+		r.queried, err = idx.QueryByVectorValues(ctx, &QueryByVectorValuesRequest{
+			Vector: []float32{1, 0.5, 0}, TopK: 2, IncludeValues: true, IncludeMetadata: true,
+		})
+		require.NoError(t, err, transport)
+		r.listed, err = idx.ListVectors(ctx, &ListVectorsRequest{Prefix: proto.String("a"), Limit: proto.Uint32(1)})
+		require.NoError(t, err, transport)
+		r.nextPage, err = idx.ListVectors(ctx, &ListVectorsRequest{Prefix: proto.String("a"), Limit: proto.Uint32(1), PaginationToken: r.listed.NextPaginationToken})
+		require.NoError(t, err, transport)
+		r.stats, err = idx.DescribeIndexStats(ctx)
+		require.NoError(t, err, transport)
+		r.namespace, err = idx.DescribeNamespace(ctx, "ns1")
+		require.NoError(t, err, transport)
+
+		require.NoError(t, idx.UpdateVector(ctx, &UpdateVectorRequest{Id: "a1", Metadata: updatedMetadata}), transport)
+		r.afterUpdate, err = idx.FetchVectors(ctx, []string{"a1"})
+		require.NoError(t, err, transport)
+		require.NoError(t, idx.DeleteVectorsById(ctx, []string{"b1"}), transport)
+		r.afterDelete, err = idx.DescribeIndexStats(ctx)
+		require.NoError(t, err, transport)
+
+		out[transport] = r
+	}
+
+	grpcResults, restResults := out[IndexTransportGRPC], out[IndexTransportREST]
+	assert.Equal(t, grpcResults, restResults, "both transports should return identical results")
+
+	// Sanity-check the shared results so the comparison is not vacuous.
+	assert.Equal(t, uint32(3), restResults.upserted)
+	assert.Len(t, restResults.fetched.Vectors, 2)
+	require.Len(t, restResults.queried.Matches, 2)
+	assert.Equal(t, "a1", restResults.queried.Matches[0].Vector.Id)
+	assert.Equal(t, "drama", restResults.queried.Matches[0].Vector.Metadata.Fields["genre"].GetStringValue())
+	assert.Equal(t, uint32(5), restResults.queried.Usage.ReadUnits)
+	require.Len(t, restResults.listed.VectorIds, 1)
+	assert.Equal(t, "a1", *restResults.listed.VectorIds[0])
+	assert.Equal(t, "a2", *restResults.nextPage.VectorIds[0])
+	assert.Equal(t, []uint32{1, 7}, restResults.fetched.Vectors["a2"].SparseValues.Indices)
+	assert.Equal(t, uint32(3), restResults.stats.TotalVectorCount)
+	assert.Equal(t, uint64(3), restResults.namespace.RecordCount)
+	assert.Equal(t, "comedy", restResults.afterUpdate.Vectors["a1"].Metadata.Fields["genre"].GetStringValue())
+	assert.Equal(t, uint32(2), restResults.afterDelete.TotalVectorCount)
+}
+
+func TestIndexTransportRestHeadersAndErrorsUnit(t *testing.T) {
+	svc := newMemoryVectorService()
+	srv := httptest.NewTLSServer(svc.restHandler())
+	defer srv.Close()
+
+	pc, err := NewClient(NewClientParams{ApiKey: "test-key", RestClient: srv.Client()})
+	require.NoError(t, err)
+	idx, err := pc.Index(NewIndexConnParams{Host: srv.URL, Transport: IndexTransportREST, AdditionalMetadata: map[string]string{"x-tenant": "conn"}})
+	require.NoError(t, err)
+	assert.Nil(t, idx.grpcConn, "a REST connection should not dial gRPC")
+	require.NoError(t, idx.Close())
+
+	_, err = idx.UpsertVectors(context.Background(), []*Vector{{Id: "v1", Values: &[]float32{1}}})
+	require.NoError(t, err)
+	assert.Equal(t, "conn", svc.tenant, "connection metadata should be sent as HTTP headers")
+
+	_, err = idx.UpsertVectors(context.Background(), []*Vector{{Id: "v1", Values: &[]float32{1}}}, WithHeader("x-tenant", "call"))
+	require.NoError(t, err)
+	assert.Equal(t, "call", svc.tenant)
+
+	// Endpoints the server does not serve surface as a PineconeError, like any other REST call.
+	_, err = idx.ListNamespaces(context.Background(), nil)
+	var perr *PineconeError
+	require.ErrorAs(t, err, &perr)
+	assert.Equal(t, http.StatusNotFound, perr.Code)
+
+	_, err = pc.Index(NewIndexConnParams{Host: srv.URL, Transport: "http3"})
+	require.ErrorContains(t, err, "invalid Transport")
+}
+
+func TestIndexTransportRestRejectsDialOptionsUnit(t *testing.T) {
+	pc, err := NewClient(NewClientParams{ApiKey: "test-key"})
+	require.NoError(t, err)
+	_, err = pc.Index(NewIndexConnParams{Host: "https://my-index.svc.pinecone.io", Transport: IndexTransportREST}, grpc.WithUserAgent("test"))
+	require.ErrorContains(t, err, "dial options")
+}
+
+func TestMapToStructPropagatesErrorsUnit(t *testing.T) {
+	s, err := mapToStruct(&map[string]interface{}{"genre": "drama"})
+	require.NoError(t, err)
+	assert.Equal(t, "drama", s.Fields["genre"].GetStringValue())
+
+	_, err = mapToStruct(&map[string]interface{}{"invalid": "\xff"})
+	require.Error(t, err, "metadata that cannot be converted should fail rather than be dropped")
+}
@@ -0,0 +1,161 @@
+package pinecone
+
+import "context"
+
+// [VectorStore] provides an interface for the vector data plane of an index: the methods of [IndexConnection]
+// for reading and writing vectors and namespaces. Depend on VectorStore rather than *IndexConnection to swap
+// in another implementation, such as the in-memory index of the localindex package or the stubs of the
+// pineconetest package.
+//
+// Implementations run each call against the namespace set with [WithNamespace], if any, and otherwise against
+// their own namespace, which [CallNamespace] helps with.
+type VectorStore interface {
+	// Upsert vectors, returning the number of vectors upserted.
+	UpsertVectors(ctx context.Context, in []*Vector, opts ...CallOption) (uint32, error)
+
+	// Fetch vectors by ID.
+	FetchVectors(ctx context.Context, ids []string, opts ...CallOption) (*FetchVectorsResponse, error)
+
+	// Fetch vectors whose metadata matches a filter.
+	FetchVectorsByMetadata(ctx context.Context, in *FetchVectorsByMetadataRequest, opts ...CallOption) (*FetchVectorsByMetadataResponse, error)
+
+	// List vector IDs, optionally by prefix.
+	ListVectors(ctx context.Context, in *ListVectorsRequest, opts ...CallOption) (*ListVectorsResponse, error)
+
+	// Query by a vector's values.
+	QueryByVectorValues(ctx context.Context, in *QueryByVectorValuesRequest, opts ...CallOption) (*QueryVectorsResponse, error)
+
+	// Query by the values of a stored vector, identified by ID.
+	QueryByVectorId(ctx context.Context, in *QueryByVectorIdRequest, opts ...CallOption) (*QueryVectorsResponse, error)
+
+	// Update a vector's values, sparse values, or metadata by ID.
+	UpdateVector(ctx context.Context, in *UpdateVectorRequest, opts ...CallOption) error
+
+	// Update the metadata of every vector matching a filter.
+	UpdateVectorsByMetadata(ctx context.Context, in *UpdateVectorsByMetadataRequest, opts ...CallOption) (*UpdateVectorsByMetadataResponse, error)
+
+	// Delete vectors by ID.
+	DeleteVectorsById(ctx context.Context, ids []string, opts ...CallOption) error
+
+	// Delete vectors whose metadata matches a filter.
+	DeleteVectorsByFilter(ctx context.Context, metadataFilter *MetadataFilter, opts ...CallOption) error
+
+	// Delete every vector in the namespace.
+	DeleteAllVectorsInNamespace(ctx context.Context, opts ...CallOption) error
+
+	// Describe the dimension, metric, and vector counts of the index.
+	DescribeIndexStats(ctx context.Context, opts ...CallOption) (*DescribeIndexStatsResponse, error)
+
+	// Describe the index, counting only vectors that match a filter.
+	DescribeIndexStatsFiltered(ctx context.Context, metadataFilter *MetadataFilter, opts ...CallOption) (*DescribeIndexStatsResponse, error)
+
+	// Create a namespace.
+	CreateNamespace(ctx context.Context, in *CreateNamespaceParams, opts ...CallOption) (*NamespaceDescription, error)
+
+	// Describe a namespace by name.
+	DescribeNamespace(ctx context.Context, namespace string, opts ...CallOption) (*NamespaceDescription, error)
+
+	// List the namespaces of the index.
+	ListNamespaces(ctx context.Context, in *ListNamespacesParams, opts ...CallOption) (*ListNamespacesResponse, error)
+
+	// Delete a namespace and all of its vectors.
+	DeleteNamespace(ctx context.Context, namespace string, opts ...CallOption) error
+
+	// Namespace returns the namespace targeted when no [WithNamespace] option is given.
+	Namespace() string
+
+	// Close releases the resources held by the store.
+	Close() error
+}
+
+// [ControlPlane] provides an interface for managing indexes, collections, backups, and restore jobs: the
+// control plane methods of [Client]. [Client.Index] is not included, since it returns the concrete
+// *IndexConnection; depend on [VectorStore] for data plane operations.
+type ControlPlane interface {
+	// List all indexes in the project.
+	ListIndexes(ctx context.Context, opts ...CallOption) ([]*Index, error)
+
+	// Create a pod-based index.
+	CreatePodIndex(ctx context.Context, in *CreatePodIndexRequest, opts ...CallOption) (*Index, error)
+
+	// Create a serverless index.
+	CreateServerlessIndex(ctx context.Context, in *CreateServerlessIndexRequest, opts ...CallOption) (*Index, error)
+
+	// Create an index with integrated inference for a hosted embedding model.
+	CreateIndexForModel(ctx context.Context, in *CreateIndexForModelRequest, opts ...CallOption) (*Index, error)
+
+	// Create a bring-your-own-cloud index.
+	CreateBYOCIndex(ctx context.Context, in *CreateBYOCIndexRequest, opts ...CallOption) (*Index, error)
+
+	// Describe an index by name.
+	DescribeIndex(ctx context.Context, idxName string, opts ...CallOption) (*Index, error)
+
+	// Delete an index by name.
+	DeleteIndex(ctx context.Context, idxName string, opts ...CallOption) error
+
+	// Configure an existing index by name.
+	ConfigureIndex(ctx context.Context, name string, in ConfigureIndexParams, opts ...CallOption) (*Index, error)
+
+	// List all collections in the project.
+	ListCollections(ctx context.Context, opts ...CallOption) ([]*Collection, error)
+
+	// Describe a collection by name.
+	DescribeCollection(ctx context.Context, collectionName string, opts ...CallOption) (*Collection, error)
+
+	// Create a collection from a pod-based index.
+	CreateCollection(ctx context.Context, in *CreateCollectionRequest, opts ...CallOption) (*Collection, error)
+
+	// Delete a collection by name.
+	DeleteCollection(ctx context.Context, collectionName string, opts ...CallOption) error
+
+	// Create a backup of a serverless index.
+	CreateBackup(ctx context.Context, in *CreateBackupParams, opts ...CallOption) (*Backup, error)
+
+	// Create an index from a backup.
+	CreateIndexFromBackup(ctx context.Context, in *CreateIndexFromBackupParams, opts ...CallOption) (*CreateIndexFromBackupResponse, error)
+
+	// Describe a backup by ID.
+	DescribeBackup(ctx context.Context, backupId string, opts ...CallOption) (*Backup, error)
+
+	// List backups, optionally for a single index.
+	ListBackups(ctx context.Context, in *ListBackupsParams, opts ...CallOption) (*BackupList, error)
+
+	// Delete a backup by ID.
+	DeleteBackup(ctx context.Context, backupId string, opts ...CallOption) error
+
+	// Describe a restore job by ID.
+	DescribeRestoreJob(ctx context.Context, restoreJobId string, opts ...CallOption) (*RestoreJob, error)
+
+	// List restore jobs.
+	ListRestoreJobs(ctx context.Context, in *ListRestoreJobsParams, opts ...CallOption) (*RestoreJobList, error)
+}
+
+// [Inference] provides an interface for Pinecone's hosted models: the methods of [InferenceService].
+type Inference interface {
+	// Generate embeddings for a list of inputs.
+	Embed(ctx context.Context, in *EmbedRequest, opts ...CallOption) (*EmbedResponse, error)
+
+	// Generate embeddings for any number of inputs, in batches.
+	EmbedAll(ctx context.Context, in *EmbedAllRequest, opts ...CallOption) (*EmbedResponse, error)
+
+	// Rerank documents against a query.
+	Rerank(ctx context.Context, in *RerankRequest, opts ...CallOption) (*RerankResponse, error)
+
+	// Rerank any number of documents against a query, in windows.
+	RerankAll(ctx context.Context, in *RerankAllRequest, opts ...CallOption) (*RerankResponse, error)
+
+	// Describe a hosted model by name.
+	DescribeModel(ctx context.Context, modelName string, opts ...CallOption) (*ModelInfo, error)
+
+	// List the hosted models.
+	ListModels(ctx context.Context, in *ListModelsParams, opts ...CallOption) (*ModelInfoList, error)
+
+	// Validate parameters against a model's supported parameters, filling in defaults.
+	ValidateParameters(ctx context.Context, model string, params map[string]any) (map[string]any, error)
+}
+
+var (
+	_ VectorStore  = (*IndexConnection)(nil)
+	_ ControlPlane = (*Client)(nil)
+	_ Inference    = (*InferenceService)(nil)
+)
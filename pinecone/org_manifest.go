@@ -0,0 +1,842 @@
+package pinecone
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// [OrgManifest] describes the desired projects, service accounts, and invites of an organization. Read one with
+// [ParseOrgManifest], preview the changes needed to reach it with [AdminClient.PlanOrgManifest], and make them
+// with [AdminClient.ApplyOrgManifest].
+//
+// Projects and service accounts are matched to existing ones by name, and invites by email. Role bindings
+// reference projects by name, so a manifest can grant roles on the projects it creates.
+//
+// Example manifest:
+//
+//	projects:
+//	  - name: search-prod
+//	    max_pods: 4
+//	    force_encryption_with_cmek: true
+//	service_accounts:
+//	  - name: ingest-bot
+//	    role_bindings:
+//	      - role: DataPlaneEditor
+//	        project: search-prod
+//	invites:
+//	  - email: jane@example.com
+//	    role_bindings:
+//	      - role: OrgMember
+//	      - role: ProjectViewer
+//	        project: search-prod
+type OrgManifest struct {
+	// (Optional) The projects the organization should have.
+	Projects []ManifestProject `json:"projects,omitempty" yaml:"projects,omitempty"`
+
+	// (Optional) The service accounts the organization should have, with their role bindings.
+	ServiceAccounts []ManifestServiceAccount `json:"service_accounts,omitempty" yaml:"service_accounts,omitempty"`
+
+	// (Optional) The people who should belong to the organization, with their role bindings. People who are not
+	// yet members are invited; the role bindings of existing members and pending invites are reconciled.
+	Invites []ManifestInvite `json:"invites,omitempty" yaml:"invites,omitempty"`
+}
+
+// [ManifestProject] is a project in an [OrgManifest].
+type ManifestProject struct {
+	// The name of the project.
+	Name string `json:"name" yaml:"name"`
+
+	// (Optional) The maximum number of Pods that can be created in the project. If omitted, an existing
+	// project's limit is left unchanged and a new project gets the default.
+	MaxPods *int `json:"max_pods,omitempty" yaml:"max_pods,omitempty"`
+
+	// (Optional) Whether to force encryption with a customer-managed encryption key (CMEK). Once enabled,
+	// CMEK encryption cannot be disabled, so setting false for a project that has it enabled is an error.
+	ForceEncryptionWithCmek *bool `json:"force_encryption_with_cmek,omitempty" yaml:"force_encryption_with_cmek,omitempty"`
+}
+
+// [ManifestServiceAccount] is a service account in an [OrgManifest].
+type ManifestServiceAccount struct {
+	// The name of the service account.
+	Name string `json:"name" yaml:"name"`
+
+	// (Optional) The roles the service account should have.
+	RoleBindings []ManifestRoleBinding `json:"role_bindings,omitempty" yaml:"role_bindings,omitempty"`
+}
+
+// [ManifestInvite] is a person in an [OrgManifest].
+type ManifestInvite struct {
+	// The email address of the person.
+	Email string `json:"email" yaml:"email"`
+
+	// The roles the person should have. Must include an organization-scoped role, e.g. "OrgMember".
+	RoleBindings []ManifestRoleBinding `json:"role_bindings" yaml:"role_bindings"`
+}
+
+// [ManifestRoleBinding] is a role granted to a service account or person in an [OrgManifest]. It is resolved
+// to a [RoleBindingInput] once the project's ID is known.
+type ManifestRoleBinding struct {
+	// The role to grant, e.g. "OrgMember" or "ProjectEditor".
+	Role string `json:"role" yaml:"role"`
+
+	// (Optional) The name of the project the role applies to. Omit for an organization-scoped role.
+	Project string `json:"project,omitempty" yaml:"project,omitempty"`
+}
+
+// [ParseOrgManifest] parses a YAML or JSON [OrgManifest] and checks that it is consistent: names and emails
+// are unique, every role binding has a role, and every invite has an organization-scoped role. Unknown fields
+// are rejected, so that a misspelled field is not silently ignored.
+//
+// Parameters:
+//   - data: The YAML or JSON manifest.
+//
+// Returns a pointer to an [OrgManifest] or an error.
+//
+// Example:
+//
+//	data, err := os.ReadFile("org.yaml")
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	manifest, err := pinecone.ParseOrgManifest(data)
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+func ParseOrgManifest(data []byte) (*OrgManifest, error) {
+	manifest := &OrgManifest{}
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(manifest); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("failed to parse org manifest: %w", err)
+	}
+	if err := manifest.validate(); err != nil {
+		return nil, err
+	}
+	return manifest, nil
+}
+
+func (m *OrgManifest) validate() error {
+	projects := map[string]bool{}
+	for _, project := range m.Projects {
+		if project.Name == "" {
+			return fmt.Errorf("invalid org manifest: every project needs a name")
+		}
+		if projects[project.Name] {
+			return fmt.Errorf("invalid org manifest: project %q is listed more than once", project.Name)
+		}
+		projects[project.Name] = true
+	}
+
+	validateBindings := func(owner string, bindings []ManifestRoleBinding) error {
+		for _, binding := range bindings {
+			if binding.Role == "" {
+				return fmt.Errorf("invalid org manifest: a role binding of %s has no role", owner)
+			}
+		}
+		return nil
+	}
+	serviceAccounts := map[string]bool{}
+	for _, account := range m.ServiceAccounts {
+		if account.Name == "" {
+			return fmt.Errorf("invalid org manifest: every service account needs a name")
+		}
+		if serviceAccounts[account.Name] {
+			return fmt.Errorf("invalid org manifest: service account %q is listed more than once", account.Name)
+		}
+		serviceAccounts[account.Name] = true
+		if err := validateBindings(fmt.Sprintf("service account %q", account.Name), account.RoleBindings); err != nil {
+			return err
+		}
+	}
+	emails := map[string]bool{}
+	for _, invite := range m.Invites {
+		if invite.Email == "" {
+			return fmt.Errorf("invalid org manifest: every invite needs an email")
+		}
+		email := strings.ToLower(invite.Email)
+		if emails[email] {
+			return fmt.Errorf("invalid org manifest: invite %q is listed more than once", invite.Email)
+		}
+		emails[email] = true
+		if err := validateBindings(fmt.Sprintf("invite %q", invite.Email), invite.RoleBindings); err != nil {
+			return err
+		}
+		if !slices.ContainsFunc(invite.RoleBindings, func(b ManifestRoleBinding) bool { return b.Project == "" }) {
+			return fmt.Errorf("invalid org manifest: invite %q needs an organization-scoped role, e.g. OrgMember", invite.Email)
+		}
+	}
+	return nil
+}
+
+// [ServiceAccountSecretSink] receives the secret of each service account created by
+// [AdminClient.ApplyOrgManifest]. Secrets are returned only once, so they must be stored as they are created.
+type ServiceAccountSecretSink interface {
+	// StoreServiceAccountSecret stores the service account's secret. Apply stops with an error if it fails; the
+	// service account then exists without a stored secret, which can be replaced with
+	// [ServiceAccountClient] RotateSecret.
+	StoreServiceAccountSecret(ctx context.Context, account *ServiceAccountWithSecret) error
+}
+
+// [ServiceAccountSecretSinkFunc] adapts a function to a [ServiceAccountSecretSink].
+type ServiceAccountSecretSinkFunc func(ctx context.Context, account *ServiceAccountWithSecret) error
+
+// StoreServiceAccountSecret implements [ServiceAccountSecretSink].
+func (f ServiceAccountSecretSinkFunc) StoreServiceAccountSecret(ctx context.Context, account *ServiceAccountWithSecret) error {
+	return f(ctx, account)
+}
+
+// [ReconcileOrgManifestParams] contains parameters for planning or applying an [OrgManifest].
+type ReconcileOrgManifestParams struct {
+	// The desired state of the organization.
+	Manifest *OrgManifest
+
+	// (Optional) Whether to delete what the manifest does not list: projects, service accounts, pending or
+	// expired invites, and the role bindings of the service accounts and people it lists. Users are never
+	// removed from the organization. Without Prune, nothing is deleted.
+	//
+	// Pruning deletes the service account the [AdminClient] authenticates as unless the manifest lists it.
+	Prune bool
+
+	// (Optional) Receives the secrets of created service accounts. Required to apply a manifest that creates
+	// service accounts.
+	SecretSink ServiceAccountSecretSink
+}
+
+// [OrgChangeAction] is what an [OrgChange] does.
+type OrgChangeAction string
+
+const (
+	OrgChangeActionCreate OrgChangeAction = "create"
+	OrgChangeActionUpdate OrgChangeAction = "update"
+	OrgChangeActionResend OrgChangeAction = "resend"
+	OrgChangeActionDelete OrgChangeAction = "delete"
+)
+
+// [OrgResourceKind] is the kind of resource an [OrgChange] applies to.
+type OrgResourceKind string
+
+const (
+	OrgResourceKindProject        OrgResourceKind = "project"
+	OrgResourceKindServiceAccount OrgResourceKind = "service_account"
+	OrgResourceKindInvite         OrgResourceKind = "invite"
+	OrgResourceKindRoleBinding    OrgResourceKind = "role_binding"
+)
+
+// [OrgChange] is a change needed to reconcile an organization with an [OrgManifest].
+type OrgChange struct {
+	// What the change does.
+	Action OrgChangeAction `json:"action"`
+
+	// The kind of resource changed.
+	Kind OrgResourceKind `json:"kind"`
+
+	// The project or service account name, invite email, or a description of the role binding.
+	Name string `json:"name"`
+
+	// The ID of the resource, once it exists.
+	Id string `json:"id,omitempty"`
+
+	// (Optional) What is created or updated, e.g. "max_pods: 1 -> 4".
+	Detail string `json:"detail,omitempty"`
+
+	// Whether the change was made.
+	Applied bool `json:"applied"`
+}
+
+// [OrgPlan] lists the changes needed to reconcile an organization with an [OrgManifest], in the order they are
+// made: creates and updates first, then deletes, starting with role bindings and ending with projects.
+type OrgPlan struct {
+	Changes []OrgChange `json:"changes"`
+}
+
+// [OrgPlan.Empty] reports whether the organization already matches the manifest.
+func (p *OrgPlan) Empty() bool {
+	return len(p.Changes) == 0
+}
+
+// [OrgPlan.String] formats the plan with one change per line, e.g. "+ project search-prod (max_pods: 4)".
+func (p *OrgPlan) String() string {
+	if p.Empty() {
+		return "no changes"
+	}
+	symbols := map[OrgChangeAction]string{
+		OrgChangeActionCreate: "+",
+		OrgChangeActionUpdate: "~",
+		OrgChangeActionResend: "~",
+		OrgChangeActionDelete: "-",
+	}
+	var b strings.Builder
+	for _, change := range p.Changes {
+		fmt.Fprintf(&b, "%s %s %s", symbols[change.Action], change.Kind, change.Name)
+		if change.Action == OrgChangeActionResend {
+			b.WriteString(" (resend)")
+		}
+		if change.Detail != "" {
+			fmt.Fprintf(&b, " (%s)", change.Detail)
+		}
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+// Computes the changes needed to reconcile the organization with a manifest, without making them. Projects,
+// service accounts, invites, users, and role bindings are listed to compute the plan.
+//
+// Parameters:
+//   - ctx: The request context.
+//   - in: A pointer to [ReconcileOrgManifestParams] containing the manifest and whether to prune.
+//
+// Returns a pointer to an [OrgPlan] or an error.
+//
+// Example:
+//
+//	plan, err := adminClient.PlanOrgManifest(ctx, &pinecone.ReconcileOrgManifestParams{Manifest: manifest})
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	fmt.Print(plan)
+func (a *AdminClient) PlanOrgManifest(ctx context.Context, in *ReconcileOrgManifestParams) (*OrgPlan, error) {
+	if err := validateReconcileOrgManifestParams(in); err != nil {
+		return nil, err
+	}
+	r := &orgReconciler{admin: a, in: in}
+	if err := r.reconcile(ctx); err != nil {
+		return nil, err
+	}
+	return r.plan, nil
+}
+
+// Reconciles the organization with a manifest: creates missing projects, service accounts, invites, and role
+// bindings, updates projects whose settings differ, resends expired invites, and, only when Prune is set,
+// deletes what the manifest does not list.
+//
+// Apply is idempotent: applying the same manifest again makes no changes. The plan is computed and checked
+// before any change is made. If a change fails, the returned [OrgPlan] reports the changes made so far
+// alongside the error, and applying the manifest again continues from there.
+//
+// Parameters:
+//   - ctx: The request context.
+//   - in: A pointer to [ReconcileOrgManifestParams] containing the manifest, whether to prune, and where to
+//     store the secrets of created service accounts.
+//
+// Returns a pointer to the applied [OrgPlan] or an error.
+//
+// Example:
+//
+//	plan, err := adminClient.ApplyOrgManifest(ctx, &pinecone.ReconcileOrgManifestParams{
+//		Manifest: manifest,
+//		SecretSink: pinecone.ServiceAccountSecretSinkFunc(func(ctx context.Context, account *pinecone.ServiceAccountWithSecret) error {
+//			return vault.Write(ctx, "pinecone/"+account.ServiceAccount.Name, account.ClientSecret)
+//		}),
+//	})
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	fmt.Print(plan)
+func (a *AdminClient) ApplyOrgManifest(ctx context.Context, in *ReconcileOrgManifestParams) (*OrgPlan, error) {
+	plan, err := a.PlanOrgManifest(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+	if plan.Empty() {
+		return plan, nil
+	}
+	createsAccounts := slices.ContainsFunc(plan.Changes, func(c OrgChange) bool {
+		return c.Kind == OrgResourceKindServiceAccount && c.Action == OrgChangeActionCreate
+	})
+	if createsAccounts && in.SecretSink == nil {
+		return nil, fmt.Errorf("SecretSink is required to apply a manifest that creates service accounts")
+	}
+
+	r := &orgReconciler{admin: a, in: in, apply: true}
+	err = r.reconcile(ctx)
+	return r.plan, err
+}
+
+func validateReconcileOrgManifestParams(in *ReconcileOrgManifestParams) error {
+	if in == nil {
+		return fmt.Errorf("in (*ReconcileOrgManifestParams) cannot be nil")
+	}
+	if in.Manifest == nil {
+		return fmt.Errorf("Manifest is required to reconcile an organization")
+	}
+	return in.Manifest.validate()
+}
+
+// orgReconciler computes, and when apply is set makes, the changes reconciling an organization with a manifest.
+type orgReconciler struct {
+	admin *AdminClient
+	in    *ReconcileOrgManifestParams
+	apply bool
+	plan  *OrgPlan
+
+	// projectIds maps project names to IDs. Projects created in a plan map to "".
+	projectIds map[string]string
+	// projectNames maps project IDs to names, to describe existing role bindings.
+	projectNames map[string]string
+	// deletes are the prune changes, made after every create and update.
+	deletes []pendingOrgChange
+}
+
+type pendingOrgChange struct {
+	change OrgChange
+	run    func() (string, error)
+}
+
+func (r *orgReconciler) reconcile(ctx context.Context) error {
+	r.plan = &OrgPlan{}
+	r.projectIds = map[string]string{}
+	r.projectNames = map[string]string{}
+	steps := []func(context.Context) error{r.reconcileProjects, r.reconcileServiceAccounts, r.reconcileInvites}
+	for _, step := range steps {
+		if err := step(ctx); err != nil {
+			return err
+		}
+	}
+	// Delete dependents first: role bindings, then invites and service accounts, then projects.
+	order := []OrgResourceKind{OrgResourceKindRoleBinding, OrgResourceKindInvite, OrgResourceKindServiceAccount, OrgResourceKindProject}
+	slices.SortStableFunc(r.deletes, func(a, b pendingOrgChange) int {
+		return slices.Index(order, a.change.Kind) - slices.Index(order, b.change.Kind)
+	})
+	for _, pending := range r.deletes {
+		if err := r.change(pending.change, pending.run); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// change records a change and, when applying, makes it with run, which returns the ID of the resource.
+func (r *orgReconciler) change(change OrgChange, run func() (string, error)) error {
+	if r.apply {
+		id, err := run()
+		if err != nil {
+			return fmt.Errorf("failed to %s %s %q: %w", change.Action, change.Kind, change.Name, err)
+		}
+		if id != "" {
+			change.Id = id
+		}
+		change.Applied = true
+	}
+	r.plan.Changes = append(r.plan.Changes, change)
+	return nil
+}
+
+func (r *orgReconciler) reconcileProjects(ctx context.Context) error {
+	projects, err := r.admin.Project.List(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list projects: %w", err)
+	}
+	existing := map[string]*Project{}
+	for _, project := range projects {
+		if _, ok := existing[project.Name]; ok {
+			return fmt.Errorf("cannot reconcile projects: more than one project is named %q", project.Name)
+		}
+		existing[project.Name] = project
+		r.projectIds[project.Name] = project.Id
+		r.projectNames[project.Id] = project.Name
+	}
+
+	listed := map[string]bool{}
+	for _, desired := range r.in.Manifest.Projects {
+		listed[desired.Name] = true
+		project, ok := existing[desired.Name]
+		if !ok {
+			var detail []string
+			if desired.MaxPods != nil {
+				detail = append(detail, fmt.Sprintf("max_pods: %d", *desired.MaxPods))
+			}
+			if desired.ForceEncryptionWithCmek != nil {
+				detail = append(detail, fmt.Sprintf("force_encryption_with_cmek: %t", *desired.ForceEncryptionWithCmek))
+			}
+			r.projectIds[desired.Name] = ""
+			err := r.change(OrgChange{Action: OrgChangeActionCreate, Kind: OrgResourceKindProject, Name: desired.Name, Detail: strings.Join(detail, ", ")}, func() (string, error) {
+				created, err := r.admin.Project.Create(ctx, &CreateProjectParams{
+					Name:                    desired.Name,
+					MaxPods:                 desired.MaxPods,
+					ForceEncryptionWithCmek: desired.ForceEncryptionWithCmek,
+				})
+				if err != nil {
+					return "", err
+				}
+				r.projectIds[created.Name] = created.Id
+				r.projectNames[created.Id] = created.Name
+				return created.Id, nil
+			})
+			if err != nil {
+				return err
+			}
+			continue
+		}
+
+		update := &UpdateProjectParams{}
+		var detail []string
+		if desired.MaxPods != nil && *desired.MaxPods != project.MaxPods {
+			update.MaxPods = desired.MaxPods
+			detail = append(detail, fmt.Sprintf("max_pods: %d -> %d", project.MaxPods, *desired.MaxPods))
+		}
+		if desired.ForceEncryptionWithCmek != nil && *desired.ForceEncryptionWithCmek != project.ForceEncryptionWithCmek {
+			if project.ForceEncryptionWithCmek {
+				return fmt.Errorf("cannot reconcile project %q: CMEK encryption cannot be disabled once enabled", project.Name)
+			}
+			update.ForceEncryptionWithCmek = desired.ForceEncryptionWithCmek
+			detail = append(detail, "force_encryption_with_cmek: false -> true")
+		}
+		if len(detail) == 0 {
+			continue
+		}
+		err := r.change(OrgChange{Action: OrgChangeActionUpdate, Kind: OrgResourceKindProject, Name: project.Name, Id: project.Id, Detail: strings.Join(detail, ", ")}, func() (string, error) {
+			_, err := r.admin.Project.Update(ctx, project.Id, update)
+			return "", err
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	if r.in.Prune {
+		for _, project := range projects {
+			if listed[project.Name] {
+				continue
+			}
+			r.prune(OrgChange{Action: OrgChangeActionDelete, Kind: OrgResourceKindProject, Name: project.Name, Id: project.Id}, func() (string, error) {
+				return "", r.admin.Project.Delete(ctx, project.Id)
+			})
+		}
+	}
+	return nil
+}
+
+func (r *orgReconciler) reconcileServiceAccounts(ctx context.Context) error {
+	accounts, err := listAllPages(func(token *string) ([]*ServiceAccount, *Pagination, error) {
+		list, err := r.admin.ServiceAccount.List(ctx, &ListServiceAccountsParams{PaginationToken: token})
+		if err != nil {
+			return nil, nil, err
+		}
+		return list.Data, list.Pagination, nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to list service accounts: %w", err)
+	}
+	existing := map[string]*ServiceAccount{}
+	for _, account := range accounts {
+		if _, ok := existing[account.Name]; ok {
+			return fmt.Errorf("cannot reconcile service accounts: more than one service account is named %q", account.Name)
+		}
+		existing[account.Name] = account
+	}
+
+	listed := map[string]bool{}
+	for _, desired := range r.in.Manifest.ServiceAccounts {
+		listed[desired.Name] = true
+		if account, ok := existing[desired.Name]; ok {
+			err := r.reconcileRoleBindings(ctx, PrincipalTypeServiceAccount, account.Id, fmt.Sprintf("service account %s", account.Name), desired.RoleBindings)
+			if err != nil {
+				return err
+			}
+			continue
+		}
+
+		if err := r.checkProjects(desired.RoleBindings); err != nil {
+			return err
+		}
+		err := r.change(OrgChange{Action: OrgChangeActionCreate, Kind: OrgResourceKindServiceAccount, Name: desired.Name, Detail: describeBindings(desired.RoleBindings)}, func() (string, error) {
+			bindings, err := r.roleBindingInputs(desired.RoleBindings)
+			if err != nil {
+				return "", err
+			}
+			created, err := r.admin.ServiceAccount.Create(ctx, &CreateServiceAccountParams{Name: desired.Name, RoleBindings: bindings})
+			if err != nil {
+				return "", err
+			}
+			if err := r.in.SecretSink.StoreServiceAccountSecret(ctx, created); err != nil {
+				return created.ServiceAccount.Id, fmt.Errorf("service account %q was created but its secret could not be stored: %w", created.ServiceAccount.Id, err)
+			}
+			return created.ServiceAccount.Id, nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	if r.in.Prune {
+		for _, account := range accounts {
+			if listed[account.Name] {
+				continue
+			}
+			r.prune(OrgChange{Action: OrgChangeActionDelete, Kind: OrgResourceKindServiceAccount, Name: account.Name, Id: account.Id}, func() (string, error) {
+				return "", r.admin.ServiceAccount.Delete(ctx, account.Id)
+			})
+		}
+	}
+	return nil
+}
+
+func (r *orgReconciler) reconcileInvites(ctx context.Context) error {
+	invites, err := listAllPages(func(token *string) ([]*Invite, *Pagination, error) {
+		list, err := r.admin.Invite.List(ctx, &ListInvitesParams{PaginationToken: token})
+		if err != nil {
+			return nil, nil, err
+		}
+		return list.Data, list.Pagination, nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to list invites: %w", err)
+	}
+	existing := map[string]*Invite{}
+	for _, invite := range invites {
+		existing[strings.ToLower(invite.Email)] = invite
+	}
+	users := map[string]*User{}
+	if len(r.in.Manifest.Invites) > 0 {
+		list, err := listAllPages(func(token *string) ([]*User, *Pagination, error) {
+			list, err := r.admin.User.List(ctx, &ListUsersParams{PaginationToken: token})
+			if err != nil {
+				return nil, nil, err
+			}
+			return list.Data, list.Pagination, nil
+		})
+		if err != nil {
+			return fmt.Errorf("failed to list users: %w", err)
+		}
+		for _, user := range list {
+			users[strings.ToLower(user.Email)] = user
+		}
+	}
+
+	listed := map[string]bool{}
+	for _, desired := range r.in.Manifest.Invites {
+		email := strings.ToLower(desired.Email)
+		listed[email] = true
+
+		// People who accepted their invite are members: reconcile their roles instead of inviting them again.
+		if user, ok := users[email]; ok {
+			if err := r.reconcileRoleBindings(ctx, PrincipalTypeUser, user.Id, fmt.Sprintf("user %s", user.Email), desired.RoleBindings); err != nil {
+				return err
+			}
+			continue
+		}
+
+		invite, ok := existing[email]
+		if !ok {
+			if err := r.checkProjects(desired.RoleBindings); err != nil {
+				return err
+			}
+			err := r.change(OrgChange{Action: OrgChangeActionCreate, Kind: OrgResourceKindInvite, Name: desired.Email, Detail: describeBindings(desired.RoleBindings)}, func() (string, error) {
+				bindings, err := r.roleBindingInputs(desired.RoleBindings)
+				if err != nil {
+					return "", err
+				}
+				created, err := r.admin.Invite.Create(ctx, &CreateInviteParams{Email: desired.Email, RoleBindings: bindings})
+				if err != nil {
+					return "", err
+				}
+				return created.Id, nil
+			})
+			if err != nil {
+				return err
+			}
+			continue
+		}
+
+		if invite.Status == InviteStatusExpired {
+			err := r.change(OrgChange{Action: OrgChangeActionResend, Kind: OrgResourceKindInvite, Name: invite.Email, Id: invite.Id, Detail: "expired"}, func() (string, error) {
+				_, err := r.admin.Invite.Resend(ctx, invite.Id)
+				return "", err
+			})
+			if err != nil {
+				return err
+			}
+		}
+		if err := r.reconcileRoleBindings(ctx, PrincipalTypeInvite, invite.Id, fmt.Sprintf("invite %s", invite.Email), desired.RoleBindings); err != nil {
+			return err
+		}
+	}
+
+	if r.in.Prune {
+		for _, invite := range invites {
+			if listed[strings.ToLower(invite.Email)] {
+				continue
+			}
+			r.prune(OrgChange{Action: OrgChangeActionDelete, Kind: OrgResourceKindInvite, Name: invite.Email, Id: invite.Id}, func() (string, error) {
+				return "", r.admin.Invite.Delete(ctx, invite.Id)
+			})
+		}
+	}
+	return nil
+}
+
+// reconcileRoleBindings creates the desired role bindings a principal is missing and, when pruning, deletes
+// the ones it should not have. owner describes the principal in change names.
+func (r *orgReconciler) reconcileRoleBindings(ctx context.Context, principalType PrincipalType, principalId, owner string, desired []ManifestRoleBinding) error {
+	bindings, err := listAllPages(func(token *string) ([]*RoleBinding, *Pagination, error) {
+		list, err := r.admin.RoleBinding.List(ctx, &ListRoleBindingsParams{
+			PrincipalType:   &principalType,
+			PrincipalId:     &principalId,
+			PaginationToken: token,
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+		return list.Data, list.Pagination, nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to list role bindings of %s: %w", owner, err)
+	}
+	existing := map[string]bool{}
+	for _, binding := range bindings {
+		existing[roleBindingKey(binding.ResourceType, binding.ResourceId, binding.Role)] = true
+	}
+
+	wanted := map[string]bool{}
+	if err := r.checkProjects(desired); err != nil {
+		return err
+	}
+	for _, binding := range desired {
+		resourceType, resourceId := ResourceTypeOrganization, ""
+		if binding.Project != "" {
+			resourceType, resourceId = ResourceTypeProject, r.projectIds[binding.Project]
+		}
+		key := roleBindingKey(resourceType, resourceId, binding.Role)
+		wanted[key] = true
+		if existing[key] {
+			continue
+		}
+		err := r.change(OrgChange{Action: OrgChangeActionCreate, Kind: OrgResourceKindRoleBinding, Name: fmt.Sprintf("%s: %s", owner, describeBinding(binding))}, func() (string, error) {
+			input, err := r.roleBindingInput(binding)
+			if err != nil {
+				return "", err
+			}
+			created, err := r.admin.RoleBinding.Create(ctx, &CreateRoleBindingParams{
+				PrincipalId:   principalId,
+				PrincipalType: principalType,
+				ResourceType:  input.ResourceType,
+				Role:          input.Role,
+				ResourceId:    input.ResourceId,
+			})
+			if err != nil {
+				return "", err
+			}
+			return created.Id, nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	if r.in.Prune {
+		for _, binding := range bindings {
+			if wanted[roleBindingKey(binding.ResourceType, binding.ResourceId, binding.Role)] {
+				continue
+			}
+			described := ManifestRoleBinding{Role: binding.Role}
+			if binding.ResourceType == ResourceTypeProject {
+				described.Project = valueOrFallback(r.projectNames[binding.ResourceId], binding.ResourceId)
+			}
+			r.prune(OrgChange{Action: OrgChangeActionDelete, Kind: OrgResourceKindRoleBinding, Name: fmt.Sprintf("%s: %s", owner, describeBinding(described)), Id: binding.Id}, func() (string, error) {
+				return "", r.admin.RoleBinding.Delete(ctx, binding.Id)
+			})
+		}
+	}
+	return nil
+}
+
+// prune records a delete, made once every create and update is done.
+func (r *orgReconciler) prune(change OrgChange, run func() (string, error)) {
+	r.deletes = append(r.deletes, pendingOrgChange{change: change, run: run})
+}
+
+// checkProject returns an error if binding references a project that neither exists nor is in the manifest.
+func (r *orgReconciler) checkProject(binding ManifestRoleBinding) error {
+	if binding.Project == "" {
+		return nil
+	}
+	if _, ok := r.projectIds[binding.Project]; !ok {
+		return fmt.Errorf("cannot grant %s: project %q does not exist and is not in the manifest", binding.Role, binding.Project)
+	}
+	return nil
+}
+
+// roleBindingInput resolves binding's project name to its ID.
+func (r *orgReconciler) roleBindingInput(binding ManifestRoleBinding) (RoleBindingInput, error) {
+	if binding.Project == "" {
+		return RoleBindingInput{ResourceType: ResourceTypeOrganization, Role: binding.Role}, nil
+	}
+	projectId := r.projectIds[binding.Project]
+	if projectId == "" {
+		return RoleBindingInput{}, fmt.Errorf("cannot grant %s: project %q does not exist", binding.Role, binding.Project)
+	}
+	return RoleBindingInput{ResourceType: ResourceTypeProject, Role: binding.Role, ResourceId: &projectId}, nil
+}
+
+func (r *orgReconciler) roleBindingInputs(bindings []ManifestRoleBinding) ([]RoleBindingInput, error) {
+	inputs := make([]RoleBindingInput, 0, len(bindings))
+	for _, binding := range bindings {
+		input, err := r.roleBindingInput(binding)
+		if err != nil {
+			return nil, err
+		}
+		inputs = append(inputs, input)
+	}
+	return inputs, nil
+}
+
+// checkProjects returns an error if any of bindings references a project that neither exists nor is in the manifest.
+func (r *orgReconciler) checkProjects(bindings []ManifestRoleBinding) error {
+	for _, binding := range bindings {
+		if err := r.checkProject(binding); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// describeBindings describes the role bindings of a new service account or invite.
+func describeBindings(bindings []ManifestRoleBinding) string {
+	described := make([]string, 0, len(bindings))
+	for _, binding := range bindings {
+		described = append(described, describeBinding(binding))
+	}
+	return strings.Join(described, ", ")
+}
+
+func describeBinding(binding ManifestRoleBinding) string {
+	if binding.Project == "" {
+		return binding.Role + " on organization"
+	}
+	return fmt.Sprintf("%s on project %s", binding.Role, binding.Project)
+}
+
+// roleBindingKey identifies a role binding of a principal. Organization-scoped bindings are keyed without
+// their resource ID, which is the organization's.
+func roleBindingKey(resourceType ResourceType, resourceId, role string) string {
+	if resourceType == ResourceTypeOrganization {
+		resourceId = ""
+	}
+	return fmt.Sprintf("%s/%s/%s", resourceType, resourceId, role)
+}
+
+// listAllPages collects every page of a paginated admin list.
+func listAllPages[T any](list func(token *string) ([]T, *Pagination, error)) ([]T, error) {
+	var all []T
+	var token *string
+	for {
+		page, pagination, err := list(token)
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, page...)
+		if pagination == nil || pagination.Next == "" {
+			return all, nil
+		}
+		next := pagination.Next
+		token = &next
+	}
+}
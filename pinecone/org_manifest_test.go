@@ -0,0 +1,342 @@
+package pinecone
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeOrg is an in-memory organization behind the admin clients returned by adminClient. Service accounts
+// are listed one per page to exercise pagination.
+type fakeOrg struct {
+	projects        []*Project
+	serviceAccounts []*ServiceAccount
+	invites         []*Invite
+	users           []*User
+	bindings        []*RoleBinding
+	nextId          int
+	calls           []string
+}
+
+func (f *fakeOrg) id(prefix string) string {
+	f.nextId++
+	return fmt.Sprintf("%s-%d", prefix, f.nextId)
+}
+
+func (f *fakeOrg) adminClient() *AdminClient {
+	return &AdminClient{
+		Project:        fakeOrgProjects{f},
+		ServiceAccount: fakeOrgServiceAccounts{f},
+		Invite:         fakeOrgInvites{f},
+		User:           fakeOrgUsers{f},
+		RoleBinding:    fakeOrgRoleBindings{f},
+	}
+}
+
+func (f *fakeOrg) bind(principalType PrincipalType, principalId string, inputs []RoleBindingInput) {
+	for _, input := range inputs {
+		resourceId := "org"
+		if input.ResourceId != nil {
+			resourceId = *input.ResourceId
+		}
+		f.bindings = append(f.bindings, &RoleBinding{Id: f.id("binding"), PrincipalType: principalType, PrincipalId: principalId, ResourceType: input.ResourceType, ResourceId: resourceId, Role: input.Role})
+	}
+}
+
+type fakeOrgProjects struct{ *fakeOrg }
+
+func (f fakeOrgProjects) Create(ctx context.Context, in *CreateProjectParams) (*Project, error) {
+	f.calls = append(f.calls, "create project "+in.Name)
+	project := &Project{Id: f.id("project"), Name: in.Name, MaxPods: derefOrDefault(in.MaxPods, 0), ForceEncryptionWithCmek: derefOrDefault(in.ForceEncryptionWithCmek, false)}
+	f.projects = append(f.projects, project)
+	return project, nil
+}
+
+func (f fakeOrgProjects) Update(ctx context.Context, projectId string, in *UpdateProjectParams) (*Project, error) {
+	f.calls = append(f.calls, "update project "+projectId)
+	for _, project := range f.projects {
+		if project.Id == projectId {
+			project.MaxPods = derefOrDefault(in.MaxPods, project.MaxPods)
+			project.ForceEncryptionWithCmek = derefOrDefault(in.ForceEncryptionWithCmek, project.ForceEncryptionWithCmek)
+			return project, nil
+		}
+	}
+	return nil, fmt.Errorf("project %s not found", projectId)
+}
+
+func (f fakeOrgProjects) List(ctx context.Context) ([]*Project, error) {
+	return f.projects, nil
+}
+
+func (f fakeOrgProjects) Describe(ctx context.Context, projectId string) (*Project, error) {
+	return nil, fmt.Errorf("not implemented")
+}
+
+func (f fakeOrgProjects) Delete(ctx context.Context, projectId string) error {
+	f.calls = append(f.calls, "delete project "+projectId)
+	f.projects = deleteById(f.projects, projectId, func(p *Project) string { return p.Id })
+	return nil
+}
+
+type fakeOrgServiceAccounts struct{ *fakeOrg }
+
+func (f fakeOrgServiceAccounts) Create(ctx context.Context, in *CreateServiceAccountParams) (*ServiceAccountWithSecret, error) {
+	f.calls = append(f.calls, "create service_account "+in.Name)
+	account := &ServiceAccount{Id: f.id("sa"), Name: in.Name}
+	f.serviceAccounts = append(f.serviceAccounts, account)
+	f.bind(PrincipalTypeServiceAccount, account.Id, in.RoleBindings)
+	return &ServiceAccountWithSecret{ServiceAccount: *account, ClientSecret: "secret-" + account.Id}, nil
+}
+
+func (f fakeOrgServiceAccounts) Update(ctx context.Context, serviceAccountId string, in *UpdateServiceAccountParams) (*ServiceAccount, error) {
+	return nil, fmt.Errorf("not implemented")
+}
+
+func (f fakeOrgServiceAccounts) List(ctx context.Context, in *ListServiceAccountsParams) (*ServiceAccountList, error) {
+	start := 0
+	if in.PaginationToken != nil {
+		start, _ = strconv.Atoi(*in.PaginationToken)
+	}
+	list := &ServiceAccountList{Data: f.serviceAccounts[start:min(start+1, len(f.serviceAccounts))]}
+	if start+1 < len(f.serviceAccounts) {
+		list.Pagination = &Pagination{Next: strconv.Itoa(start + 1)}
+	}
+	return list, nil
+}
+
+func (f fakeOrgServiceAccounts) Describe(ctx context.Context, serviceAccountId string) (*ServiceAccount, error) {
+	return nil, fmt.Errorf("not implemented")
+}
+
+func (f fakeOrgServiceAccounts) RotateSecret(ctx context.Context, serviceAccountId string) (*ServiceAccountWithSecret, error) {
+	return nil, fmt.Errorf("not implemented")
+}
+
+func (f fakeOrgServiceAccounts) Delete(ctx context.Context, serviceAccountId string) error {
+	f.calls = append(f.calls, "delete service_account "+serviceAccountId)
+	f.serviceAccounts = deleteById(f.serviceAccounts, serviceAccountId, func(a *ServiceAccount) string { return a.Id })
+	return nil
+}
+
+type fakeOrgInvites struct{ *fakeOrg }
+
+func (f fakeOrgInvites) Create(ctx context.Context, in *CreateInviteParams) (*Invite, error) {
+	f.calls = append(f.calls, "create invite "+in.Email)
+	invite := &Invite{Id: f.id("invite"), Email: in.Email, Status: InviteStatusPending}
+	f.invites = append(f.invites, invite)
+	f.bind(PrincipalTypeInvite, invite.Id, in.RoleBindings)
+	return invite, nil
+}
+
+func (f fakeOrgInvites) List(ctx context.Context, in *ListInvitesParams) (*InviteList, error) {
+	return &InviteList{Data: f.invites}, nil
+}
+
+func (f fakeOrgInvites) Describe(ctx context.Context, inviteId string) (*Invite, error) {
+	return nil, fmt.Errorf("not implemented")
+}
+
+func (f fakeOrgInvites) Resend(ctx context.Context, inviteId string) (*Invite, error) {
+	f.calls = append(f.calls, "resend invite "+inviteId)
+	for _, invite := range f.invites {
+		if invite.Id == inviteId {
+			invite.Status = InviteStatusPending
+			return invite, nil
+		}
+	}
+	return nil, fmt.Errorf("invite %s not found", inviteId)
+}
+
+func (f fakeOrgInvites) Delete(ctx context.Context, inviteId string) error {
+	f.calls = append(f.calls, "delete invite "+inviteId)
+	f.invites = deleteById(f.invites, inviteId, func(i *Invite) string { return i.Id })
+	return nil
+}
+
+type fakeOrgUsers struct{ *fakeOrg }
+
+func (f fakeOrgUsers) List(ctx context.Context, in *ListUsersParams) (*UserList, error) {
+	return &UserList{Data: f.users}, nil
+}
+
+func (f fakeOrgUsers) Describe(ctx context.Context, userId string) (*User, error) {
+	return nil, fmt.Errorf("not implemented")
+}
+
+func (f fakeOrgUsers) Delete(ctx context.Context, userId string) error {
+	return fmt.Errorf("not implemented")
+}
+
+type fakeOrgRoleBindings struct{ *fakeOrg }
+
+func (f fakeOrgRoleBindings) Create(ctx context.Context, in *CreateRoleBindingParams) (*RoleBinding, error) {
+	f.calls = append(f.calls, fmt.Sprintf("create role_binding %s %s", in.PrincipalId, in.Role))
+	f.bind(in.PrincipalType, in.PrincipalId, []RoleBindingInput{{ResourceType: in.ResourceType, Role: in.Role, ResourceId: in.ResourceId}})
+	return f.bindings[len(f.bindings)-1], nil
+}
+
+func (f fakeOrgRoleBindings) List(ctx context.Context, in *ListRoleBindingsParams) (*RoleBindingList, error) {
+	list := &RoleBindingList{}
+	for _, binding := range f.bindings {
+		if in.PrincipalType == nil || binding.PrincipalType == *in.PrincipalType && binding.PrincipalId == *in.PrincipalId {
+			list.Data = append(list.Data, binding)
+		}
+	}
+	return list, nil
+}
+
+func (f fakeOrgRoleBindings) Describe(ctx context.Context, roleBindingId string) (*RoleBinding, error) {
+	return nil, fmt.Errorf("not implemented")
+}
+
+func (f fakeOrgRoleBindings) Delete(ctx context.Context, roleBindingId string) error {
+	f.calls = append(f.calls, "delete role_binding "+roleBindingId)
+	f.bindings = deleteById(f.bindings, roleBindingId, func(b *RoleBinding) string { return b.Id })
+	return nil
+}
+
+func deleteById[T any](items []T, id string, idOf func(T) string) []T {
+	var kept []T
+	for _, item := range items {
+		if idOf(item) != id {
+			kept = append(kept, item)
+		}
+	}
+	return kept
+}
+
+const testOrgManifest = `
+projects:
+  - name: search-prod
+    max_pods: 4
+  - name: search-dev
+service_accounts:
+  - name: ingest-bot
+    role_bindings:
+      - role: DataPlaneEditor
+        project: search-prod
+  - name: ci-bot
+    role_bindings:
+      - role: ProjectViewer
+        project: search-dev
+invites:
+  - email: jane@example.com
+    role_bindings:
+      - role: OrgMember
+  - email: Sam@example.com
+    role_bindings:
+      - role: OrgMember
+      - role: ProjectEditor
+        project: search-prod
+`
+
+// Unit tests:
+func TestParseOrgManifestUnit(t *testing.T) {
+	manifest, err := ParseOrgManifest([]byte(testOrgManifest))
+	require.NoError(t, err)
+	assert.Len(t, manifest.Projects, 2)
+	assert.Equal(t, 4, *manifest.Projects[0].MaxPods)
+	assert.Equal(t, []ManifestRoleBinding{{Role: "DataPlaneEditor", Project: "search-prod"}}, manifest.ServiceAccounts[0].RoleBindings)
+
+	manifest, err = ParseOrgManifest([]byte(`{"projects": [{"name": "a", "force_encryption_with_cmek": true}]}`))
+	require.NoError(t, err)
+	assert.True(t, *manifest.Projects[0].ForceEncryptionWithCmek)
+
+	_, err = ParseOrgManifest([]byte("projects:\n  - name: a\n    max_pod: 1\n"))
+	assert.ErrorContains(t, err, "max_pod", "unknown fields should be rejected")
+	_, err = ParseOrgManifest([]byte("projects:\n  - name: a\n  - name: a\n"))
+	assert.ErrorContains(t, err, "more than once")
+	_, err = ParseOrgManifest([]byte("invites:\n  - email: a@example.com\n    role_bindings:\n      - role: ProjectViewer\n        project: p\n"))
+	assert.ErrorContains(t, err, "organization-scoped role")
+}
+
+func TestApplyOrgManifestUnit(t *testing.T) {
+	ctx := context.Background()
+	org := &fakeOrg{
+		projects:        []*Project{{Id: "p-prod", Name: "search-prod", MaxPods: 1}, {Id: "p-old", Name: "legacy"}},
+		serviceAccounts: []*ServiceAccount{{Id: "sa-ingest", Name: "ingest-bot"}, {Id: "sa-old", Name: "old-bot"}},
+		invites:         []*Invite{{Id: "inv-jane", Email: "jane@example.com", Status: InviteStatusExpired}},
+		users:           []*User{{Id: "u-sam", Email: "sam@example.com"}},
+		bindings: []*RoleBinding{
+			{Id: "b-ingest", PrincipalType: PrincipalTypeServiceAccount, PrincipalId: "sa-ingest", ResourceType: ResourceTypeProject, ResourceId: "p-old", Role: "DataPlaneEditor"},
+			{Id: "b-jane", PrincipalType: PrincipalTypeInvite, PrincipalId: "inv-jane", ResourceType: ResourceTypeOrganization, ResourceId: "org", Role: "OrgMember"},
+			{Id: "b-sam", PrincipalType: PrincipalTypeUser, PrincipalId: "u-sam", ResourceType: ResourceTypeOrganization, ResourceId: "org", Role: "OrgMember"},
+		},
+	}
+	admin := org.adminClient()
+	manifest, err := ParseOrgManifest([]byte(testOrgManifest))
+	require.NoError(t, err)
+	var secrets []string
+	params := &ReconcileOrgManifestParams{
+		Manifest: manifest,
+		SecretSink: ServiceAccountSecretSinkFunc(func(ctx context.Context, account *ServiceAccountWithSecret) error {
+			secrets = append(secrets, account.ClientSecret)
+			return nil
+		}),
+	}
+
+	plan, err := admin.PlanOrgManifest(ctx, params)
+	require.NoError(t, err)
+	assert.Empty(t, org.calls, "planning should not change anything")
+	assert.Equal(t, "~ project search-prod (max_pods: 1 -> 4)\n"+
+		"+ project search-dev\n"+
+		"+ role_binding service account ingest-bot: DataPlaneEditor on project search-prod\n"+
+		"+ service_account ci-bot (ProjectViewer on project search-dev)\n"+
+		"~ invite jane@example.com (resend) (expired)\n"+
+		"+ role_binding user sam@example.com: ProjectEditor on project search-prod\n", plan.String())
+
+	applied, err := admin.ApplyOrgManifest(ctx, params)
+	require.NoError(t, err)
+	require.Len(t, applied.Changes, len(plan.Changes))
+	for _, change := range applied.Changes {
+		assert.True(t, change.Applied)
+	}
+	assert.Len(t, secrets, 1)
+	assert.Contains(t, org.calls, "create role_binding sa-ingest DataPlaneEditor")
+	assert.NotContains(t, org.calls, "create invite Sam@example.com", "members should not be invited again")
+	assert.Len(t, org.projects, 3, "nothing should be deleted without Prune")
+
+	plan, err = admin.ApplyOrgManifest(ctx, params)
+	require.NoError(t, err)
+	assert.True(t, plan.Empty(), "applying the same manifest again should change nothing, got:\n%s", plan)
+
+	params.Prune = true
+	plan, err = admin.ApplyOrgManifest(ctx, params)
+	require.NoError(t, err)
+	assert.Equal(t, "- role_binding service account ingest-bot: DataPlaneEditor on project legacy\n"+
+		"- service_account old-bot\n"+
+		"- project legacy\n", plan.String())
+	assert.Len(t, org.projects, 2)
+	assert.Len(t, org.serviceAccounts, 2)
+
+	plan, err = admin.PlanOrgManifest(ctx, params)
+	require.NoError(t, err)
+	assert.True(t, plan.Empty())
+}
+
+func TestApplyOrgManifestChecksBeforeChangingUnit(t *testing.T) {
+	ctx := context.Background()
+
+	org := &fakeOrg{projects: []*Project{{Id: "p", Name: "secure", ForceEncryptionWithCmek: true}}}
+	manifest, err := ParseOrgManifest([]byte("projects:\n  - name: new\n  - name: secure\n    force_encryption_with_cmek: false\n"))
+	require.NoError(t, err)
+	_, err = org.adminClient().ApplyOrgManifest(ctx, &ReconcileOrgManifestParams{Manifest: manifest})
+	assert.ErrorContains(t, err, "CMEK encryption cannot be disabled")
+	assert.Empty(t, org.calls)
+
+	org = &fakeOrg{}
+	manifest, err = ParseOrgManifest([]byte("projects:\n  - name: p\nservice_accounts:\n  - name: bot\n"))
+	require.NoError(t, err)
+	_, err = org.adminClient().ApplyOrgManifest(ctx, &ReconcileOrgManifestParams{Manifest: manifest})
+	assert.ErrorContains(t, err, "SecretSink is required")
+	assert.Empty(t, org.calls)
+
+	manifest, err = ParseOrgManifest([]byte("service_accounts:\n  - name: bot\n    role_bindings:\n      - role: ProjectViewer\n        project: missing\n"))
+	require.NoError(t, err)
+	_, err = org.adminClient().PlanOrgManifest(ctx, &ReconcileOrgManifestParams{Manifest: manifest})
+	assert.ErrorContains(t, err, `project "missing" does not exist`)
+}
@@ -0,0 +1,249 @@
+package pinecone
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+)
+
+// [ParameterViolation] describes a single parameter that does not satisfy a model's [SupportedParameter]
+// constraints.
+//
+// Fields:
+//   - Parameter: The name of the parameter.
+//   - Message: A description of the problem.
+type ParameterViolation struct {
+	Parameter string
+	Message   string
+}
+
+// [ParameterValidationError] is returned when parameters fail validation against a model's
+// [ModelInfo.SupportedParameters]. It lists every violation, not just the first one.
+//
+// Fields:
+//   - Model: The model the parameters were validated against.
+//   - Violations: The parameters that failed validation, sorted by parameter name.
+type ParameterValidationError struct {
+	Model      string
+	Violations []ParameterViolation
+}
+
+func (e *ParameterValidationError) Error() string {
+	msgs := make([]string, len(e.Violations))
+	for i, v := range e.Violations {
+		msgs[i] = fmt.Sprintf("%s: %s", v.Parameter, v.Message)
+	}
+	return fmt.Sprintf("invalid parameters for model %q: %s", e.Model, strings.Join(msgs, "; "))
+}
+
+// [InferenceService.ValidateParameters] checks params against the [ModelInfo.SupportedParameters] of model,
+// which is fetched through [InferenceService.DescribeModel] and cached. It checks that required parameters are
+// present, that every parameter is supported by the model, that values have the expected ValueType, that
+// "one_of" values are among the AllowedValues, and that "numeric_range" values are within [Min, Max].
+//
+// Validation runs automatically for [InferenceService.Embed], [InferenceService.Rerank],
+// [IndexConnection.SearchRecords] reranking, and [Client.CreateIndexForModel] when
+// [NewClientParams.ValidateParameters] is set.
+//
+// Parameters:
+//   - ctx: A context.Context object controls the request's lifetime, allowing for the request
+//     to be canceled or to timeout according to the context's deadline.
+//   - model: The name of the model.
+//   - params: The parameters to validate. params is not modified.
+//
+// Returns a copy of params with the Default of every missing optional parameter filled in, or a
+// [*ParameterValidationError] listing all violations. Errors from DescribeModel are returned as is.
+//
+// Example:
+//
+//	    params, err := pc.Inference.ValidateParameters(ctx, "multilingual-e5-large", map[string]any{"input_type": "query"})
+//	    var verr *pinecone.ParameterValidationError
+//	    if errors.As(err, &verr) {
+//		       for _, v := range verr.Violations {
+//			       fmt.Printf("%s: %s\n", v.Parameter, v.Message)
+//		       }
+//	    }
+func (i *InferenceService) ValidateParameters(ctx context.Context, model string, params map[string]any) (map[string]any, error) {
+	return i.validateParameters(ctx, model, params, true)
+}
+
+// validateParameters is [InferenceService.ValidateParameters]; checkRequired is false for index read and
+// write parameters, where Pinecone sets parameters like input_type itself.
+func (i *InferenceService) validateParameters(ctx context.Context, model string, params map[string]any, checkRequired bool) (map[string]any, error) {
+	info, err := i.describeModelCached(ctx, model)
+	if err != nil {
+		return nil, err
+	}
+	supported := derefOrDefault(info.SupportedParameters, nil)
+
+	out := make(map[string]any, len(params)+len(supported))
+	for key, value := range params {
+		out[key] = value
+	}
+
+	var violations []ParameterViolation
+	known := make(map[string]bool, len(supported))
+	for _, sp := range supported {
+		known[sp.Parameter] = true
+		value, ok := params[sp.Parameter]
+		if !ok || value == nil {
+			if sp.Default != nil {
+				out[sp.Parameter] = sp.Default.value()
+			} else if sp.Required && checkRequired {
+				violations = append(violations, ParameterViolation{sp.Parameter, "is required"})
+			}
+			continue
+		}
+		if msg := checkParameterValue(sp, value); msg != "" {
+			violations = append(violations, ParameterViolation{sp.Parameter, msg})
+		}
+	}
+	for key := range params {
+		if !known[key] {
+			violations = append(violations, ParameterViolation{key, "is not supported by the model"})
+		}
+	}
+
+	if len(violations) > 0 {
+		sort.Slice(violations, func(a, b int) bool { return violations[a].Parameter < violations[b].Parameter })
+		return nil, &ParameterValidationError{Model: model, Violations: violations}
+	}
+	return out, nil
+}
+
+// validateParametersPtr validates *params if params is non-nil, returning a pointer to the result.
+func (i *InferenceService) validateParametersPtr(ctx context.Context, model string, params *map[string]any, checkRequired bool) (*map[string]any, error) {
+	var in map[string]any
+	if params != nil {
+		in = *params
+	}
+	out, err := i.validateParameters(ctx, model, in, checkRequired)
+	if err != nil {
+		return nil, err
+	}
+	if len(out) == 0 {
+		return params, nil
+	}
+	return &out, nil
+}
+
+func checkParameterValue(sp SupportedParameter, value any) string {
+	switch sp.ValueType {
+	case "string":
+		if _, ok := value.(string); !ok {
+			return fmt.Sprintf("must be a string, got %T", value)
+		}
+	case "boolean":
+		if _, ok := value.(bool); !ok {
+			return fmt.Sprintf("must be a boolean, got %T", value)
+		}
+	case "integer":
+		n, ok := toFloat64(value)
+		if !ok || n != math.Trunc(n) {
+			return fmt.Sprintf("must be an integer, got %v", value)
+		}
+	case "float":
+		if _, ok := toFloat64(value); !ok {
+			return fmt.Sprintf("must be a number, got %T", value)
+		}
+	}
+
+	switch sp.Type {
+	case "one_of":
+		allowed := derefOrDefault(sp.AllowedValues, nil)
+		for _, a := range allowed {
+			if a.matches(value) {
+				return ""
+			}
+		}
+		names := make([]string, len(allowed))
+		for n, a := range allowed {
+			names[n] = fmt.Sprint(a.value())
+		}
+		return fmt.Sprintf("must be one of [%s], got %v", strings.Join(names, ", "), value)
+	case "numeric_range":
+		// The range is checked whatever the ValueType, so a value that is not a number is a type violation
+		// rather than being compared as 0.
+		number, ok := toFloat64(value)
+		if !ok {
+			return fmt.Sprintf("must be a number, got %T", value)
+		}
+		if sp.Min != nil && number < float64(*sp.Min) {
+			return fmt.Sprintf("must be at least %v, got %v", *sp.Min, value)
+		}
+		if sp.Max != nil && number > float64(*sp.Max) {
+			return fmt.Sprintf("must be at most %v, got %v", *sp.Max, value)
+		}
+	}
+	return ""
+}
+
+// toFloat64 converts a numeric parameter value, including a json.Number, to a float64.
+func toFloat64(value any) (float64, bool) {
+	switch v := value.(type) {
+	case int:
+		return float64(v), true
+	case int8:
+		return float64(v), true
+	case int16:
+		return float64(v), true
+	case int32:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	case uint:
+		return float64(v), true
+	case uint8:
+		return float64(v), true
+	case uint16:
+		return float64(v), true
+	case uint32:
+		return float64(v), true
+	case uint64:
+		return float64(v), true
+	case float32:
+		return float64(v), true
+	case float64:
+		return v, true
+	case json.Number:
+		n, err := v.Float64()
+		return n, err == nil
+	}
+	return 0, false
+}
+
+// value returns the value held by spv, or nil if it is empty.
+func (spv *SupportedParameterValue) value() any {
+	switch {
+	case spv.StringValue != nil:
+		return *spv.StringValue
+	case spv.IntValue != nil:
+		return *spv.IntValue
+	case spv.FloatValue != nil:
+		return *spv.FloatValue
+	case spv.BoolValue != nil:
+		return *spv.BoolValue
+	}
+	return nil
+}
+
+func (spv *SupportedParameterValue) matches(value any) bool {
+	switch {
+	case spv.StringValue != nil:
+		s, ok := value.(string)
+		return ok && s == *spv.StringValue
+	case spv.BoolValue != nil:
+		b, ok := value.(bool)
+		return ok && b == *spv.BoolValue
+	case spv.IntValue != nil:
+		n, ok := toFloat64(value)
+		return ok && n == float64(*spv.IntValue)
+	case spv.FloatValue != nil:
+		n, ok := toFloat64(value)
+		return ok && float32(n) == *spv.FloatValue
+	}
+	return false
+}
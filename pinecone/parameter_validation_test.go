@@ -0,0 +1,180 @@
+package pinecone
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const validationModelJson = `{
+	"model": "test-model",
+	"type": "embed",
+	"short_description": "test",
+	"supported_parameters": [
+		{"parameter": "input_type", "type": "one_of", "value_type": "string", "required": true, "allowed_values": ["query", "passage"]},
+		{"parameter": "truncate", "type": "one_of", "value_type": "string", "required": false, "default": "END", "allowed_values": ["END", "NONE"]},
+		{"parameter": "dimension", "type": "numeric_range", "value_type": "integer", "required": false, "min": 1, "max": 1024}
+	]
+}`
+
+// validationServer serves the test model description and records the body of every other request by path.
+type validationServer struct {
+	mu     sync.Mutex
+	bodies map[string][]map[string]any
+}
+
+func (s *validationServer) handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.Method == http.MethodGet {
+			_, _ = w.Write([]byte(validationModelJson))
+			return
+		}
+		b, _ := io.ReadAll(r.Body)
+		var body map[string]any
+		_ = json.Unmarshal(b, &body)
+		s.mu.Lock()
+		s.bodies[r.URL.Path] = append(s.bodies[r.URL.Path], body)
+		s.mu.Unlock()
+		switch r.URL.Path {
+		case "/embed":
+			_, _ = w.Write([]byte(`{"model":"test-model","vector_type":"dense","data":[{"vector_type":"dense","values":[1]}],"usage":{"total_tokens":1}}`))
+		case "/rerank":
+			_, _ = w.Write([]byte(`{"model":"test-model","data":[],"usage":{"rerank_units":1}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+}
+
+func newValidationClient(t *testing.T, validate bool) (*Client, *validationServer) {
+	t.Helper()
+	s := &validationServer{bodies: make(map[string][]map[string]any)}
+	srv := httptest.NewTLSServer(s.handler())
+	t.Cleanup(srv.Close)
+	pc, err := NewClient(NewClientParams{ApiKey: "test-key", Host: srv.URL, RestClient: srv.Client(), ValidateParameters: validate})
+	require.NoError(t, err)
+	return pc, s
+}
+
+// Unit tests:
+func TestValidateParametersUnit(t *testing.T) {
+	pc, _ := newValidationClient(t, false)
+	ctx := context.Background()
+
+	params, err := pc.Inference.ValidateParameters(ctx, "test-model", map[string]any{"input_type": "query", "dimension": 512.0})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{"input_type": "query", "dimension": 512.0, "truncate": "END"}, params, "defaults should be filled in")
+
+	_, err = pc.Inference.ValidateParameters(ctx, "test-model", map[string]any{
+		"truncate":  "MIDDLE",
+		"dimension": 2048,
+		"unknown":   true,
+	})
+	var verr *ParameterValidationError
+	require.ErrorAs(t, err, &verr)
+	assert.Equal(t, "test-model", verr.Model)
+	assert.Equal(t, []ParameterViolation{
+		{"dimension", "must be at most 1024, got 2048"},
+		{"input_type", "is required"},
+		{"truncate", "must be one of [END, NONE], got MIDDLE"},
+		{"unknown", "is not supported by the model"},
+	}, verr.Violations, "all violations should be reported at once")
+
+	_, err = pc.Inference.ValidateParameters(ctx, "test-model", map[string]any{"input_type": 1, "dimension": 1.5})
+	require.ErrorAs(t, err, &verr)
+	assert.Equal(t, []ParameterViolation{
+		{"dimension", "must be an integer, got 1.5"},
+		{"input_type", "must be a string, got int"},
+	}, verr.Violations)
+}
+
+func TestCheckParameterValueNumericRangeUnit(t *testing.T) {
+	min, max := float32(1), float32(1024)
+	rng := SupportedParameter{Parameter: "dimension", Type: "numeric_range", Min: &min, Max: &max}
+
+	assert.Empty(t, checkParameterValue(rng, uint(512)))
+	assert.Empty(t, checkParameterValue(rng, json.Number("512")))
+	assert.Equal(t, "must be at most 1024, got 2048", checkParameterValue(rng, uint64(2048)))
+	assert.Equal(t, "must be at most 1024, got 2048", checkParameterValue(rng, json.Number("2048")))
+	assert.Equal(t, "must be a number, got string", checkParameterValue(rng, "512"), "a non-numeric value should not be compared as 0")
+	assert.Equal(t, "must be a number, got json.Number", checkParameterValue(rng, json.Number("abc")))
+
+	rng.ValueType = "integer"
+	assert.Empty(t, checkParameterValue(rng, uint32(8)))
+	assert.Equal(t, "must be an integer, got 1.5", checkParameterValue(rng, json.Number("1.5")))
+}
+
+func TestEmbedAndRerankValidationUnit(t *testing.T) {
+	pc, s := newValidationClient(t, true)
+	ctx := context.Background()
+
+	_, err := pc.Inference.Embed(ctx, &EmbedRequest{Model: "test-model", TextInputs: []string{"a"}, Parameters: EmbedParameters{"truncate": "NONE"}})
+	var verr *ParameterValidationError
+	require.ErrorAs(t, err, &verr)
+	assert.Empty(t, s.bodies["/embed"], "invalid requests should not be sent")
+
+	_, err = pc.Inference.Embed(ctx, &EmbedRequest{Model: "test-model", TextInputs: []string{"a"}, Parameters: EmbedParameters{"input_type": "query"}})
+	require.NoError(t, err)
+	require.Len(t, s.bodies["/embed"], 1)
+	assert.Equal(t, map[string]any{"input_type": "query", "truncate": "END"}, s.bodies["/embed"][0]["parameters"])
+
+	_, err = pc.Inference.Rerank(ctx, &RerankRequest{Model: "test-model", Query: "q", Parameters: &map[string]any{"input_type": "other"}})
+	require.ErrorAs(t, err, &verr)
+	assert.Empty(t, s.bodies["/rerank"])
+}
+
+func TestValidationDisabledUnit(t *testing.T) {
+	pc, s := newValidationClient(t, false)
+	_, err := pc.Inference.Embed(context.Background(), &EmbedRequest{Model: "test-model", TextInputs: []string{"a"}, Parameters: EmbedParameters{"truncate": "MIDDLE"}})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{"truncate": "MIDDLE"}, s.bodies["/embed"][0]["parameters"], "parameters should be sent unchanged")
+}
+
+func TestCreateIndexForModelValidationUnit(t *testing.T) {
+	pc, s := newValidationClient(t, true)
+
+	_, err := pc.CreateIndexForModel(context.Background(), &CreateIndexForModelRequest{
+		Name:   "idx",
+		Cloud:  Aws,
+		Region: "us-east-1",
+		Embed: CreateIndexForModelEmbed{
+			Model:           "test-model",
+			FieldMap:        map[string]interface{}{"text": "chunk_text"},
+			ReadParameters:  &map[string]interface{}{"truncate": "END"},
+			WriteParameters: &map[string]interface{}{"truncate": "MIDDLE"},
+		},
+	})
+	require.ErrorContains(t, err, "invalid Embed.WriteParameters")
+	var verr *ParameterValidationError
+	require.ErrorAs(t, err, &verr)
+	assert.Equal(t, []ParameterViolation{{"truncate", "must be one of [END, NONE], got MIDDLE"}}, verr.Violations,
+		"input_type is set by Pinecone for index parameters, so it is not required")
+	assert.Empty(t, s.bodies)
+}
+
+func TestSearchRecordsRerankValidationUnit(t *testing.T) {
+	pc, s := newValidationClient(t, true)
+	idx, err := pc.Index(NewIndexConnParams{Host: "https://index.example.com", Transport: IndexTransportREST})
+	require.NoError(t, err)
+
+	_, err = idx.SearchRecords(context.Background(), &SearchRecordsRequest{
+		Query:  SearchRecordsQuery{TopK: 5, Inputs: &map[string]interface{}{"text": "q"}},
+		Rerank: &SearchRecordsRerank{Model: "test-model", RankFields: []string{"text"}, Parameters: &map[string]interface{}{"dimension": 0}},
+	})
+	var verr *ParameterValidationError
+	require.True(t, errors.As(err, &verr), err)
+	assert.Equal(t, []ParameterViolation{
+		{"dimension", "must be at least 1, got 0"},
+		{"input_type", "is required"},
+	}, verr.Violations)
+	assert.Empty(t, s.bodies)
+}
@@ -0,0 +1,247 @@
+package pinecone
+
+import (
+	"context"
+	"fmt"
+)
+
+// [QueryAndRerankRequest] holds the parameters for [IndexConnection.QueryAndRerank]. Set either Vector or Text.
+//
+// Fields:
+//   - Vector: (Optional) The query vector used to retrieve candidates.
+//   - SparseValues: (Optional) The sparse values of the query vector, if applicable.
+//   - Text: (Optional) A text query. If Vector is not set, Text is embedded with EmbedModel to retrieve
+//     candidates. Text is also the default rerank query.
+//   - EmbedModel: (Optional) The model used to embed Text. Required when Text is set and Vector is not.
+//   - EmbedParameters: (Optional) Parameters for embedding Text. Defaults to {"input_type": "query"}.
+//   - Query: (Optional) The query documents are reranked against. Defaults to Text; required when Text is not set.
+//   - TopK: (Required) The number of candidates to retrieve from the index before reranking.
+//   - MetadataFilter: (Optional) The filter to apply when retrieving candidates.
+//   - IncludeValues: (Optional) Whether to include the values of the vectors in the response.
+//   - RerankModel: (Required) The model used to rerank the candidates.
+//   - RankFields: (Optional) The metadata fields holding the text to rerank on. Defaults to ["text"].
+//   - TopN: (Optional) The number of reranked matches to return. Defaults to all candidates.
+//   - RerankParameters: (Optional) Additional model-specific parameters for the reranker.
+type QueryAndRerankRequest struct {
+	Vector           []float32
+	SparseValues     *SparseValues
+	Text             string
+	EmbedModel       string
+	EmbedParameters  EmbedParameters
+	Query            string
+	TopK             uint32
+	MetadataFilter   *MetadataFilter
+	IncludeValues    bool
+	RerankModel      string
+	RankFields       []string
+	TopN             *int
+	RerankParameters *map[string]interface{}
+}
+
+// [RerankedMatch] is a vector returned by [IndexConnection.QueryAndRerank].
+//
+// Fields:
+//   - Vector: The vector, including its metadata.
+//   - VectorScore: The similarity score from the index query.
+//   - RerankScore: The relevance score from the reranker, between 0 and 1. Matches are ordered by RerankScore.
+type RerankedMatch struct {
+	Vector      *Vector `json:"vector,omitempty"`
+	VectorScore float32 `json:"vector_score"`
+	RerankScore float32 `json:"rerank_score"`
+}
+
+// [QueryAndRerankUsage] is the combined usage of the requests made by [IndexConnection.QueryAndRerank].
+//
+// Fields:
+//   - ReadUnits: The read units consumed by the index query.
+//   - EmbedTokens: The tokens consumed by embedding the text query, or 0 if a vector was given.
+//   - RerankUnits: The rerank units consumed by reranking.
+type QueryAndRerankUsage struct {
+	ReadUnits   uint32 `json:"read_units"`
+	EmbedTokens int32  `json:"embed_tokens"`
+	RerankUnits int    `json:"rerank_units"`
+}
+
+// [QueryAndRerankResponse] is returned by [IndexConnection.QueryAndRerank].
+//
+// Fields:
+//   - Matches: The reranked matches, most relevant first.
+//   - Namespace: The namespace that was queried.
+//   - Usage: The combined usage of the query, embed, and rerank requests.
+type QueryAndRerankResponse struct {
+	Matches   []*RerankedMatch    `json:"matches,omitempty"`
+	Namespace string              `json:"namespace"`
+	Usage     QueryAndRerankUsage `json:"usage"`
+}
+
+// [IndexConnection.QueryAndRerank] retrieves candidates from a vector index and reranks them with a hosted
+// reranking model. It is the equivalent of [SearchRecordsRequest.Rerank] for indexes without integrated
+// inference. It embeds Text with [InferenceService.Embed] if no Vector is given, queries the index with
+// [IndexConnection.QueryByVectorValues] including metadata, and reranks the matches on the RankFields read from
+// their metadata with [InferenceService.RerankAll], so TopK may exceed the reranker's batch size.
+//
+// Parameters:
+//   - ctx: A context.Context object controls the request's lifetime, allowing for the request
+//     to be canceled or to timeout according to the context's deadline.
+//   - in: A pointer to a [QueryAndRerankRequest] object.
+//   - opts: (Optional) [CallOption] values that apply to this call only, e.g. [WithTimeout] or [WithHeader].
+//
+// Returns a pointer to a [QueryAndRerankResponse] object or an error.
+//
+// Example:
+//
+//	    ctx := context.Background()
+//
+//	    clientParams := pinecone.NewClientParams{
+//		       ApiKey:    "YOUR_API_KEY",
+//		       SourceTag: "your_source_identifier", // optional
+//	    }
+//
+//	    pc, err := pinecone.NewClient(clientParams)
+//	    if err != nil {
+//		       log.Fatalf("Failed to create Client: %v", err)
+//	    }
+//
+//	    idx, err := pc.DescribeIndex(ctx, "your-index-name")
+//	    if err != nil {
+//		       log.Fatalf("Failed to describe index \"%v\". Error:%s", idx.Name, err)
+//	    }
+//
+//	    idxConnection, err := pc.Index(pinecone.NewIndexConnParams{Host: idx.Host})
+//	    if err != nil {
+//		       log.Fatalf("Failed to create IndexConnection for Host: %v. Error: %v", idx.Host, err)
+//	    }
+//
+//	    topN := 5
+//	    res, err := idxConnection.QueryAndRerank(ctx, &pinecone.QueryAndRerankRequest{
+//		       Text:        "Disease prevention",
+//		       EmbedModel:  "multilingual-e5-large",
+//		       TopK:        100,
+//		       RerankModel: "bge-reranker-v2-m3",
+//		       RankFields:  []string{"chunk_text"},
+//		       TopN:        &topN,
+//	    })
+//	    if err != nil {
+//		       log.Fatalf("Failed to query and rerank: %v", err)
+//	    }
+//	    for _, match := range res.Matches {
+//		       fmt.Printf("%s: vector score %f, rerank score %f\n", match.Vector.Id, match.VectorScore, match.RerankScore)
+//	    }
+func (idx *IndexConnection) QueryAndRerank(ctx context.Context, in *QueryAndRerankRequest, opts ...CallOption) (*QueryAndRerankResponse, error) {
+	idx, ctx, cancel := idx.withCallOptions(ctx, opts)
+	defer cancel()
+
+	if in == nil {
+		return nil, fmt.Errorf("in (*QueryAndRerankRequest) cannot be nil")
+	}
+	if idx.inference == nil {
+		return nil, fmt.Errorf("QueryAndRerank requires an IndexConnection created with Client.Index")
+	}
+	if in.RerankModel == "" {
+		return nil, fmt.Errorf("RerankModel is required")
+	}
+	query := valueOrFallback(in.Query, in.Text)
+	if query == "" {
+		return nil, fmt.Errorf("one of Query or Text is required")
+	}
+	if in.Vector == nil && in.SparseValues == nil && (in.Text == "" || in.EmbedModel == "") {
+		return nil, fmt.Errorf("either Vector or both Text and EmbedModel are required")
+	}
+
+	// Inference calls record usage only to an accumulator attached to ctx, so attach the connection's.
+	if _, ok := ctx.Value(usageAccumulatorKey{}).(*UsageAccumulator); !ok && idx.usage != nil {
+		ctx = WithUsageAccumulator(ctx, idx.usage)
+	}
+
+	out := &QueryAndRerankResponse{}
+	queryReq := &QueryByVectorValuesRequest{
+		Vector:          in.Vector,
+		SparseValues:    in.SparseValues,
+		TopK:            in.TopK,
+		MetadataFilter:  in.MetadataFilter,
+		IncludeValues:   in.IncludeValues,
+		IncludeMetadata: true,
+	}
+	if in.Vector == nil && in.SparseValues == nil {
+		embedding, tokens, err := idx.embedQuery(ctx, in)
+		if err != nil {
+			return nil, err
+		}
+		out.Usage.EmbedTokens = tokens
+		if embedding.DenseEmbedding != nil {
+			queryReq.Vector = embedding.DenseEmbedding.Values
+		}
+		if embedding.SparseEmbedding != nil {
+			indices := make([]uint32, len(embedding.SparseEmbedding.SparseIndices))
+			for n, index := range embedding.SparseEmbedding.SparseIndices {
+				indices[n] = uint32(index)
+			}
+			queryReq.SparseValues = &SparseValues{Indices: indices, Values: embedding.SparseEmbedding.SparseValues}
+		}
+	}
+
+	queryRes, err := idx.QueryByVectorValues(ctx, queryReq)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query candidates: %w", err)
+	}
+	out.Namespace = queryRes.Namespace
+	if queryRes.Usage != nil {
+		out.Usage.ReadUnits = queryRes.Usage.ReadUnits
+	}
+	if len(queryRes.Matches) == 0 {
+		return out, nil
+	}
+
+	var rankFields *[]string
+	if len(in.RankFields) > 0 {
+		rankFields = &in.RankFields
+	}
+	returnDocuments := false
+	rerankRes, err := idx.inference.RerankAll(ctx, &RerankAllRequest{
+		Model:           in.RerankModel,
+		Query:           query,
+		Matches:         queryRes.Matches,
+		RankFields:      rankFields,
+		ReturnDocuments: &returnDocuments,
+		TopN:            in.TopN,
+		Parameters:      in.RerankParameters,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to rerank candidates: %w", err)
+	}
+	out.Usage.RerankUnits = derefOrDefault(rerankRes.Usage.RerankUnits, 0)
+
+	out.Matches = make([]*RerankedMatch, 0, len(rerankRes.Data))
+	for _, ranked := range rerankRes.Data {
+		if ranked.Index < 0 || ranked.Index >= len(queryRes.Matches) {
+			return nil, fmt.Errorf("reranker returned unknown document index %d", ranked.Index)
+		}
+		match := queryRes.Matches[ranked.Index]
+		out.Matches = append(out.Matches, &RerankedMatch{
+			Vector:      match.Vector,
+			VectorScore: match.Score,
+			RerankScore: ranked.Score,
+		})
+	}
+	return out, nil
+}
+
+// embedQuery embeds the text query of in, returning the embedding and the tokens used.
+func (idx *IndexConnection) embedQuery(ctx context.Context, in *QueryAndRerankRequest) (*Embedding, int32, error) {
+	params := in.EmbedParameters
+	if params == nil {
+		params = EmbedParameters{"input_type": "query"}
+	}
+	res, err := idx.inference.Embed(ctx, &EmbedRequest{
+		Model:      in.EmbedModel,
+		TextInputs: []string{in.Text},
+		Parameters: params,
+	})
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to embed query: %w", err)
+	}
+	if len(res.Data) != 1 {
+		return nil, 0, fmt.Errorf("failed to embed query: expected 1 embedding, got %d", len(res.Data))
+	}
+	return &res.Data[0], derefOrDefault(res.Usage.TotalTokens, 0), nil
+}
@@ -0,0 +1,121 @@
+package pinecone
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newQueryAndRerankConnection returns a REST IndexConnection whose index and inference requests are served by
+// s, with /query returning three matches whose "chunk_text" scores 10, 30, and 20 with [rerankServer], and
+// /embed returning the embedding [1, 2]. The body of the last query is stored in query.
+func newQueryAndRerankConnection(t *testing.T, s *rerankServer, query *map[string]any) *IndexConnection {
+	t.Helper()
+	mux := s.handler().(*http.ServeMux)
+	mux.HandleFunc("POST /query", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewDecoder(r.Body).Decode(query)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"namespace":"ns","usage":{"readUnits":5},"matches":[
+			{"id":"a","score":0.9,"metadata":{"chunk_text":"10"}},
+			{"id":"b","score":0.8,"metadata":{"chunk_text":"30"}},
+			{"id":"c","score":0.7,"metadata":{"chunk_text":"20"}}]}`))
+	})
+	mux.HandleFunc("POST /embed", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"model":"embedder","vector_type":"dense","data":[{"vector_type":"dense","values":[1,2]}],"usage":{"total_tokens":4}}`))
+	})
+	srv := httptest.NewTLSServer(mux)
+	t.Cleanup(srv.Close)
+
+	pc, err := NewClient(NewClientParams{ApiKey: "test-key", Host: srv.URL, RestClient: srv.Client()})
+	require.NoError(t, err)
+	idx, err := pc.Index(NewIndexConnParams{Host: srv.URL, Namespace: "ns", Transport: IndexTransportREST})
+	require.NoError(t, err)
+	return idx
+}
+
+// Unit tests:
+func TestQueryAndRerankUnit(t *testing.T) {
+	s := &rerankServer{maxBatchSize: 2}
+	var query map[string]any
+	idx := newQueryAndRerankConnection(t, s, &query)
+
+	topN := 2
+	res, err := idx.QueryAndRerank(context.Background(), &QueryAndRerankRequest{
+		Text:        "q",
+		EmbedModel:  "embedder",
+		TopK:        3,
+		RerankModel: "reranker",
+		RankFields:  []string{"chunk_text"},
+		TopN:        &topN,
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, []any{1.0, 2.0}, query["vector"], "the embedded text should be the query vector")
+	assert.Equal(t, true, query["includeMetadata"])
+
+	require.Len(t, res.Matches, 2)
+	assert.Equal(t, "b", res.Matches[0].Vector.Id)
+	assert.Equal(t, float32(0.8), res.Matches[0].VectorScore)
+	assert.Equal(t, float32(0.3), res.Matches[0].RerankScore)
+	assert.Equal(t, "c", res.Matches[1].Vector.Id)
+	assert.Equal(t, "ns", res.Namespace)
+	assert.Equal(t, QueryAndRerankUsage{ReadUnits: 5, EmbedTokens: 4, RerankUnits: 2}, res.Usage)
+	assert.Len(t, s.windows, 2, "candidates beyond the reranker's batch size should be windowed")
+}
+
+func TestQueryAndRerankUsageAccumulatorUnit(t *testing.T) {
+	s := &rerankServer{maxBatchSize: 2}
+	var query map[string]any
+	acc := NewUsageAccumulator()
+	idx := newQueryAndRerankConnection(t, s, &query).WithUsageAccumulator(acc)
+
+	_, err := idx.QueryAndRerank(context.Background(), &QueryAndRerankRequest{
+		Text:        "q",
+		EmbedModel:  "embedder",
+		TopK:        3,
+		RerankModel: "reranker",
+		RankFields:  []string{"chunk_text"},
+	})
+	require.NoError(t, err)
+
+	totals := map[string]UsageTotals{}
+	for _, entry := range acc.Snapshot() {
+		totals[entry.Operation] = entry.UsageTotals
+	}
+	assert.Equal(t, int64(5), totals["Query"].ReadUnits)
+	assert.Equal(t, int64(4), totals["Embed"].EmbedTokens, "embed usage should reach the connection's accumulator")
+	assert.Equal(t, int64(2), totals["Rerank"].RerankUnits, "rerank usage should reach the connection's accumulator")
+	assert.Equal(t, int64(2), totals["Rerank"].Requests)
+}
+
+func TestQueryAndRerankWithVectorUnit(t *testing.T) {
+	s := &rerankServer{maxBatchSize: 10}
+	var query map[string]any
+	idx := newQueryAndRerankConnection(t, s, &query)
+
+	res, err := idx.QueryAndRerank(context.Background(), &QueryAndRerankRequest{
+		Vector:      []float32{3},
+		Query:       "q",
+		TopK:        3,
+		RerankModel: "reranker",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []any{3.0}, query["vector"])
+	require.Len(t, s.windows, 1)
+	assert.Equal(t, Document{"id": "a", "text": ""}, s.windows[0][0], "RankFields should default to text")
+	assert.Len(t, res.Matches, 3)
+	assert.Zero(t, res.Usage.EmbedTokens)
+
+	_, err = idx.QueryAndRerank(context.Background(), &QueryAndRerankRequest{Vector: []float32{3}, TopK: 3, RerankModel: "reranker"})
+	require.ErrorContains(t, err, "Query or Text")
+	_, err = idx.QueryAndRerank(context.Background(), &QueryAndRerankRequest{Text: "q", TopK: 3, RerankModel: "reranker"})
+	require.ErrorContains(t, err, "EmbedModel")
+	_, err = idx.QueryAndRerank(context.Background(), &QueryAndRerankRequest{Vector: []float32{3}, Query: "q", TopK: 3})
+	require.ErrorContains(t, err, "RerankModel")
+}
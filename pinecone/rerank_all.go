@@ -0,0 +1,254 @@
+package pinecone
+
+import (
+	"context"
+	"fmt"
+	"sort"
+)
+
+// defaultRerankAllConcurrency is the number of windows [InferenceService.RerankAll] sends at once when
+// [RerankAllRequest.MaxConcurrency] is not set.
+const defaultRerankAllConcurrency = 4
+
+// [RerankAllRequest] holds the parameters for [InferenceService.RerankAll]. Set exactly one of Documents or Matches.
+//
+// Fields:
+//   - Model: (Required) The model to use for reranking.
+//   - Query: (Required) The query to rerank documents against.
+//   - Documents: (Optional) The documents to rerank. There is no limit on the number of documents.
+//   - Matches: (Optional) [ScoredVector] results, e.g. from [IndexConnection.QueryByVectorValues], to rerank in
+//     place of Documents. Each match becomes a document holding its "id" and the RankFields read from its
+//     metadata. Matches without metadata for a rank field get an empty string for it.
+//   - RankFields: (Optional) The fields to rank the documents by. Defaults to ["text"].
+//   - ReturnDocuments: (Optional) Whether to include the documents in the response. Defaults to true.
+//   - TopN: (Optional) How many documents to return across all windows. Defaults to all documents.
+//   - Parameters: (Optional) Additional model-specific parameters for the reranker.
+//   - WindowSize: (Optional) The number of documents sent per request. Defaults to, and may not exceed, the
+//     model's [ModelInfo.MaxBatchSize].
+//   - MaxConcurrency: (Optional) The maximum number of windows in flight at once. Defaults to 4.
+//   - BatchRetryPolicy: (Optional) A [RetryPolicy] controlling how a failed window is retried. Only that window
+//     is resent. Defaults to [DefaultRetryPolicy]; pass &RetryPolicy{} to disable window retries.
+type RerankAllRequest struct {
+	Model            string
+	Query            string
+	Documents        []Document
+	Matches          []*ScoredVector
+	RankFields       *[]string
+	ReturnDocuments  *bool
+	TopN             *int
+	Parameters       *map[string]interface{}
+	WindowSize       int
+	MaxConcurrency   int
+	BatchRetryPolicy *RetryPolicy
+}
+
+// [InferenceService.RerankAll] reranks any number of documents. It looks up the model's [ModelInfo] through
+// [InferenceService.DescribeModel] (caching it for later calls), splits the documents into windows of at most
+// [ModelInfo.MaxBatchSize], and reranks the windows concurrently through [InferenceService.Rerank]. The
+// results of all windows are merged by score, highest first, and cut to [RerankAllRequest.TopN].
+// [RankedDocument.Index] refers to the position of the document in [RerankAllRequest.Documents] (or
+// [RerankAllRequest.Matches]), and Usage.RerankUnits is summed across all windows.
+//
+// Scores from different windows are compared directly, which suits rerankers that score each document
+// independently of the others in its request, as Pinecone's hosted rerankers do.
+//
+// A window that fails with a rate limit, server, or network error is retried on its own according to
+// [RerankAllRequest.BatchRetryPolicy], unless the client's [RetryPolicy] already retried it, as described for
+// [InferenceService.EmbedAll]. If a window still fails, the remaining windows are cancelled and the error is
+// returned.
+//
+// Parameters:
+//   - ctx: A context.Context object controls the request's lifetime, allowing for the request
+//     to be canceled or to timeout according to the context's deadline.
+//   - in: A pointer to a [RerankAllRequest] object.
+//   - opts: (Optional) [CallOption] values that apply to each underlying request, e.g. [WithHeader].
+//     [WithTimeout] bounds the whole call.
+//
+// Returns a pointer to a [RerankResponse] object or an error.
+//
+// Example:
+//
+//	    ctx := context.Background()
+//
+//	    pc, err := pinecone.NewClient(pinecone.NewClientParams{
+//		       ApiKey: "YOUR_API_KEY",
+//	    })
+//	    if err != nil {
+//		       log.Fatalf("Failed to create Client: %v", err)
+//	    }
+//
+//	    queryRes, err := idxConnection.QueryByVectorValues(ctx, &pinecone.QueryByVectorValuesRequest{
+//		       Vector:          queryVector,
+//		       TopK:            1000,
+//		       IncludeMetadata: true,
+//	    })
+//	    if err != nil {
+//		       log.Fatalf("Failed to query: %v", err)
+//	    }
+//
+//	    topN := 10
+//	    res, err := pc.Inference.RerankAll(ctx, &pinecone.RerankAllRequest{
+//		       Model:      "bge-reranker-v2-m3",
+//		       Query:      "What is the capital of France?",
+//		       Matches:    queryRes.Matches,
+//		       RankFields: &[]string{"chunk_text"},
+//		       TopN:       &topN,
+//	    })
+//	    if err != nil {
+//		       log.Fatalf("Failed to rerank: %v", err)
+//	    }
+//	    for _, doc := range res.Data {
+//		       fmt.Printf("%s: %f\n", queryRes.Matches[doc.Index].Vector.Id, doc.Score)
+//	    }
+func (i *InferenceService) RerankAll(ctx context.Context, in *RerankAllRequest, opts ...CallOption) (*RerankResponse, error) {
+	if in == nil {
+		return nil, fmt.Errorf("in (*RerankAllRequest) cannot be nil")
+	}
+	if len(in.Documents) > 0 && len(in.Matches) > 0 {
+		return nil, fmt.Errorf("only one of Documents or Matches can be set")
+	}
+	if in.WindowSize < 0 || in.MaxConcurrency < 0 {
+		return nil, fmt.Errorf("WindowSize and MaxConcurrency cannot be negative")
+	}
+	if in.TopN != nil && *in.TopN < 1 {
+		return nil, fmt.Errorf("TopN must be at least 1")
+	}
+	documents := in.Documents
+	if len(in.Matches) > 0 {
+		documents = DocumentsFromMatches(in.Matches, derefOrDefault(in.RankFields, nil))
+	}
+	if len(documents) == 0 {
+		return nil, fmt.Errorf("Documents or Matches must contain at least one value")
+	}
+	retryPolicy := in.BatchRetryPolicy
+	if retryPolicy == nil {
+		retryPolicy = DefaultRetryPolicy()
+	}
+	if err := retryPolicy.validate(); err != nil {
+		return nil, err
+	}
+
+	// The timeout bounds the whole call, so it is applied here rather than to each window.
+	ctx, cancel := withCallOptions(ctx, opts)
+	defer cancel()
+
+	model, err := i.describeModelCached(ctx, in.Model)
+	if err != nil {
+		return nil, err
+	}
+	windowSize, err := rerankWindowSize(model, in.WindowSize, len(documents))
+	if err != nil {
+		return nil, err
+	}
+	concurrency := in.MaxConcurrency
+	if concurrency == 0 {
+		concurrency = defaultRerankAllConcurrency
+	}
+
+	windows := (len(documents) + windowSize - 1) / windowSize
+	responses := make([]*RerankResponse, windows)
+	err = forEachBatch(ctx, windows, concurrency, func(ctx context.Context, n int) error {
+		start := n * windowSize
+		end := min(start+windowSize, len(documents))
+		// Each window only needs to return as many documents as can make the global top N.
+		topN := end - start
+		if in.TopN != nil {
+			topN = min(topN, *in.TopN)
+		}
+		req := &RerankRequest{
+			Model:           in.Model,
+			Query:           in.Query,
+			Documents:       documents[start:end],
+			RankFields:      in.RankFields,
+			ReturnDocuments: in.ReturnDocuments,
+			TopN:            &topN,
+			Parameters:      in.Parameters,
+		}
+		res, err := retryBatch(ctx, retryPolicy, i.transportRetryPolicy(ctx), OperationRerank, func() (*RerankResponse, error) {
+			return i.Rerank(ctx, req)
+		})
+		if err != nil {
+			return fmt.Errorf("failed to rerank documents %d-%d: %w", start, end-1, err)
+		}
+		for d := range res.Data {
+			res.Data[d].Index += start
+		}
+		responses[n] = res
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	out := &RerankResponse{Data: make([]RankedDocument, 0, len(documents))}
+	var rerankUnits int
+	var hasUnits bool
+	for _, res := range responses {
+		out.Data = append(out.Data, res.Data...)
+		out.Model = res.Model
+		if res.Usage.RerankUnits != nil {
+			rerankUnits += *res.Usage.RerankUnits
+			hasUnits = true
+		}
+	}
+	sort.SliceStable(out.Data, func(a, b int) bool {
+		if out.Data[a].Score != out.Data[b].Score {
+			return out.Data[a].Score > out.Data[b].Score
+		}
+		return out.Data[a].Index < out.Data[b].Index
+	})
+	if in.TopN != nil && len(out.Data) > *in.TopN {
+		out.Data = out.Data[:*in.TopN]
+	}
+	if hasUnits {
+		out.Usage.RerankUnits = &rerankUnits
+	}
+	return out, nil
+}
+
+// [DocumentsFromMatches] converts query matches into [Document] values for reranking. Each document holds the
+// match's vector ID under "id" and, for every field in rankFields, the metadata value of that field as a
+// string. Missing fields are set to "". rankFields defaults to ["text"]. The documents are in the same order
+// as matches, so [RankedDocument.Index] can be used to look up the original match.
+func DocumentsFromMatches(matches []*ScoredVector, rankFields []string) []Document {
+	if len(rankFields) == 0 {
+		rankFields = []string{"text"}
+	}
+	documents := make([]Document, len(matches))
+	for n, match := range matches {
+		doc := Document{}
+		var metadata map[string]interface{}
+		if match != nil && match.Vector != nil {
+			doc["id"] = match.Vector.Id
+			if match.Vector.Metadata != nil {
+				metadata = match.Vector.Metadata.AsMap()
+			}
+		}
+		for _, field := range rankFields {
+			switch value := metadata[field].(type) {
+			case nil:
+				doc[field] = ""
+			case string:
+				doc[field] = value
+			default:
+				doc[field] = fmt.Sprint(value)
+			}
+		}
+		documents[n] = doc
+	}
+	return documents
+}
+
+func rerankWindowSize(model *ModelInfo, requested int, documents int) (int, error) {
+	maxBatchSize := int(derefOrDefault(model.MaxBatchSize, 0))
+	switch {
+	case requested > 0 && maxBatchSize > 0 && requested > maxBatchSize:
+		return 0, fmt.Errorf("WindowSize %d exceeds the maximum batch size of %d for model %q", requested, maxBatchSize, model.Model)
+	case requested > 0:
+		return requested, nil
+	case maxBatchSize > 0:
+		return maxBatchSize, nil
+	default:
+		return documents, nil
+	}
+}
@@ -0,0 +1,173 @@
+package pinecone
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// rerankServer serves /models/{model} and /rerank, scoring each document by the number in its first rank
+// field, divided by 100, and charging one rerank unit per request.
+type rerankServer struct {
+	maxBatchSize int
+	failOnce     bool
+
+	mu      sync.Mutex
+	windows [][]Document
+	topNs   []int
+}
+
+func (s *rerankServer) handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /models/{model}", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprintf(w, `{"model":%q,"type":"rerank","short_description":"test","max_batch_size":%d}`,
+			r.PathValue("model"), s.maxBatchSize)
+	})
+	mux.HandleFunc("POST /rerank", func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Model      string     `json:"model"`
+			Documents  []Document `json:"documents"`
+			RankFields []string   `json:"rank_fields"`
+			TopN       int        `json:"top_n"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		s.mu.Lock()
+		fail := s.failOnce
+		s.failOnce = false
+		s.windows = append(s.windows, body.Documents)
+		s.topNs = append(s.topNs, body.TopN)
+		s.mu.Unlock()
+		if fail {
+			w.WriteHeader(http.StatusTooManyRequests)
+			_, _ = w.Write([]byte(`{"error":{"code":"RESOURCE_EXHAUSTED","message":"slow down"}}`))
+			return
+		}
+
+		field := "text"
+		if len(body.RankFields) > 0 {
+			field = body.RankFields[0]
+		}
+		data := make([]map[string]any, len(body.Documents))
+		for i, doc := range body.Documents {
+			n, _ := strconv.Atoi(fmt.Sprint(doc[field]))
+			data[i] = map[string]any{"index": i, "score": float32(n) / 100, "document": doc}
+		}
+		sort.SliceStable(data, func(a, b int) bool { return data[a]["score"].(float32) > data[b]["score"].(float32) })
+		if body.TopN > 0 && body.TopN < len(data) {
+			data = data[:body.TopN]
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{"model": body.Model, "data": data, "usage": map[string]any{"rerank_units": 1}})
+	})
+	return mux
+}
+
+func newRerankAllClient(t *testing.T, s *rerankServer) *Client {
+	t.Helper()
+	srv := httptest.NewServer(s.handler())
+	t.Cleanup(srv.Close)
+	pc, err := NewClient(NewClientParams{ApiKey: "test-key", Host: srv.URL})
+	require.NoError(t, err)
+	return pc
+}
+
+// Unit tests:
+func TestRerankAllMergesWindowsUnit(t *testing.T) {
+	s := &rerankServer{maxBatchSize: 3}
+	pc := newRerankAllClient(t, s)
+
+	// Scores are deliberately not in input order, so the best documents are spread across windows.
+	scores := []int{5, 90, 10, 70, 20, 95, 30, 1}
+	documents := make([]Document, len(scores))
+	for i, score := range scores {
+		documents[i] = Document{"id": strconv.Itoa(i), "text": strconv.Itoa(score)}
+	}
+	topN := 3
+	res, err := pc.Inference.RerankAll(context.Background(), &RerankAllRequest{
+		Model:     "test-reranker",
+		Query:     "q",
+		Documents: documents,
+		TopN:      &topN,
+	})
+	require.NoError(t, err)
+
+	require.Len(t, res.Data, 3)
+	assert.Equal(t, []int{5, 1, 3}, []int{res.Data[0].Index, res.Data[1].Index, res.Data[2].Index},
+		"indexes should refer to the original input positions")
+	assert.Equal(t, float32(0.95), res.Data[0].Score)
+	require.NotNil(t, res.Data[0].Document)
+	assert.Equal(t, "5", (*res.Data[0].Document)["id"])
+	assert.Equal(t, "test-reranker", res.Model)
+	require.NotNil(t, res.Usage.RerankUnits)
+	assert.Equal(t, 3, *res.Usage.RerankUnits)
+
+	require.Len(t, s.windows, 3)
+	for i, window := range s.windows {
+		assert.LessOrEqual(t, len(window), 3)
+		assert.LessOrEqual(t, s.topNs[i], len(window), "windows should not ask for more than they hold")
+	}
+}
+
+func TestRerankAllMatchesUnit(t *testing.T) {
+	s := &rerankServer{maxBatchSize: 2, failOnce: true}
+	pc := newRerankAllClient(t, s)
+
+	matches := make([]*ScoredVector, 3)
+	for i, score := range []int{10, 30, 20} {
+		metadata, err := structpb.NewStruct(map[string]any{"chunk_text": strconv.Itoa(score), "other": "x"})
+		require.NoError(t, err)
+		matches[i] = &ScoredVector{Vector: &Vector{Id: fmt.Sprintf("v%d", i), Metadata: metadata}}
+	}
+	matches = append(matches, &ScoredVector{Vector: &Vector{Id: "no-metadata"}})
+
+	res, err := pc.Inference.RerankAll(context.Background(), &RerankAllRequest{
+		Model:            "test-reranker",
+		Query:            "q",
+		Matches:          matches,
+		RankFields:       &[]string{"chunk_text"},
+		MaxConcurrency:   1,
+		BatchRetryPolicy: fastPolicy(1),
+	})
+	require.NoError(t, err)
+
+	require.Len(t, res.Data, 4)
+	ids := make([]string, len(res.Data))
+	for i, doc := range res.Data {
+		ids[i] = matches[doc.Index].Vector.Id
+	}
+	assert.Equal(t, []string{"v1", "v2", "v0", "no-metadata"}, ids)
+	require.Len(t, s.windows, 3, "the rate limited window should be retried on its own")
+	assert.Equal(t, s.windows[0], s.windows[1])
+	assert.Equal(t, Document{"id": "v0", "chunk_text": "10"}, s.windows[1][0], "only the id and rank fields should be sent")
+}
+
+func TestRerankAllValidationUnit(t *testing.T) {
+	s := &rerankServer{maxBatchSize: 2}
+	pc := newRerankAllClient(t, s)
+	ctx := context.Background()
+
+	_, err := pc.Inference.RerankAll(ctx, nil)
+	require.Error(t, err)
+	_, err = pc.Inference.RerankAll(ctx, &RerankAllRequest{Model: "m", Query: "q"})
+	require.ErrorContains(t, err, "at least one value")
+	_, err = pc.Inference.RerankAll(ctx, &RerankAllRequest{Model: "m", Query: "q", Documents: []Document{{}}, Matches: []*ScoredVector{{}}})
+	require.ErrorContains(t, err, "only one of")
+	_, err = pc.Inference.RerankAll(ctx, &RerankAllRequest{Model: "m", Query: "q", Documents: []Document{{}}, WindowSize: 3})
+	require.ErrorContains(t, err, "exceeds the maximum batch size")
+	assert.Empty(t, s.windows)
+}
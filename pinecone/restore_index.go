@@ -0,0 +1,214 @@
+package pinecone
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// [RestoreIndexAndWaitParams] contains the parameters for [Client.RestoreIndexAndWait].
+//
+// Fields:
+//   - BackupId: (Required) The unique identifier of the backup to restore from.
+//   - Name: (Required) The name of the index to create.
+//   - DeletionProtection: (Optional) Deletion protection for the new index.
+//   - Tags: (Optional) Custom user tags added to the new index.
+//   - OnProgress: (Optional) Called with the [RestoreJob] whenever its status or percent complete changes.
+//   - Timeout: (Optional) How long to wait for the restore job, the index, and the verification to finish.
+//     Default is 30 minutes.
+//   - VerifyRecordCounts: (Optional) Whether to compare the record and namespace counts of the new index with
+//     those of the backup once the index is ready.
+//   - Transport: (Optional) The [IndexTransport] used to describe the new index's statistics. Defaults to
+//     [IndexTransportGRPC].
+type RestoreIndexAndWaitParams struct {
+	BackupId           string
+	Name               string
+	DeletionProtection *DeletionProtection
+	Tags               *IndexTags
+	OnProgress         func(job *RestoreJob)
+	Timeout            time.Duration
+	VerifyRecordCounts bool
+	Transport          IndexTransport
+}
+
+// [RestoredIndex] is the result of [Client.RestoreIndexAndWait].
+//
+// Fields:
+//   - Index: The restored [Index], ready to serve requests.
+//   - RestoreJob: The completed [RestoreJob].
+//   - Verification: The record and namespace counts compared, if VerifyRecordCounts was set.
+type RestoredIndex struct {
+	Index        *Index               `json:"index"`
+	RestoreJob   *RestoreJob          `json:"restore_job"`
+	Verification *RestoreVerification `json:"verification,omitempty"`
+}
+
+// [RestoreVerification] compares the counts reported by a [Backup] with those of the index restored from it.
+// Counts the backup does not report are nil and are not compared.
+//
+// Fields:
+//   - ExpectedRecordCount: The number of records in the backup.
+//   - RecordCount: The number of records in the restored index.
+//   - ExpectedNamespaceCount: The number of namespaces in the backup.
+//   - NamespaceCount: The number of namespaces in the restored index.
+type RestoreVerification struct {
+	ExpectedRecordCount    *int `json:"expected_record_count,omitempty"`
+	RecordCount            int  `json:"record_count"`
+	ExpectedNamespaceCount *int `json:"expected_namespace_count,omitempty"`
+	NamespaceCount         int  `json:"namespace_count"`
+}
+
+// Matches reports whether the restored index has the counts of the backup.
+func (v *RestoreVerification) Matches() bool {
+	return (v.ExpectedRecordCount == nil || *v.ExpectedRecordCount == v.RecordCount) &&
+		(v.ExpectedNamespaceCount == nil || *v.ExpectedNamespaceCount == v.NamespaceCount)
+}
+
+// [Client.RestoreIndexAndWait] creates an index from a backup and waits until it is ready. It follows the
+// [RestoreJob] until it completes, reporting its progress to OnProgress, then waits for the new index to be
+// ready. With VerifyRecordCounts, it also waits until the index's statistics match the record and namespace
+// counts of the [Backup], since statistics can lag behind a completed restore.
+//
+// Parameters:
+//   - ctx: A context.Context object controls the request's lifetime, allowing for the request
+//     to be canceled or to timeout according to the context's deadline.
+//   - in: A pointer to a [RestoreIndexAndWaitParams] object.
+//   - opts: (Optional) [CallOption] values that apply to each underlying request, e.g. [WithHeader].
+//     [WithTimeout] bounds the whole call. [WithNamespace] has no effect.
+//
+// Returns a pointer to a [RestoredIndex] or an error. If the restore completes but the counts never match, the
+// [RestoredIndex] is returned alongside the error.
+//
+// Example:
+//
+//	ctx := context.Background()
+//
+//	pc, err := pinecone.NewClient(pinecone.NewClientParams{
+//		ApiKey: "YOUR_API_KEY",
+//	})
+//	if err != nil {
+//		log.Fatalf("Failed to create Client: %v", err)
+//	}
+//
+//	restored, err := pc.RestoreIndexAndWait(ctx, &pinecone.RestoreIndexAndWaitParams{
+//		BackupId:           "my-backup-id",
+//		Name:               "my-index-restored",
+//		VerifyRecordCounts: true,
+//		OnProgress: func(job *pinecone.RestoreJob) {
+//			log.Printf("restore %s: %s", job.RestoreJobId, job.Status)
+//		},
+//	})
+//	if err != nil {
+//		log.Fatalf("Failed to restore index: %v", err)
+//	}
+//	fmt.Printf("Index %s is ready at %s\n", restored.Index.Name, restored.Index.Host)
+func (c *Client) RestoreIndexAndWait(ctx context.Context, in *RestoreIndexAndWaitParams, opts ...CallOption) (*RestoredIndex, error) {
+	if in == nil {
+		return nil, fmt.Errorf("in (*RestoreIndexAndWaitParams) cannot be nil")
+	}
+	if in.BackupId == "" || in.Name == "" {
+		return nil, fmt.Errorf("BackupId and Name are required to restore an index")
+	}
+	ctx, cancelOpts := withClientCallOptions(ctx, opts)
+	defer cancelOpts()
+	timeout := valueOrFallback(in.Timeout, 30*time.Minute)
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	var backup *Backup
+	if in.VerifyRecordCounts {
+		var err error
+		if backup, err = c.DescribeBackup(ctx, in.BackupId); err != nil {
+			return nil, err
+		}
+	}
+
+	started, err := c.CreateIndexFromBackup(ctx, &CreateIndexFromBackupParams{
+		BackupId:           in.BackupId,
+		Name:               in.Name,
+		DeletionProtection: in.DeletionProtection,
+		Tags:               in.Tags,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	job, err := c.waitForRestoreJob(ctx, started.RestoreJobId, in.OnProgress)
+	if err != nil {
+		return nil, err
+	}
+	// The restore job has already waited out most of the timeout; the index gets whatever remains.
+	idx, err := waitForIndexReady(ctx, c, in.Name, timeout)
+	if err != nil {
+		return nil, err
+	}
+	restored := &RestoredIndex{Index: idx, RestoreJob: job}
+	if !in.VerifyRecordCounts {
+		return restored, nil
+	}
+
+	conn, err := c.Index(NewIndexConnParams{Host: idx.Host, Transport: in.Transport})
+	if err != nil {
+		return restored, err
+	}
+	defer conn.Close()
+	for {
+		stats, err := conn.DescribeIndexStats(ctx)
+		if err != nil {
+			if restored.Verification != nil && ctx.Err() != nil {
+				break
+			}
+			return restored, err
+		}
+		restored.Verification = &RestoreVerification{
+			ExpectedRecordCount:    backup.RecordCount,
+			RecordCount:            int(stats.TotalVectorCount),
+			ExpectedNamespaceCount: backup.NamespaceCount,
+			NamespaceCount:         len(stats.Namespaces),
+		}
+		if restored.Verification.Matches() {
+			return restored, nil
+		}
+		if !wait(ctx, indexReadyPollInterval) {
+			break
+		}
+	}
+	v := restored.Verification
+	return restored, fmt.Errorf("index %q has %d records in %d namespaces, but backup %q has %s records in %s namespaces",
+		in.Name, v.RecordCount, v.NamespaceCount, in.BackupId, countOrUnknown(v.ExpectedRecordCount), countOrUnknown(v.ExpectedNamespaceCount))
+}
+
+// waitForRestoreJob polls a restore job until it completes, calling onProgress when it changes.
+func (c *Client) waitForRestoreJob(ctx context.Context, restoreJobId string, onProgress func(*RestoreJob)) (*RestoreJob, error) {
+	var lastStatus string
+	var lastPercent float32 = -1
+	for {
+		job, err := c.DescribeRestoreJob(ctx, restoreJobId)
+		if err != nil {
+			return nil, err
+		}
+		percent := derefOrDefault(job.PercentComplete, 0)
+		if onProgress != nil && (job.Status != lastStatus || percent != lastPercent) {
+			onProgress(job)
+		}
+		lastStatus, lastPercent = job.Status, percent
+
+		switch strings.ToLower(job.Status) {
+		case "completed":
+			return job, nil
+		case "failed", "cancelled", "canceled":
+			return job, fmt.Errorf("restore job %q for index %q ended with status %s", restoreJobId, job.TargetIndexName, job.Status)
+		}
+		if !wait(ctx, indexReadyPollInterval) {
+			return job, fmt.Errorf("restore job %q did not complete: %w", restoreJobId, ctx.Err())
+		}
+	}
+}
+
+func countOrUnknown(count *int) string {
+	if count == nil {
+		return "an unknown number of"
+	}
+	return fmt.Sprint(*count)
+}
@@ -0,0 +1,83 @@
+package pinecone
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Unit tests:
+func TestRestoreIndexAndWaitUnit(t *testing.T) {
+	previous := indexReadyPollInterval
+	indexReadyPollInterval = time.Millisecond
+	defer func() { indexReadyPollInterval = previous }()
+
+	server := newFakeControlPlane(t)
+	server.addBackups(map[string]any{"backup_id": "backup-1", "source_index_name": "docs", "source_index_id": "id", "status": "Ready", "cloud": "aws", "region": "us-east-1", "record_count": 10, "namespace_count": 2})
+	server.fillNamespace("a", 4)
+	server.fillNamespace("b", 6)
+	server.restoreProgress = []map[string]any{
+		{"status": "Pending"},
+		{"status": "InProgress", "percent_complete": 40},
+		{"status": "InProgress", "percent_complete": 40},
+		{"status": "InProgress", "percent_complete": 90},
+		{"status": "Completed", "percent_complete": 100},
+	}
+	pc := server.client(t)
+
+	var progress []string
+	restored, err := pc.RestoreIndexAndWait(context.Background(), &RestoreIndexAndWaitParams{
+		BackupId:           "backup-1",
+		Name:               "docs-restored",
+		VerifyRecordCounts: true,
+		Transport:          IndexTransportREST,
+		OnProgress: func(job *RestoreJob) {
+			progress = append(progress, job.Status+" "+formatPercent(job.PercentComplete))
+		},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Pending 0", "InProgress 40", "InProgress 90", "Completed 100"}, progress)
+	assert.Equal(t, "docs-restored", restored.Index.Name)
+	assert.Equal(t, "Completed", restored.RestoreJob.Status)
+	require.NotNil(t, restored.Verification)
+	assert.True(t, restored.Verification.Matches())
+	assert.Equal(t, 10, restored.Verification.RecordCount)
+	assert.Equal(t, 2, restored.Verification.NamespaceCount)
+
+	server.fillNamespace("b", 3)
+	restored, err = pc.RestoreIndexAndWait(context.Background(), &RestoreIndexAndWaitParams{
+		BackupId:           "backup-1",
+		Name:               "docs-restored",
+		VerifyRecordCounts: true,
+		Transport:          IndexTransportREST,
+		Timeout:            50 * time.Millisecond,
+	})
+	assert.ErrorContains(t, err, `index "docs-restored" has 7 records in 2 namespaces, but backup "backup-1" has 10 records in 2 namespaces`)
+	require.NotNil(t, restored)
+	assert.False(t, restored.Verification.Matches())
+}
+
+func TestRestoreIndexAndWaitFailedJobUnit(t *testing.T) {
+	previous := indexReadyPollInterval
+	indexReadyPollInterval = time.Millisecond
+	defer func() { indexReadyPollInterval = previous }()
+
+	server := newFakeControlPlane(t)
+	server.addBackups(fakeBackup("backup-1", "", "Ready", time.Now()))
+	server.restoreProgress = []map[string]any{{"status": "Pending"}, {"status": "Failed"}}
+	_, err := server.client(t).RestoreIndexAndWait(context.Background(), &RestoreIndexAndWaitParams{BackupId: "backup-1", Name: "docs-restored"})
+	assert.ErrorContains(t, err, `restore job "restore-1" for index "docs-restored" ended with status Failed`)
+
+	_, err = server.client(t).RestoreIndexAndWait(context.Background(), nil)
+	assert.ErrorContains(t, err, "cannot be nil")
+	_, err = server.client(t).RestoreIndexAndWait(context.Background(), &RestoreIndexAndWaitParams{Name: "docs-restored"})
+	assert.ErrorContains(t, err, "BackupId and Name are required")
+}
+
+func formatPercent(percent *float32) string {
+	return fmt.Sprint(derefOrDefault(percent, 0))
+}
@@ -3,35 +3,135 @@ package pinecone
 import (
 	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"math"
 	"math/rand"
 	"net/http"
+	"net/url"
+	"slices"
 	"strconv"
+	"strings"
 	"time"
 
+	"github.com/google/uuid"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
 )
 
+// [RequestIdHeader] is the HTTP header (and gRPC metadata key) carrying the client-generated ID attached to
+// every request made through a [Client] or [IndexConnection]. The ID is reused for every retry of the same
+// request, so the server and client logs can deduplicate replayed attempts.
+const RequestIdHeader = "X-Request-Id"
+
 // [RetryPolicy] configures exponential-backoff retries for rate-limited (HTTP 429 /
 // gRPC RESOURCE_EXHAUSTED) and transient (5xx / gRPC UNAVAILABLE) responses. Other
 // 4xx errors are never retried. Pass it via [NewClientParams.RetryPolicy] to enable
 // retries on both the REST (control/data/inference) and gRPC (data plane) clients.
 //
 // For REST, 429 is always retried; 5xx and transport errors are retried only for
-// idempotent methods, to avoid duplicating non-idempotent operations.
+// idempotent requests, to avoid duplicating non-idempotent operations. A request is
+// idempotent if its HTTP method is (GET, PUT, DELETE, ...), or if its endpoint is
+// classified as safe to replay even though it is sent as a POST: upserts and deletes
+// by ID, and read-only calls such as query, search, fetch by metadata and index stats.
+// Other POST endpoints ([OperationCreateIndex], [OperationStartImport],
+// [OperationEmbed], ...) can be opted in through IdempotentOperations.
 //
 // Fields:
 //   - MaxRetries: Number of retries after the initial attempt. 0 disables retries.
 //   - BaseDelay: Initial backoff before the first retry. Required when MaxRetries > 0.
 //   - MaxDelay: Upper bound on any single backoff. Required when MaxRetries > 0.
 //   - BackoffMultiplier: Growth factor applied to the delay each attempt (e.g. 2.0).
+//   - IdempotentOperations: (Optional) Additional [Operation] values to treat as safe to
+//     replay on 5xx and transport errors.
 type RetryPolicy struct {
-	MaxRetries        int
-	BaseDelay         time.Duration
-	MaxDelay          time.Duration
-	BackoffMultiplier float64
+	MaxRetries           int
+	BaseDelay            time.Duration
+	MaxDelay             time.Duration
+	BackoffMultiplier    float64
+	IdempotentOperations []Operation
+}
+
+// [Operation] identifies a REST endpoint that is sent as a POST, for the purpose of
+// deciding whether a failed request may be replayed. See [RetryPolicy.IdempotentOperations].
+type Operation string
+
+const (
+	OperationUpsertVectors          Operation = "UpsertVectors"          // idempotent by default
+	OperationUpsertRecords          Operation = "UpsertRecords"          // idempotent by default
+	OperationDeleteVectors          Operation = "DeleteVectors"          // idempotent by default
+	OperationQueryVectors           Operation = "QueryVectors"           // idempotent by default
+	OperationSearchRecords          Operation = "SearchRecords"          // idempotent by default
+	OperationFetchVectorsByMetadata Operation = "FetchVectorsByMetadata" // idempotent by default
+	OperationDescribeIndexStats     Operation = "DescribeIndexStats"     // idempotent by default
+	OperationUpdateVector           Operation = "UpdateVector"
+	OperationStartImport            Operation = "StartImport"
+	OperationCreateIndex            Operation = "CreateIndex"
+	OperationCreateIndexForModel    Operation = "CreateIndexForModel"
+	OperationCreateCollection       Operation = "CreateCollection"
+	OperationCreateBackup           Operation = "CreateBackup"
+	OperationCreateIndexFromBackup  Operation = "CreateIndexFromBackup"
+	OperationCreateNamespace        Operation = "CreateNamespace"
+	OperationEmbed                  Operation = "Embed"
+	OperationRerank                 Operation = "Rerank"
+)
+
+// restEndpoint classifies a POST endpoint by its route: the full path below the API's base path, where "*"
+// matches any single segment. Routes are matched exactly, so a path that merely ends like a known route, such
+// as an unknown endpoint or one behind an unexpected prefix, is not classified and is never replayed.
+type restEndpoint struct {
+	route      string
+	operation  Operation
+	idempotent bool
+}
+
+var restEndpoints = []restEndpoint{
+	{"/vectors/upsert", OperationUpsertVectors, true},
+	{"/records/namespaces/*/upsert", OperationUpsertRecords, true},
+	{"/vectors/delete", OperationDeleteVectors, true},
+	{"/query", OperationQueryVectors, true},
+	{"/records/namespaces/*/search", OperationSearchRecords, true},
+	{"/vectors/fetch_by_metadata", OperationFetchVectorsByMetadata, true},
+	{"/describe_index_stats", OperationDescribeIndexStats, true},
+	{"/vectors/update", OperationUpdateVector, false},
+	{"/bulk/imports", OperationStartImport, false},
+	{"/indexes/create-for-model", OperationCreateIndexForModel, false},
+	{"/indexes/*/backups", OperationCreateBackup, false},
+	{"/backups/*/create-index", OperationCreateIndexFromBackup, false},
+	{"/indexes", OperationCreateIndex, false},
+	{"/collections", OperationCreateCollection, false},
+	{"/namespaces", OperationCreateNamespace, false},
+	{"/embed", OperationEmbed, false},
+	{"/rerank", OperationRerank, false},
+}
+
+// lookupEndpoint returns the classification of a POST request path served under basePath, if known.
+func lookupEndpoint(path, basePath string) (restEndpoint, bool) {
+	path, ok := strings.CutPrefix(path, strings.TrimSuffix(basePath, "/"))
+	if !ok {
+		return restEndpoint{}, false
+	}
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	for _, ep := range restEndpoints {
+		route := strings.Split(strings.Trim(ep.route, "/"), "/")
+		if len(route) != len(segments) {
+			continue
+		}
+		matched := true
+		for i, want := range route {
+			if want != "*" && want != segments[i] {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			return ep, true
+		}
+	}
+	return restEndpoint{}, false
 }
 
 // [DefaultRetryPolicy] returns a sensible default: 3 retries, 500ms base delay,
@@ -67,6 +167,11 @@ func (p *RetryPolicy) validate() error {
 	if p.BackoffMultiplier < 1 {
 		return fmt.Errorf("RetryPolicy.BackoffMultiplier must be >= 1, got %v", p.BackoffMultiplier)
 	}
+	for _, op := range p.IdempotentOperations {
+		if !slices.ContainsFunc(restEndpoints, func(ep restEndpoint) bool { return ep.operation == op }) {
+			return fmt.Errorf("RetryPolicy.IdempotentOperations contains unknown operation %q", op)
+		}
+	}
 	return nil
 }
 
@@ -77,18 +182,27 @@ func NewRetryHTTPClient(policy *RetryPolicy, base *http.Client) *http.Client {
 	if policy == nil {
 		policy = DefaultRetryPolicy()
 	}
+	return newRetryHTTPClient(policy, nil, base, nil)
+}
+
+// newRetryHTTPClient wraps base with a [retryTransport]. apiBase, if set, is the control plane URL; its path
+// is the base path of the API routes served by its host.
+func newRetryHTTPClient(policy *RetryPolicy, hooks *Hooks, base *http.Client, apiBase *url.URL) *http.Client {
 	client := &http.Client{}
 	if base != nil {
 		*client = *base
 	}
-	client.Transport = &retryTransport{policy: policy, base: client.Transport}
+	client.Transport = &retryTransport{policy: policy, hooks: hooks, base: client.Transport, apiBase: apiBase}
 	return client
 }
 
-// retryTransport wraps an http.RoundTripper, retrying rate-limit and transient responses.
+// retryTransport wraps an http.RoundTripper, retrying rate-limit and transient responses
+// and reporting each attempt to the configured [Hooks].
 type retryTransport struct {
-	policy *RetryPolicy
-	base   http.RoundTripper
+	policy  *RetryPolicy
+	hooks   *Hooks
+	base    http.RoundTripper
+	apiBase *url.URL
 }
 
 // RoundTrip implements http.RoundTripper.
@@ -97,6 +211,17 @@ func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
 	if base == nil {
 		base = http.DefaultTransport
 	}
+	ctx := req.Context()
+
+	// A policy passed with WithRetryPolicy replaces the client's for this request.
+	if o := callOptionsFrom(ctx); o != nil && o.retryPolicy != nil {
+		if err := o.retryPolicy.validate(); err != nil {
+			return nil, err
+		}
+		override := *t
+		override.policy = o.retryPolicy
+		t = &override
+	}
 
 	// Buffer the body once so it can be replayed on each attempt.
 	var body []byte
@@ -108,52 +233,95 @@ func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
 		_ = req.Body.Close()
 	}
 
+	info := RequestInfo{
+		Protocol:  ProtocolHTTP,
+		Method:    req.Method,
+		Path:      req.URL.Path,
+		RequestId: req.Header.Get(RequestIdHeader),
+	}
+
 	var resp *http.Response
 	var err error
 	for attempt := 0; ; attempt++ {
-		attemptReq := req.Clone(req.Context())
+		attemptReq := req.Clone(ctx)
 		if body != nil {
 			attemptReq.Body = io.NopCloser(bytes.NewReader(body))
 			attemptReq.ContentLength = int64(len(body))
 		}
 
+		info.Attempt = attempt + 1
+		t.hooks.onRequest(ctx, info)
+		start := time.Now()
 		resp, err = base.RoundTrip(attemptReq)
+		t.hooks.onResponse(ctx, ResponseInfo{RequestInfo: info, StatusCode: statusCodeOf(resp), Err: err, Duration: time.Since(start)})
 
 		// Stop on cancellation regardless of the error.
-		if req.Context().Err() != nil {
+		if ctx.Err() != nil {
+			t.hooks.onGiveUp(ctx, info, retryCause(resp, err))
 			return resp, err
 		}
-		if !t.shouldRetry(attempt, req.Method, resp, err) {
+		if !t.shouldRetry(attempt, req, resp, err) {
+			t.hooks.onGiveUp(ctx, info, retryCause(resp, err))
 			return resp, err
 		}
 
 		retryAfter := retryAfterDelay(resp)
 		if retryAfter > t.policy.MaxDelay {
+			t.hooks.onGiveUp(ctx, info, retryCause(resp, err))
 			return resp, err // honor the server's hint over our budget: stop retrying
 		}
+		delay := t.backoff(attempt, retryAfter)
+		t.hooks.onRetry(ctx, info, delay, retryCause(resp, err))
 		drainResponse(resp)
-		if !wait(req.Context(), t.backoff(attempt, retryAfter)) {
-			return nil, req.Context().Err()
+		if !wait(ctx, delay) {
+			t.hooks.onGiveUp(ctx, info, ctx.Err())
+			return nil, ctx.Err()
 		}
 	}
 }
 
-func (t *retryTransport) shouldRetry(attempt int, method string, resp *http.Response, err error) bool {
+func (t *retryTransport) shouldRetry(attempt int, req *http.Request, resp *http.Response, err error) bool {
 	if attempt >= t.policy.MaxRetries {
 		return false
 	}
 	if err != nil {
-		return isIdempotent(method) // transport error: only safe to replay idempotent requests
+		return t.replayable(req) // transport error: only safe to replay idempotent requests
 	}
 	if resp.StatusCode == http.StatusTooManyRequests {
 		return true // rate-limited: request was rejected, safe to retry
 	}
 	if resp.StatusCode >= 500 {
-		return isIdempotent(method) // transient server error
+		return t.replayable(req) // transient server error
 	}
 	return false
 }
 
+// replayable reports whether a request may be retried after the server may have processed it:
+// idempotent HTTP methods, POST endpoints classified as idempotent, and operations opted in
+// through [RetryPolicy.IdempotentOperations].
+func (t *retryTransport) replayable(req *http.Request) bool {
+	if isIdempotent(req.Method) {
+		return true
+	}
+	if req.Method != http.MethodPost {
+		return false
+	}
+	ep, ok := lookupEndpoint(req.URL.Path, t.basePath(req.URL))
+	if !ok {
+		return false
+	}
+	return ep.idempotent || slices.Contains(t.policy.IdempotentOperations, ep.operation)
+}
+
+// basePath returns the path under which API routes are served for u: the path of the control plane URL for
+// requests to its host, which a proxy may serve the API under, and the root otherwise.
+func (t *retryTransport) basePath(u *url.URL) string {
+	if t.apiBase != nil && strings.EqualFold(u.Host, t.apiBase.Host) {
+		return t.apiBase.Path
+	}
+	return ""
+}
+
 // isIdempotent reports whether an HTTP method is safe to retry after the server may
 // have processed the request.
 func isIdempotent(method string) bool {
@@ -168,12 +336,16 @@ func isIdempotent(method string) bool {
 // (already bounded by MaxDelay by the caller), else exponential growth with full
 // jitter, capped at MaxDelay.
 func (t *retryTransport) backoff(attempt int, retryAfter time.Duration) time.Duration {
+	return t.policy.backoff(attempt, retryAfter)
+}
+
+func (p *RetryPolicy) backoff(attempt int, retryAfter time.Duration) time.Duration {
 	if retryAfter > 0 {
 		return retryAfter
 	}
-	d := float64(t.policy.BaseDelay) * math.Pow(t.policy.BackoffMultiplier, float64(attempt))
-	if d > float64(t.policy.MaxDelay) {
-		d = float64(t.policy.MaxDelay)
+	d := float64(p.BaseDelay) * math.Pow(p.BackoffMultiplier, float64(attempt))
+	if d > float64(p.MaxDelay) {
+		d = float64(p.MaxDelay)
 	}
 	if d <= 0 {
 		return 0
@@ -205,6 +377,26 @@ func retryAfterDelay(resp *http.Response) time.Duration {
 	return 0
 }
 
+// statusCodeOf returns the response status code, or 0 if there is no response.
+func statusCodeOf(resp *http.Response) int {
+	if resp == nil {
+		return 0
+	}
+	return resp.StatusCode
+}
+
+// retryCause describes why an attempt is considered a retryable failure: the transport error,
+// or a [PineconeError] for 429 and 5xx responses. Returns nil for any other outcome.
+func retryCause(resp *http.Response, err error) error {
+	if err != nil {
+		return err
+	}
+	if resp != nil && (resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500) {
+		return &PineconeError{Code: resp.StatusCode, Msg: errors.New(resp.Status)}
+	}
+	return nil
+}
+
 func drainResponse(resp *http.Response) {
 	if resp == nil || resp.Body == nil {
 		return
@@ -267,6 +459,71 @@ func buildRetryServiceConfig(policy *RetryPolicy) string {
 }`, attempts, grpcDuration(policy.BaseDelay), grpcDuration(policy.MaxDelay), policy.BackoffMultiplier)
 }
 
+// requestIdInterceptor attaches a client-generated [RequestIdHeader] to each data plane RPC unless the
+// caller already set one. gRPC retries replay the same metadata, so every attempt carries the same ID.
+func requestIdInterceptor(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	if md, _ := metadata.FromOutgoingContext(ctx); len(md.Get(RequestIdHeader)) == 0 {
+		ctx = metadata.AppendToOutgoingContext(ctx, RequestIdHeader, uuid.NewString())
+	}
+	return invoker(ctx, method, req, reply, cc, opts...)
+}
+
+// retryInterceptor returns a gRPC unary interceptor that retries data plane RPCs failing with
+// RESOURCE_EXHAUSTED or UNAVAILABLE per policy, and reports each attempt to hooks. A policy passed
+// with [WithRetryPolicy] replaces policy for the call, and options passed with [WithGRPCCallOptions]
+// are added to the RPC. A nil policy disables retries.
+func retryInterceptor(policy *RetryPolicy, hooks *Hooks) grpc.UnaryClientInterceptor {
+	if policy == nil {
+		policy = &RetryPolicy{}
+	}
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		callPolicy := policy
+		if o := callOptionsFrom(ctx); o != nil {
+			if o.retryPolicy != nil {
+				if err := o.retryPolicy.validate(); err != nil {
+					return err
+				}
+				callPolicy = o.retryPolicy
+			}
+			opts = append(opts, o.grpcOptions...)
+		}
+
+		info := RequestInfo{Protocol: ProtocolGRPC, Method: method}
+		if md, ok := metadata.FromOutgoingContext(ctx); ok {
+			if ids := md.Get(RequestIdHeader); len(ids) > 0 {
+				info.RequestId = ids[0]
+			}
+		}
+
+		for attempt := 0; ; attempt++ {
+			info.Attempt = attempt + 1
+			hooks.onRequest(ctx, info)
+			start := time.Now()
+			err := invoker(ctx, method, req, reply, cc, opts...)
+			hooks.onResponse(ctx, ResponseInfo{RequestInfo: info, StatusCode: int(status.Code(err)), Err: err, Duration: time.Since(start)})
+
+			if err == nil {
+				return nil
+			}
+			var cause error
+			if code := status.Code(err); code == codes.ResourceExhausted || code == codes.Unavailable {
+				cause = err
+			}
+			if cause == nil || attempt >= callPolicy.MaxRetries || ctx.Err() != nil {
+				hooks.onGiveUp(ctx, info, cause)
+				return err
+			}
+
+			delay := callPolicy.backoff(attempt, 0)
+			hooks.onRetry(ctx, info, delay, cause)
+			if !wait(ctx, delay) {
+				hooks.onGiveUp(ctx, info, ctx.Err())
+				return err
+			}
+		}
+	}
+}
+
 // grpcDuration formats a duration as protobuf JSON seconds (e.g. "0.5s", "30s").
 func grpcDuration(d time.Duration) string {
 	return strconv.FormatFloat(d.Seconds(), 'f', -1, 64) + "s"
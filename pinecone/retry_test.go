@@ -16,6 +16,7 @@ import (
 	"github.com/stretchr/testify/require"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
 )
 
 func fastPolicy(maxRetries int) *RetryPolicy {
@@ -343,3 +344,113 @@ func TestNewClientInvalidRetryPolicyUnit(t *testing.T) {
 	_, err := NewClient(NewClientParams{ApiKey: "test-key", RetryPolicy: &RetryPolicy{MaxRetries: -1}})
 	require.Error(t, err)
 }
+
+func TestRetryTransportIdempotentPostEndpointsUnit(t *testing.T) {
+	tests := []struct {
+		path      string
+		wantCalls int32
+	}{
+		{"/vectors/upsert", 2},
+		{"/vectors/delete", 2},
+		{"/query", 2},
+		{"/records/namespaces/ns/upsert", 2},
+		{"/records/namespaces/ns/search", 2},
+		{"/vectors/update", 1},
+		{"/bulk/imports", 1},
+		{"/indexes", 1},
+		{"/embed", 1},
+		{"/proxy/query", 1},
+		{"/indexes/my-index/query", 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			srv, calls, _ := serverReturning(http.StatusServiceUnavailable, 1)
+			defer srv.Close()
+
+			client := NewRetryHTTPClient(fastPolicy(3), nil)
+			resp, err := client.Post(srv.URL+tt.path, "application/json", strings.NewReader("{}"))
+			require.NoError(t, err)
+			resp.Body.Close()
+			assert.Equal(t, tt.wantCalls, atomic.LoadInt32(calls))
+		})
+	}
+}
+
+func TestLookupEndpointAnchoredAtBasePathUnit(t *testing.T) {
+	ep, ok := lookupEndpoint("/pinecone/indexes", "/pinecone/")
+	require.True(t, ok)
+	assert.Equal(t, OperationCreateIndex, ep.operation)
+
+	ep, ok = lookupEndpoint("/pinecone/records/namespaces/ns/upsert", "/pinecone")
+	require.True(t, ok)
+	assert.Equal(t, OperationUpsertRecords, ep.operation)
+
+	_, ok = lookupEndpoint("/indexes", "/pinecone")
+	assert.False(t, ok, "a path outside the base path should not be classified")
+	_, ok = lookupEndpoint("/pinecone/v2/query", "/pinecone")
+	assert.False(t, ok, "a path that only ends like a route should not be classified")
+}
+
+func TestRetryTransportIdempotentOperationsOptInUnit(t *testing.T) {
+	srv, calls, _ := serverReturning(http.StatusServiceUnavailable, 1)
+	defer srv.Close()
+
+	policy := fastPolicy(3)
+	policy.IdempotentOperations = []Operation{OperationEmbed}
+	require.NoError(t, policy.validate())
+
+	client := NewRetryHTTPClient(policy, nil)
+	resp, err := client.Post(srv.URL+"/embed", "application/json", strings.NewReader("{}"))
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, int32(2), atomic.LoadInt32(calls), "opted-in POST should be retried on 5xx")
+
+	policy.IdempotentOperations = []Operation{"NotAnOperation"}
+	require.Error(t, policy.validate())
+}
+
+func TestRetryTransportRequestIdStableAcrossRetriesUnit(t *testing.T) {
+	var calls int32
+	var ids []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ids = append(ids, r.Header.Get(RequestIdHeader))
+		if atomic.AddInt32(&calls, 1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"indexes":[]}`))
+	}))
+	defer srv.Close()
+
+	pc, err := NewClient(NewClientParams{ApiKey: "test-key", Host: srv.URL, RetryPolicy: fastPolicy(3)})
+	require.NoError(t, err)
+	_, err = pc.ListIndexes(context.Background())
+	require.NoError(t, err)
+
+	require.Len(t, ids, 2)
+	assert.NotEmpty(t, ids[0])
+	assert.Equal(t, ids[0], ids[1], "retries should reuse the original request ID")
+
+	_, err = pc.ListIndexes(context.Background())
+	require.NoError(t, err)
+	assert.NotEqual(t, ids[0], ids[2], "each new request should get a new ID")
+}
+
+func TestRequestIdInterceptorUnit(t *testing.T) {
+	var got []string
+	invoker := func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		md, _ := metadata.FromOutgoingContext(ctx)
+		got = md.Get(RequestIdHeader)
+		return nil
+	}
+
+	require.NoError(t, requestIdInterceptor(context.Background(), "/Upsert", nil, nil, nil, invoker))
+	require.Len(t, got, 1)
+	assert.NotEmpty(t, got[0])
+
+	ctx := metadata.AppendToOutgoingContext(context.Background(), RequestIdHeader, "caller-id")
+	require.NoError(t, requestIdInterceptor(ctx, "/Upsert", nil, nil, nil, invoker))
+	assert.Equal(t, []string{"caller-id"}, got, "a caller-supplied ID should be preserved")
+}
@@ -0,0 +1,385 @@
+package pinecone
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// [ErrMutationLimitExceeded] is wrapped by the error returned by the safe mutation methods, e.g.
+// [IndexConnection.SafeDeleteVectorsByFilter], when more vectors match than the caller allowed. Nothing is
+// changed in that case.
+var ErrMutationLimitExceeded = errors.New("mutation limit exceeded")
+
+// [ErrConfirmationMismatch] is wrapped by the error returned by [IndexConnection.SafeDeleteAllVectorsInNamespace]
+// when the confirmation token does not match the namespace's current state. Nothing is deleted in that case.
+var ErrConfirmationMismatch = errors.New("confirmation token does not match")
+
+const (
+	defaultMutationSampleSize = 10
+	safeMutationPageSize      = 100
+
+	// confirmationTokenTTL is how long a delete-all confirmation token stays valid after its dry run.
+	confirmationTokenTTL = 10 * time.Minute
+)
+
+// [SafeDeleteVectorsByFilterRequest] holds the parameters for [IndexConnection.SafeDeleteVectorsByFilter].
+//
+// Fields:
+//   - Filter: (Required) The metadata filter selecting the vectors to delete.
+//   - MaxAffected: (Required) The largest number of matching vectors the delete may affect. Must be greater than 0.
+//   - DryRun: (Optional) If true, matching vectors are counted and reported but not deleted.
+//   - SampleSize: (Optional) The number of matching IDs to include in the report. Defaults to 10.
+//   - UseIndexStats: (Optional) If true, matches are counted with [IndexConnection.DescribeIndexStatsFiltered],
+//     which is only available on pod-based indexes, and the report has no sample IDs. Otherwise matches are
+//     counted by paging through [IndexConnection.FetchVectorsByMetadata].
+type SafeDeleteVectorsByFilterRequest struct {
+	Filter        *MetadataFilter
+	MaxAffected   int
+	DryRun        bool
+	SampleSize    int
+	UseIndexStats bool
+}
+
+// [SafeUpdateVectorsByMetadataRequest] holds the parameters for [IndexConnection.SafeUpdateVectorsByMetadata].
+//
+// Fields:
+//   - Filter: (Required) The metadata filter selecting the vectors to update.
+//   - Metadata: (Required) The metadata to set on the matching vectors.
+//   - MaxAffected: (Required) The largest number of matching vectors the update may affect. Must be greater than 0.
+//   - DryRun: (Optional) If true, matching vectors are counted and reported but not updated.
+//   - SampleSize: (Optional) The number of matching IDs to include in the report. Defaults to 10.
+//   - UseIndexStats: (Optional) If true, matches are counted with [IndexConnection.DescribeIndexStatsFiltered],
+//     which is only available on pod-based indexes, and the report has no sample IDs. Otherwise matches are
+//     counted by paging through [IndexConnection.FetchVectorsByMetadata].
+type SafeUpdateVectorsByMetadataRequest struct {
+	Filter        *MetadataFilter
+	Metadata      *Metadata
+	MaxAffected   int
+	DryRun        bool
+	SampleSize    int
+	UseIndexStats bool
+}
+
+// [SafeDeleteAllVectorsInNamespaceRequest] holds the parameters for [IndexConnection.SafeDeleteAllVectorsInNamespace].
+//
+// Fields:
+//   - ConfirmationToken: (Optional) The token from a previous dry run of the delete. If empty, the call is a dry
+//     run that returns the token in its [MutationReport].
+//   - MaxAffected: (Optional) The largest number of vectors the delete may affect. If 0, there is no limit.
+type SafeDeleteAllVectorsInNamespaceRequest struct {
+	ConfirmationToken string
+	MaxAffected       int
+}
+
+// [MutationReport] describes the vectors affected by a safe mutation, e.g. [IndexConnection.SafeDeleteVectorsByFilter].
+//
+// Fields:
+//   - Namespace: The namespace the mutation targets.
+//   - MatchedCount: The number of vectors the mutation affects. If LimitExceeded is true, counting stopped early
+//     and MatchedCount is a lower bound.
+//   - LimitExceeded: Whether more vectors matched than MaxAffected allowed.
+//   - SampleIds: Up to SampleSize of the matching vector IDs, sorted.
+//   - DryRun: Whether the mutation was only counted and not applied.
+//   - Applied: Whether the mutation was applied.
+//   - ConfirmationToken: The token to pass to [IndexConnection.SafeDeleteAllVectorsInNamespace] to confirm the
+//     delete. Only set for namespace-wide deletes.
+type MutationReport struct {
+	Namespace         string   `json:"namespace"`
+	MatchedCount      int      `json:"matched_count"`
+	LimitExceeded     bool     `json:"limit_exceeded"`
+	SampleIds         []string `json:"sample_ids,omitempty"`
+	DryRun            bool     `json:"dry_run"`
+	Applied           bool     `json:"applied"`
+	ConfirmationToken string   `json:"confirmation_token,omitempty"`
+}
+
+// [IndexConnection.SafeDeleteVectorsByFilter] deletes the vectors matching a metadata filter only if at most
+// MaxAffected vectors match. It counts the matches first and returns a [MutationReport] with a sample of the
+// matching IDs. If more vectors match than allowed, nothing is deleted and the error wraps
+// [ErrMutationLimitExceeded]; the report is returned alongside it. With DryRun set, nothing is deleted.
+//
+// Vectors written between the count and the delete are not accounted for, so MaxAffected is a safeguard
+// against mistakes, not a guarantee.
+//
+// Parameters:
+//   - ctx: A context.Context object controls the request's lifetime,
+//     allowing for the request to be canceled or to timeout according to the context's deadline.
+//   - in: A [SafeDeleteVectorsByFilterRequest] object with the parameters for the request.
+//   - opts: (Optional) [CallOption] values that apply to this call only, e.g. [WithTimeout] or [WithHeader].
+//
+// Returns a pointer to a [MutationReport] or an error if the request fails.
+//
+// Example:
+//
+//	    filter, err := pinecone.NewMetadataFilter(map[string]interface{}{"genre": "classical"})
+//	    if err != nil {
+//			log.Fatalf("Failed to create metadata filter. Error: %v", err)
+//	    }
+//
+//	    report, err := idxConnection.SafeDeleteVectorsByFilter(ctx, &pinecone.SafeDeleteVectorsByFilterRequest{
+//			Filter:      filter,
+//			MaxAffected: 500,
+//			DryRun:      true,
+//	    })
+//	    if err != nil {
+//			log.Fatalf("Failed to plan delete. Error: %v", err)
+//	    }
+//	    fmt.Printf("Would delete %d vector(s), e.g. %v\n", report.MatchedCount, report.SampleIds)
+func (idx *IndexConnection) SafeDeleteVectorsByFilter(ctx context.Context, in *SafeDeleteVectorsByFilterRequest, opts ...CallOption) (*MutationReport, error) {
+	idx, ctx, cancel := idx.withCallOptions(ctx, opts)
+	defer cancel()
+
+	if in == nil {
+		return nil, fmt.Errorf("in (*SafeDeleteVectorsByFilterRequest) cannot be nil")
+	}
+	if in.Filter == nil {
+		return nil, fmt.Errorf("Filter is required to delete vectors by filter")
+	}
+	report, err := idx.countMatches(ctx, in.Filter, in.MaxAffected, in.SampleSize, in.UseIndexStats)
+	if err != nil {
+		return report, err
+	}
+	report.DryRun = in.DryRun
+	if in.DryRun {
+		return report, nil
+	}
+
+	if err := idx.DeleteVectorsByFilter(ctx, in.Filter); err != nil {
+		return report, err
+	}
+	report.Applied = true
+	return report, nil
+}
+
+// [IndexConnection.SafeUpdateVectorsByMetadata] sets metadata on the vectors matching a metadata filter only if
+// at most MaxAffected vectors match. It counts the matches first and returns a [MutationReport] with a sample
+// of the matching IDs. If more vectors match than allowed, nothing is updated and the error wraps
+// [ErrMutationLimitExceeded]; the report is returned alongside it. With DryRun set, nothing is updated.
+//
+// Vectors written between the count and the update are not accounted for, so MaxAffected is a safeguard
+// against mistakes, not a guarantee.
+//
+// Parameters:
+//   - ctx: A context.Context object controls the request's lifetime,
+//     allowing for the request to be canceled or to timeout according to the context's deadline.
+//   - in: A [SafeUpdateVectorsByMetadataRequest] object with the parameters for the request.
+//   - opts: (Optional) [CallOption] values that apply to this call only, e.g. [WithTimeout] or [WithHeader].
+//
+// Returns a pointer to a [MutationReport] or an error if the request fails.
+//
+// Example:
+//
+//	    filter, err := pinecone.NewMetadataFilter(map[string]interface{}{"genre": "rock"})
+//	    if err != nil {
+//			log.Fatalf("Failed to create metadata filter. Error: %v", err)
+//	    }
+//	    metadata, err := pinecone.NewMetadata(map[string]interface{}{"reviewed": true})
+//	    if err != nil {
+//			log.Fatalf("Failed to create metadata. Error: %v", err)
+//	    }
+//
+//	    report, err := idxConnection.SafeUpdateVectorsByMetadata(ctx, &pinecone.SafeUpdateVectorsByMetadataRequest{
+//			Filter:      filter,
+//			Metadata:    metadata,
+//			MaxAffected: 1000,
+//	    })
+//	    if errors.Is(err, pinecone.ErrMutationLimitExceeded) {
+//			log.Fatalf("Refusing to update more than 1000 vectors, at least %d match", report.MatchedCount)
+//	    } else if err != nil {
+//			log.Fatalf("Failed to update vectors. Error: %v", err)
+//	    }
+func (idx *IndexConnection) SafeUpdateVectorsByMetadata(ctx context.Context, in *SafeUpdateVectorsByMetadataRequest, opts ...CallOption) (*MutationReport, error) {
+	idx, ctx, cancel := idx.withCallOptions(ctx, opts)
+	defer cancel()
+
+	if in == nil {
+		return nil, fmt.Errorf("in (*SafeUpdateVectorsByMetadataRequest) cannot be nil")
+	}
+	if in.Filter == nil {
+		return nil, fmt.Errorf("Filter is required to update vectors by metadata")
+	}
+	if in.Metadata == nil {
+		return nil, fmt.Errorf("Metadata is required to update vectors by metadata")
+	}
+	report, err := idx.countMatches(ctx, in.Filter, in.MaxAffected, in.SampleSize, in.UseIndexStats)
+	if err != nil {
+		return report, err
+	}
+	report.DryRun = in.DryRun
+	if in.DryRun {
+		return report, nil
+	}
+
+	if _, err := idx.UpdateVectorsByMetadata(ctx, &UpdateVectorsByMetadataRequest{Filter: in.Filter, Metadata: in.Metadata}); err != nil {
+		return report, err
+	}
+	report.Applied = true
+	return report, nil
+}
+
+// [IndexConnection.SafeDeleteAllVectorsInNamespace] deletes all vectors in the namespace only when given the
+// confirmation token of a previous dry run. Called without a token, it counts the vectors in the namespace with
+// [IndexConnection.DescribeIndexStats] and returns a [MutationReport] holding the token. The token is only valid for 10
+// minutes, on the [IndexConnection] that issued it (or one derived from it, e.g. with [IndexConnection.WithNamespace]),
+// for the same index and namespace, and while the namespace's vector count is unchanged; otherwise nothing is deleted
+// and the error wraps [ErrConfirmationMismatch]. If MaxAffected is set and the namespace holds more vectors, nothing is
+// deleted and the error wraps [ErrMutationLimitExceeded].
+//
+// Parameters:
+//   - ctx: A context.Context object controls the request's lifetime,
+//     allowing for the request to be canceled or to timeout according to the context's deadline.
+//   - in: A [SafeDeleteAllVectorsInNamespaceRequest] object with the parameters for the request.
+//   - opts: (Optional) [CallOption] values that apply to this call only, e.g. [WithTimeout] or [WithHeader].
+//
+// Returns a pointer to a [MutationReport] or an error if the request fails.
+//
+// Example:
+//
+//	    plan, err := idxConnection.SafeDeleteAllVectorsInNamespace(ctx, &pinecone.SafeDeleteAllVectorsInNamespaceRequest{})
+//	    if err != nil {
+//			log.Fatalf("Failed to plan delete. Error: %v", err)
+//	    }
+//	    fmt.Printf("Delete all %d vector(s) in %q? ", plan.MatchedCount, plan.Namespace)
+//	    // ... after the user confirms:
+//	    _, err = idxConnection.SafeDeleteAllVectorsInNamespace(ctx, &pinecone.SafeDeleteAllVectorsInNamespaceRequest{
+//			ConfirmationToken: plan.ConfirmationToken,
+//	    })
+//	    if err != nil {
+//			log.Fatalf("Failed to delete vectors. Error: %v", err)
+//	    }
+func (idx *IndexConnection) SafeDeleteAllVectorsInNamespace(ctx context.Context, in *SafeDeleteAllVectorsInNamespaceRequest, opts ...CallOption) (*MutationReport, error) {
+	idx, ctx, cancel := idx.withCallOptions(ctx, opts)
+	defer cancel()
+
+	if in == nil {
+		return nil, fmt.Errorf("in (*SafeDeleteAllVectorsInNamespaceRequest) cannot be nil")
+	}
+	if in.MaxAffected < 0 {
+		return nil, fmt.Errorf("MaxAffected cannot be negative")
+	}
+	stats, err := idx.DescribeIndexStats(ctx)
+	if err != nil {
+		return nil, err
+	}
+	report := &MutationReport{Namespace: idx.namespace, DryRun: in.ConfirmationToken == ""}
+	if summary, ok := stats.Namespaces[idx.namespace]; ok && summary != nil {
+		report.MatchedCount = int(summary.VectorCount)
+	}
+	report.ConfirmationToken = idx.confirmationToken(report.MatchedCount, time.Now().Add(confirmationTokenTTL))
+
+	if in.MaxAffected > 0 && report.MatchedCount > in.MaxAffected {
+		report.LimitExceeded = true
+		return report, fmt.Errorf("%w: namespace %q holds %d vectors, more than MaxAffected (%d)", ErrMutationLimitExceeded, report.Namespace, report.MatchedCount, in.MaxAffected)
+	}
+	if report.DryRun {
+		return report, nil
+	}
+	if err := idx.checkConfirmationToken(in.ConfirmationToken, report.MatchedCount); err != nil {
+		return report, fmt.Errorf("%w: %s", ErrConfirmationMismatch, err)
+	}
+
+	if err := idx.DeleteAllVectorsInNamespace(ctx); err != nil {
+		return report, err
+	}
+	report.Applied = true
+	return report, nil
+}
+
+// countMatches counts the vectors in the namespace matching filter, stopping once more than maxAffected match.
+// The returned error wraps [ErrMutationLimitExceeded] if the limit is exceeded.
+func (idx *IndexConnection) countMatches(ctx context.Context, filter *MetadataFilter, maxAffected, sampleSize int, useIndexStats bool) (*MutationReport, error) {
+	if maxAffected < 1 {
+		return nil, fmt.Errorf("MaxAffected must be greater than 0")
+	}
+	if sampleSize <= 0 {
+		sampleSize = defaultMutationSampleSize
+	}
+	report := &MutationReport{Namespace: idx.namespace}
+
+	if useIndexStats {
+		stats, err := idx.DescribeIndexStatsFiltered(ctx, filter)
+		if err != nil {
+			return nil, err
+		}
+		if summary, ok := stats.Namespaces[idx.namespace]; ok && summary != nil {
+			report.MatchedCount = int(summary.VectorCount)
+		}
+	} else {
+		var paginationToken *string
+		for report.MatchedCount <= maxAffected {
+			limit := uint32(safeMutationPageSize)
+			res, err := idx.FetchVectorsByMetadata(ctx, &FetchVectorsByMetadataRequest{
+				Filter:          filter,
+				Limit:           &limit,
+				PaginationToken: paginationToken,
+			})
+			if err != nil {
+				return nil, err
+			}
+			ids := make([]string, 0, len(res.Vectors))
+			for id := range res.Vectors {
+				ids = append(ids, id)
+			}
+			sort.Strings(ids)
+			report.MatchedCount += len(ids)
+			for _, id := range ids {
+				if len(report.SampleIds) < sampleSize {
+					report.SampleIds = append(report.SampleIds, id)
+				}
+			}
+			if res.Pagination == nil || res.Pagination.Next == "" {
+				break
+			}
+			paginationToken = &res.Pagination.Next
+		}
+		sort.Strings(report.SampleIds)
+	}
+
+	if report.MatchedCount > maxAffected {
+		report.LimitExceeded = true
+		return report, fmt.Errorf("%w: %d or more vectors in namespace %q match the filter, more than MaxAffected (%d)", ErrMutationLimitExceeded, report.MatchedCount, report.Namespace, maxAffected)
+	}
+	return report, nil
+}
+
+// newTokenKey returns a random key for signing the confirmation tokens of one [IndexConnection].
+func newTokenKey() []byte {
+	key := make([]byte, 32)
+	rand.Read(key)
+	return key
+}
+
+// confirmationToken signs a namespace-wide delete of count vectors in the connection's index and namespace,
+// valid until expires.
+func (idx *IndexConnection) confirmationToken(count int, expires time.Time) string {
+	expiry := strconv.FormatInt(expires.Unix(), 10)
+	mac := hmac.New(sha256.New, idx.tokenKey)
+	fmt.Fprintf(mac, "%s\x00%s\x00%d\x00%s", idx.host, idx.namespace, count, expiry)
+	return "delete-all-" + expiry + "-" + hex.EncodeToString(mac.Sum(nil)[:16])
+}
+
+// checkConfirmationToken reports why token does not confirm a namespace-wide delete of count vectors.
+func (idx *IndexConnection) checkConfirmationToken(token string, count int) error {
+	expiry, _, ok := strings.Cut(strings.TrimPrefix(token, "delete-all-"), "-")
+	unix, err := strconv.ParseInt(expiry, 10, 64)
+	if !ok || err != nil {
+		return fmt.Errorf("malformed token")
+	}
+	expires := time.Unix(unix, 0)
+	if !hmac.Equal([]byte(token), []byte(idx.confirmationToken(count, expires))) {
+		return fmt.Errorf("namespace %q changed since the dry run, or the token was issued for another connection, index, or namespace", idx.namespace)
+	}
+	if time.Now().After(expires) {
+		return fmt.Errorf("token expired at %s", expires.Format(time.RFC3339))
+	}
+	return nil
+}
@@ -0,0 +1,231 @@
+package pinecone
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// mutationServer serves a namespace "ns" holding ids, all of which match every filter. fetch_by_metadata
+// returns them in pages of pageSize; delete, update, and describe_index_stats requests are recorded.
+type mutationServer struct {
+	ids      []string
+	pageSize int
+
+	mu       sync.Mutex
+	fetches  int
+	deletes  []map[string]any
+	updates  []map[string]any
+	statsReq []map[string]any
+}
+
+func (s *mutationServer) connection(t *testing.T) *IndexConnection {
+	t.Helper()
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /vectors/fetch_by_metadata", func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			PaginationToken string `json:"paginationToken"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		s.mu.Lock()
+		s.fetches++
+		s.mu.Unlock()
+
+		start := 0
+		if body.PaginationToken != "" {
+			_, _ = fmt.Sscan(body.PaginationToken, &start)
+		}
+		end := min(start+s.pageSize, len(s.ids))
+		vectors := map[string]any{}
+		for _, id := range s.ids[start:end] {
+			vectors[id] = map[string]any{"id": id}
+		}
+		res := map[string]any{"namespace": "ns", "vectors": vectors}
+		if end < len(s.ids) {
+			res["pagination"] = map[string]any{"next": fmt.Sprint(end)}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(res)
+	})
+	record := func(into *[]map[string]any, response string) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			var body map[string]any
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			s.mu.Lock()
+			*into = append(*into, body)
+			s.mu.Unlock()
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(response))
+		}
+	}
+	mux.HandleFunc("POST /vectors/delete", record(&s.deletes, `{}`))
+	mux.HandleFunc("POST /vectors/update", record(&s.updates, `{"matchedRecords":3}`))
+	mux.HandleFunc("POST /describe_index_stats", func(w http.ResponseWriter, r *http.Request) {
+		record(&s.statsReq, fmt.Sprintf(`{"namespaces":{"ns":{"vectorCount":%d}}}`, len(s.ids)))(w, r)
+	})
+	srv := httptest.NewTLSServer(mux)
+	t.Cleanup(srv.Close)
+
+	pc, err := NewClient(NewClientParams{ApiKey: "test-key", Host: srv.URL, RestClient: srv.Client()})
+	require.NoError(t, err)
+	idx, err := pc.Index(NewIndexConnParams{Host: srv.URL, Namespace: "ns", Transport: IndexTransportREST})
+	require.NoError(t, err)
+	return idx
+}
+
+func testFilter(t *testing.T) *MetadataFilter {
+	t.Helper()
+	filter, err := NewMetadataFilter(map[string]interface{}{"genre": "drama"})
+	require.NoError(t, err)
+	return filter
+}
+
+// Unit tests:
+func TestSafeDeleteVectorsByFilterUnit(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("dry run reports matches", func(t *testing.T) {
+		s := &mutationServer{ids: []string{"e", "d", "c", "b", "a"}, pageSize: 2}
+		idx := s.connection(t)
+		report, err := idx.SafeDeleteVectorsByFilter(ctx, &SafeDeleteVectorsByFilterRequest{
+			Filter: testFilter(t), MaxAffected: 5, DryRun: true, SampleSize: 3,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, &MutationReport{Namespace: "ns", MatchedCount: 5, SampleIds: []string{"b", "d", "e"}, DryRun: true}, report)
+		assert.Equal(t, 3, s.fetches)
+		assert.Empty(t, s.deletes)
+	})
+
+	t.Run("refuses above the limit", func(t *testing.T) {
+		s := &mutationServer{ids: []string{"a", "b", "c", "d", "e"}, pageSize: 2}
+		idx := s.connection(t)
+		report, err := idx.SafeDeleteVectorsByFilter(ctx, &SafeDeleteVectorsByFilterRequest{Filter: testFilter(t), MaxAffected: 1})
+		require.ErrorIs(t, err, ErrMutationLimitExceeded)
+		require.NotNil(t, report)
+		assert.True(t, report.LimitExceeded)
+		assert.Equal(t, 2, report.MatchedCount)
+		assert.Equal(t, 1, s.fetches, "counting should stop once the limit is exceeded")
+		assert.Empty(t, s.deletes)
+	})
+
+	t.Run("deletes within the limit", func(t *testing.T) {
+		s := &mutationServer{ids: []string{"a", "b"}, pageSize: 10}
+		idx := s.connection(t)
+		report, err := idx.SafeDeleteVectorsByFilter(ctx, &SafeDeleteVectorsByFilterRequest{Filter: testFilter(t), MaxAffected: 2})
+		require.NoError(t, err)
+		assert.True(t, report.Applied)
+		require.Len(t, s.deletes, 1)
+		assert.Equal(t, map[string]any{"genre": "drama"}, s.deletes[0]["filter"])
+		assert.Equal(t, "ns", s.deletes[0]["namespace"])
+	})
+
+	t.Run("counts with index stats", func(t *testing.T) {
+		s := &mutationServer{ids: []string{"a", "b", "c"}, pageSize: 10}
+		idx := s.connection(t)
+		report, err := idx.SafeDeleteVectorsByFilter(ctx, &SafeDeleteVectorsByFilterRequest{Filter: testFilter(t), MaxAffected: 2, UseIndexStats: true})
+		require.ErrorIs(t, err, ErrMutationLimitExceeded)
+		assert.Equal(t, 3, report.MatchedCount)
+		assert.Equal(t, 0, s.fetches)
+		require.Len(t, s.statsReq, 1)
+		assert.Equal(t, map[string]any{"genre": "drama"}, s.statsReq[0]["filter"])
+	})
+
+	t.Run("requires MaxAffected", func(t *testing.T) {
+		s := &mutationServer{}
+		idx := s.connection(t)
+		_, err := idx.SafeDeleteVectorsByFilter(ctx, &SafeDeleteVectorsByFilterRequest{Filter: testFilter(t)})
+		assert.ErrorContains(t, err, "MaxAffected")
+		_, err = idx.SafeDeleteVectorsByFilter(ctx, nil)
+		assert.Error(t, err)
+	})
+}
+
+func TestSafeUpdateVectorsByMetadataUnit(t *testing.T) {
+	s := &mutationServer{ids: []string{"a", "b", "c"}, pageSize: 10}
+	idx := s.connection(t)
+	metadata, err := NewMetadata(map[string]interface{}{"reviewed": true})
+	require.NoError(t, err)
+
+	_, err = idx.SafeUpdateVectorsByMetadata(context.Background(), &SafeUpdateVectorsByMetadataRequest{Filter: testFilter(t), MaxAffected: 3})
+	assert.ErrorContains(t, err, "Metadata is required")
+
+	report, err := idx.SafeUpdateVectorsByMetadata(context.Background(), &SafeUpdateVectorsByMetadataRequest{
+		Filter: testFilter(t), Metadata: metadata, MaxAffected: 3,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, &MutationReport{Namespace: "ns", MatchedCount: 3, SampleIds: []string{"a", "b", "c"}, Applied: true}, report)
+	require.Len(t, s.updates, 1)
+	assert.Equal(t, map[string]any{"reviewed": true}, s.updates[0]["setMetadata"])
+}
+
+func TestSafeDeleteAllVectorsInNamespaceUnit(t *testing.T) {
+	ctx := context.Background()
+	s := &mutationServer{ids: []string{"a", "b", "c"}}
+	idx := s.connection(t)
+
+	plan, err := idx.SafeDeleteAllVectorsInNamespace(ctx, &SafeDeleteAllVectorsInNamespaceRequest{})
+	require.NoError(t, err)
+	assert.True(t, plan.DryRun)
+	assert.False(t, plan.Applied)
+	assert.Equal(t, 3, plan.MatchedCount)
+	assert.NotEmpty(t, plan.ConfirmationToken)
+	assert.Empty(t, s.deletes)
+
+	_, err = idx.WithNamespace("other").SafeDeleteAllVectorsInNamespace(ctx, &SafeDeleteAllVectorsInNamespaceRequest{ConfirmationToken: plan.ConfirmationToken})
+	require.ErrorIs(t, err, ErrConfirmationMismatch, "a token should not confirm a delete in another namespace")
+
+	_, err = idx.SafeDeleteAllVectorsInNamespace(ctx, &SafeDeleteAllVectorsInNamespaceRequest{ConfirmationToken: plan.ConfirmationToken, MaxAffected: 2})
+	require.ErrorIs(t, err, ErrMutationLimitExceeded)
+
+	s.ids = append(s.ids, "d")
+	_, err = idx.SafeDeleteAllVectorsInNamespace(ctx, &SafeDeleteAllVectorsInNamespaceRequest{ConfirmationToken: plan.ConfirmationToken})
+	require.ErrorIs(t, err, ErrConfirmationMismatch, "a token should not confirm a delete after the namespace changed")
+	assert.Empty(t, s.deletes)
+
+	s.ids = s.ids[:3]
+	report, err := idx.SafeDeleteAllVectorsInNamespace(ctx, &SafeDeleteAllVectorsInNamespaceRequest{ConfirmationToken: plan.ConfirmationToken})
+	require.NoError(t, err)
+	assert.True(t, report.Applied)
+	require.Len(t, s.deletes, 1)
+	assert.Equal(t, true, s.deletes[0]["deleteAll"])
+}
+
+func TestSafeDeleteAllVectorsInNamespaceTokenScopeUnit(t *testing.T) {
+	ctx := context.Background()
+	s := &mutationServer{ids: []string{"a", "b", "c"}}
+	idx := s.connection(t)
+
+	plan, err := idx.SafeDeleteAllVectorsInNamespace(ctx, &SafeDeleteAllVectorsInNamespaceRequest{})
+	require.NoError(t, err)
+
+	other := s.connection(t)
+	_, err = other.SafeDeleteAllVectorsInNamespace(ctx, &SafeDeleteAllVectorsInNamespaceRequest{ConfirmationToken: plan.ConfirmationToken})
+	require.ErrorIs(t, err, ErrConfirmationMismatch, "a token should not confirm a delete on another connection")
+
+	moved := idx.WithNamespace(idx.namespace)
+	moved.host = "https://other-index.svc.pinecone.io"
+	_, err = moved.SafeDeleteAllVectorsInNamespace(ctx, &SafeDeleteAllVectorsInNamespaceRequest{ConfirmationToken: plan.ConfirmationToken})
+	require.ErrorIs(t, err, ErrConfirmationMismatch, "a token should not confirm a delete in another index")
+
+	expired := idx.confirmationToken(3, time.Now().Add(-time.Minute))
+	_, err = idx.SafeDeleteAllVectorsInNamespace(ctx, &SafeDeleteAllVectorsInNamespaceRequest{ConfirmationToken: expired})
+	require.ErrorIs(t, err, ErrConfirmationMismatch, "an expired token should not confirm a delete")
+
+	for _, token := range []string{"delete-all-", "delete-all-abc-def", "bogus"} {
+		_, err = idx.SafeDeleteAllVectorsInNamespace(ctx, &SafeDeleteAllVectorsInNamespaceRequest{ConfirmationToken: token})
+		require.ErrorIs(t, err, ErrConfirmationMismatch, "token %q", token)
+	}
+	assert.Empty(t, s.deletes)
+
+	_, err = idx.WithNamespace(idx.namespace).SafeDeleteAllVectorsInNamespace(ctx, &SafeDeleteAllVectorsInNamespaceRequest{ConfirmationToken: plan.ConfirmationToken})
+	require.NoError(t, err, "a token should confirm a delete on a connection derived from the one that issued it")
+	require.Len(t, s.deletes, 1)
+}
@@ -0,0 +1,367 @@
+package pinecone
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/pinecone-io/go-pinecone/v6/internal/gen/admin"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// tokenRefreshMargin is how long before expiry a cached token is refreshed. Tokens living less than twice the
+// margin are refreshed halfway through their lifetime.
+const tokenRefreshMargin = time.Minute
+
+// [Token] is an OAuth access token returned by a [TokenSource].
+//
+// Fields:
+//   - AccessToken: The bearer token sent in the Authorization header.
+//   - Expiry: (Optional) When the token expires. The zero value means the token does not expire.
+type Token struct {
+	AccessToken string
+	Expiry      time.Time
+}
+
+// [TokenSource] supplies the access tokens used to authenticate the [AdminClient] or [Client]. Implement it to
+// obtain tokens from elsewhere, e.g. workload identity or a secrets vault, and pass it in [NewAdminClientParams]
+// or [NewClientParams].
+//
+// Token should return a new token each time it is called: the SDK caches the token, refreshes it shortly
+// before its Expiry, and refreshes it when a request is rejected with 401 Unauthorized. Concurrent requests
+// share a single refresh, so Token is never called concurrently by the SDK.
+type TokenSource interface {
+	Token(ctx context.Context) (*Token, error)
+}
+
+// [TokenSourceFunc] adapts a function to a [TokenSource].
+//
+// Example:
+//
+//	    tokens := pinecone.TokenSourceFunc(func(ctx context.Context) (*pinecone.Token, error) {
+//		       secret, err := vault.Read(ctx, "pinecone/admin-token")
+//		       if err != nil {
+//			       return nil, err
+//		       }
+//		       return &pinecone.Token{AccessToken: secret.Value, Expiry: secret.ExpiresAt}, nil
+//	    })
+//
+//	    adminClient, err := pinecone.NewAdminClient(pinecone.NewAdminClientParams{TokenSource: tokens})
+type TokenSourceFunc func(ctx context.Context) (*Token, error)
+
+// Token implements [TokenSource].
+func (f TokenSourceFunc) Token(ctx context.Context) (*Token, error) {
+	return f(ctx)
+}
+
+// [StaticTokenSource] returns a [TokenSource] that always returns accessToken, which never expires.
+func StaticTokenSource(accessToken string) TokenSource {
+	return TokenSourceFunc(func(ctx context.Context) (*Token, error) {
+		return &Token{AccessToken: accessToken}, nil
+	})
+}
+
+// [NewClientCredentialsTokenSource] returns a [TokenSource] that exchanges an OAuth client ID and secret for
+// access tokens with Pinecone's authorization server. This is what [NewAdminClient] and [NewClient] use when
+// given a ClientId and ClientSecret.
+//
+// Parameters:
+//   - clientId: (Required) The OAuth client ID of a service account.
+//   - clientSecret: (Required) The OAuth client secret of the service account.
+//   - httpClient: (Optional) The HTTP client used to request tokens. Defaults to a new http.Client.
+func NewClientCredentialsTokenSource(clientId, clientSecret string, httpClient *http.Client) TokenSource {
+	var opts []admin.ClientOption
+	if httpClient != nil {
+		opts = append(opts, admin.WithHTTPClient(httpClient))
+	}
+	return &clientCredentialsTokenSource{clientId: clientId, clientSecret: clientSecret, opts: opts}
+}
+
+type clientCredentialsTokenSource struct {
+	clientId     string
+	clientSecret string
+	opts         []admin.ClientOption
+}
+
+func (s *clientCredentialsTokenSource) Token(ctx context.Context) (*Token, error) {
+	return getAuthTokenFunc(ctx, s.clientId, s.clientSecret, s.opts...)
+}
+
+// reuseTokenSource caches the tokens of a [TokenSource], refreshing them before they expire or when
+// invalidated. Concurrent callers needing a new token share a single call to the source.
+type reuseTokenSource struct {
+	src TokenSource
+	now func() time.Time
+
+	mu       sync.Mutex
+	current  *Token
+	obtained time.Time
+	flight   *tokenFlight
+}
+
+// tokenFlight is a call to the source in progress; done is closed once token and err are set.
+type tokenFlight struct {
+	done  chan struct{}
+	token *Token
+	err   error
+}
+
+func newReuseTokenSource(src TokenSource) *reuseTokenSource {
+	return &reuseTokenSource{src: src, now: time.Now}
+}
+
+// token returns the cached token, or a new one if it is missing or about to expire.
+func (s *reuseTokenSource) token(ctx context.Context) (*Token, error) {
+	s.mu.Lock()
+	if s.fresh() {
+		token := s.current
+		s.mu.Unlock()
+		return token, nil
+	}
+	flight := s.flight
+	if flight == nil {
+		flight = &tokenFlight{done: make(chan struct{})}
+		s.flight = flight
+		// The refresh outlives a cancelled caller, since other callers may be waiting on it.
+		go s.refresh(context.WithoutCancel(ctx), flight)
+	}
+	s.mu.Unlock()
+
+	select {
+	case <-flight.done:
+		return flight.token, flight.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+func (s *reuseTokenSource) refresh(ctx context.Context, flight *tokenFlight) {
+	token, err := s.src.Token(ctx)
+	if err == nil && (token == nil || token.AccessToken == "") {
+		err = fmt.Errorf("token source returned an empty token")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err != nil {
+		flight.err = fmt.Errorf("failed to get auth token: %w", err)
+	} else {
+		flight.token = token
+		s.current = token
+		s.obtained = s.now()
+	}
+	s.flight = nil
+	close(flight.done)
+}
+
+// fresh reports whether the cached token can be used without a refresh. s.mu must be held.
+func (s *reuseTokenSource) fresh() bool {
+	if s.current == nil {
+		return false
+	}
+	if s.current.Expiry.IsZero() {
+		return true
+	}
+	margin := min(tokenRefreshMargin, s.current.Expiry.Sub(s.obtained)/2)
+	return s.now().Add(margin).Before(s.current.Expiry)
+}
+
+// invalidate discards the cached token if it is still stale, so the next call to token gets a new one.
+// Comparing against the rejected token keeps concurrent 401s from refreshing more than once.
+func (s *reuseTokenSource) invalidate(stale string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.current != nil && s.current.AccessToken == stale {
+		s.current = nil
+	}
+}
+
+// newTokenHTTPClient returns a copy of base, or a new client, whose requests are authenticated with tokens.
+func newTokenHTTPClient(tokens *reuseTokenSource, base *http.Client) *http.Client {
+	client := &http.Client{}
+	if base != nil {
+		*client = *base
+	}
+	client.Transport = &tokenTransport{tokens: tokens, base: client.Transport}
+	return client
+}
+
+// tokenTransport wraps an http.RoundTripper, setting the Authorization header from a token source and
+// retrying once with a new token when a request is rejected with 401 Unauthorized.
+type tokenTransport struct {
+	tokens *reuseTokenSource
+	base   http.RoundTripper
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *tokenTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	base := t.base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	ctx := req.Context()
+
+	token, err := t.tokens.token(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Buffer the body once so the request can be replayed with a new token.
+	var body []byte
+	if req.Body != nil {
+		if body, err = io.ReadAll(req.Body); err != nil {
+			return nil, err
+		}
+		_ = req.Body.Close()
+	}
+	send := func(token *Token) (*http.Response, error) {
+		attemptReq := req.Clone(ctx)
+		if body != nil {
+			attemptReq.Body = io.NopCloser(bytes.NewReader(body))
+			attemptReq.ContentLength = int64(len(body))
+		}
+		attemptReq.Header.Set("Authorization", "Bearer "+token.AccessToken)
+		return base.RoundTrip(attemptReq)
+	}
+
+	resp, err := send(token)
+	if err != nil || resp.StatusCode != http.StatusUnauthorized {
+		return resp, err
+	}
+
+	t.tokens.invalidate(token.AccessToken)
+	refreshed, refreshErr := t.tokens.token(ctx)
+	if refreshErr != nil || refreshed.AccessToken == token.AccessToken {
+		return resp, nil // surface the original 401
+	}
+	drainResponse(resp)
+	return send(refreshed)
+}
+
+// tokenCredentials is a gRPC credentials.PerRPCCredentials setting the authorization metadata from a token source.
+type tokenCredentials struct {
+	tokens     *reuseTokenSource
+	requireTLS bool
+}
+
+// GetRequestMetadata implements credentials.PerRPCCredentials.
+func (c *tokenCredentials) GetRequestMetadata(ctx context.Context, _ ...string) (map[string]string, error) {
+	token, err := c.tokens.token(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return map[string]string{"authorization": "Bearer " + token.AccessToken}, nil
+}
+
+// RequireTransportSecurity implements credentials.PerRPCCredentials.
+func (c *tokenCredentials) RequireTransportSecurity() bool {
+	return c.requireTLS
+}
+
+// tokenInterceptor retries a gRPC call once with a new token when it fails with codes.Unauthenticated.
+// The token itself is sent by [tokenCredentials].
+func tokenInterceptor(tokens *reuseTokenSource) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		token, err := tokens.token(ctx)
+		if err != nil {
+			return err
+		}
+		err = invoker(ctx, method, req, reply, cc, opts...)
+		if status.Code(err) != codes.Unauthenticated {
+			return err
+		}
+
+		tokens.invalidate(token.AccessToken)
+		refreshed, refreshErr := tokens.token(ctx)
+		if refreshErr != nil || refreshed.AccessToken == token.AccessToken {
+			return err // surface the original error
+		}
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+}
+
+// projectIdSource looks up the project of a client authenticated with a token source on first use, caching it
+// once found. Concurrent first requests may each look it up; a failed lookup is retried by the next request.
+type projectIdSource struct {
+	resolve func(ctx context.Context) (string, error)
+
+	mu sync.Mutex
+	id string
+}
+
+// projectId returns the cached project ID, or looks it up under ctx.
+func (p *projectIdSource) projectId(ctx context.Context) (string, error) {
+	p.mu.Lock()
+	id := p.id
+	p.mu.Unlock()
+	if id != "" {
+		return id, nil
+	}
+	id, err := p.resolve(ctx)
+	if err != nil {
+		return "", err
+	}
+	p.mu.Lock()
+	p.id = id
+	p.mu.Unlock()
+	return id, nil
+}
+
+// newProjectIdHTTPClient returns a copy of base, or a new client, setting the "X-Project-Id" header of requests
+// that have none from projects.
+func newProjectIdHTTPClient(projects *projectIdSource, base *http.Client) *http.Client {
+	client := &http.Client{}
+	if base != nil {
+		*client = *base
+	}
+	client.Transport = &projectIdTransport{projects: projects, base: client.Transport}
+	return client
+}
+
+// projectIdTransport wraps an http.RoundTripper, setting the "X-Project-Id" header from a [projectIdSource].
+type projectIdTransport struct {
+	projects *projectIdSource
+	base     http.RoundTripper
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *projectIdTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	base := t.base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	if req.Header.Get("X-Project-Id") != "" {
+		return base.RoundTrip(req)
+	}
+	id, err := t.projects.projectId(req.Context())
+	if err != nil {
+		if req.Body != nil {
+			_ = req.Body.Close()
+		}
+		return nil, err
+	}
+	req = req.Clone(req.Context())
+	req.Header.Set("X-Project-Id", id)
+	return base.RoundTrip(req)
+}
+
+// projectIdInterceptor sets the "x-project-id" metadata of gRPC calls that have none from projects.
+func projectIdInterceptor(projects *projectIdSource) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		if md, _ := metadata.FromOutgoingContext(ctx); len(md.Get("x-project-id")) == 0 {
+			id, err := projects.projectId(ctx)
+			if err != nil {
+				return err
+			}
+			ctx = metadata.AppendToOutgoingContext(ctx, "x-project-id", id)
+		}
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+}
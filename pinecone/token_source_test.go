@@ -0,0 +1,335 @@
+package pinecone
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/pinecone-io/go-pinecone/v6/internal/gen/admin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// countingTokenSource returns "token-1", "token-2", ... each expiring after lifetime, if set.
+type countingTokenSource struct {
+	calls    atomic.Int32
+	lifetime time.Duration
+	now      func() time.Time
+	release  chan struct{}
+}
+
+func (s *countingTokenSource) Token(ctx context.Context) (*Token, error) {
+	if s.release != nil {
+		<-s.release
+	}
+	n := s.calls.Add(1)
+	token := &Token{AccessToken: fmt.Sprintf("token-%d", n)}
+	if s.lifetime > 0 {
+		token.Expiry = s.now().Add(s.lifetime)
+	}
+	return token, nil
+}
+
+// Unit tests:
+func TestReuseTokenSourceRefreshesBeforeExpiryUnit(t *testing.T) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	clock := func() time.Time { return now }
+	src := &countingTokenSource{lifetime: time.Hour, now: clock}
+	tokens := newReuseTokenSource(src)
+	tokens.now = clock
+
+	for range 3 {
+		token, err := tokens.token(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, "token-1", token.AccessToken)
+	}
+
+	now = now.Add(time.Hour - 2*time.Minute)
+	token, err := tokens.token(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "token-1", token.AccessToken, "the token should be reused until it nears expiry")
+
+	now = now.Add(90 * time.Second)
+	token, err = tokens.token(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "token-2", token.AccessToken, "the token should be refreshed within a minute of expiry")
+
+	// Short-lived tokens are refreshed halfway through their lifetime.
+	src.lifetime = 10 * time.Second
+	tokens.invalidate("token-2")
+	_, err = tokens.token(context.Background())
+	require.NoError(t, err)
+	now = now.Add(6 * time.Second)
+	token, err = tokens.token(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "token-4", token.AccessToken)
+}
+
+func TestReuseTokenSourceSingleFlightUnit(t *testing.T) {
+	src := &countingTokenSource{release: make(chan struct{})}
+	tokens := newReuseTokenSource(src)
+
+	var wg sync.WaitGroup
+	results := make([]string, 10)
+	for n := range results {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			token, err := tokens.token(context.Background())
+			if assert.NoError(t, err) {
+				results[n] = token.AccessToken
+			}
+		}()
+	}
+	time.Sleep(20 * time.Millisecond)
+	close(src.release)
+	wg.Wait()
+
+	assert.Equal(t, int32(1), src.calls.Load(), "concurrent callers should share one refresh")
+	for _, result := range results {
+		assert.Equal(t, "token-1", result)
+	}
+
+	// A stale invalidation after the token was already replaced does not trigger another refresh.
+	tokens.invalidate("token-0")
+	_, err := tokens.token(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, int32(1), src.calls.Load())
+}
+
+func TestReuseTokenSourceErrorsUnit(t *testing.T) {
+	tokens := newReuseTokenSource(TokenSourceFunc(func(ctx context.Context) (*Token, error) {
+		return nil, fmt.Errorf("vault unavailable")
+	}))
+	_, err := tokens.token(context.Background())
+	assert.ErrorContains(t, err, "vault unavailable")
+
+	tokens = newReuseTokenSource(StaticTokenSource(""))
+	_, err = tokens.token(context.Background())
+	assert.ErrorContains(t, err, "empty token")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	blocked := &countingTokenSource{release: make(chan struct{})}
+	defer close(blocked.release)
+	tokens = newReuseTokenSource(blocked)
+	_, err = tokens.token(ctx)
+	assert.ErrorIs(t, err, context.Canceled, "a cancelled caller should not wait for the refresh")
+}
+
+func TestTokenTransportRetriesUnauthorizedUnit(t *testing.T) {
+	var bodies []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		bodies = append(bodies, string(body))
+		if r.Header.Get("Authorization") != "Bearer token-2" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	src := &countingTokenSource{}
+	client := newTokenHTTPClient(newReuseTokenSource(src), nil)
+	res, err := client.Post(srv.URL, "application/json", strings.NewReader(`{"name":"p"}`))
+	require.NoError(t, err)
+	res.Body.Close()
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	assert.Equal(t, []string{`{"name":"p"}`, `{"name":"p"}`}, bodies, "the body should be replayed with the new token")
+	assert.Equal(t, int32(2), src.calls.Load())
+
+	// A source that keeps returning the rejected token is not retried.
+	bodies = nil
+	client = newTokenHTTPClient(newReuseTokenSource(StaticTokenSource("revoked")), nil)
+	res, err = client.Get(srv.URL)
+	require.NoError(t, err)
+	res.Body.Close()
+	assert.Equal(t, http.StatusUnauthorized, res.StatusCode)
+	assert.Len(t, bodies, 1)
+}
+
+func TestNewAdminClientWithTokenSourceUnit(t *testing.T) {
+	osAccessToken := os.Getenv("PINECONE_ACCESS_TOKEN")
+	os.Unsetenv("PINECONE_ACCESS_TOKEN")
+	defer os.Setenv("PINECONE_ACCESS_TOKEN", osAccessToken)
+
+	var authorization string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authorization = r.Header.Get("Authorization")
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"data":[]}`))
+	}))
+	defer srv.Close()
+
+	src := &countingTokenSource{}
+	adminClient, err := NewAdminClient(NewAdminClientParams{TokenSource: src, AccessToken: "ignored", Host: srv.URL})
+	require.NoError(t, err)
+	assert.Equal(t, int32(1), src.calls.Load(), "the client should authenticate when created")
+
+	_, err = adminClient.Project.List(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "Bearer token-1", authorization)
+	assert.Equal(t, int32(1), src.calls.Load())
+
+	_, err = NewAdminClient(NewAdminClientParams{
+		TokenSource: TokenSourceFunc(func(ctx context.Context) (*Token, error) { return nil, fmt.Errorf("denied") }),
+		Host:        srv.URL,
+	})
+	assert.ErrorContains(t, err, "denied")
+}
+
+// serviceAccountServer serves the Admin API project list with projects, and records the headers of the last
+// control plane request.
+func serviceAccountServer(t *testing.T, projects ...string) (*httptest.Server, *http.Header) {
+	t.Helper()
+	var last http.Header
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /admin/projects", func(w http.ResponseWriter, r *http.Request) {
+		data := make([]string, len(projects))
+		for n, id := range projects {
+			data[n] = fmt.Sprintf(`{"id":%q,"name":"project-%d"}`, id, n)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprintf(w, `{"data":[%s]}`, strings.Join(data, ","))
+	})
+	mux.HandleFunc("GET /indexes", func(w http.ResponseWriter, r *http.Request) {
+		last = r.Header.Clone()
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"indexes":[]}`))
+	})
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+	return srv, &last
+}
+
+func TestNewClientWithServiceAccountUnit(t *testing.T) {
+	for _, env := range []string{"PINECONE_API_KEY", "PINECONE_CLIENT_ID", "PINECONE_CLIENT_SECRET", "PINECONE_PROJECT_ID"} {
+		t.Setenv(env, "")
+	}
+	projectId := "0b6d6a6a-2b5f-4d07-9d3c-1f7f2a1d9a10"
+
+	t.Run("looks up the only project", func(t *testing.T) {
+		srv, last := serviceAccountServer(t, projectId)
+		src := &countingTokenSource{}
+		pc, err := NewClient(NewClientParams{TokenSource: src, Host: srv.URL})
+		require.NoError(t, err)
+		assert.Equal(t, int32(0), src.calls.Load(), "the client should not authenticate until the first request")
+
+		_, err = pc.ListIndexes(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, "Bearer token-1", last.Get("Authorization"))
+		assert.Equal(t, projectId, last.Get("X-Project-Id"))
+		assert.Equal(t, int32(1), src.calls.Load())
+
+		idx, err := pc.Index(NewIndexConnParams{Host: "my-index-host.io"})
+		require.NoError(t, err)
+		for key := range idx.additionalMetadata {
+			assert.NotEqual(t, "authorization", strings.ToLower(key), "gRPC requests should use per-RPC credentials")
+		}
+		var md metadata.MD
+		invoker := func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+			md, _ = metadata.FromOutgoingContext(ctx)
+			return nil
+		}
+		require.NoError(t, projectIdInterceptor(pc.projects)(context.Background(), "/test", nil, nil, nil, invoker))
+		assert.Equal(t, []string{projectId}, md.Get("x-project-id"))
+	})
+
+	t.Run("bounds the lookup with the first request's context", func(t *testing.T) {
+		srv, _ := serviceAccountServer(t, projectId)
+		release := make(chan struct{})
+		defer close(release)
+		src := TokenSourceFunc(func(ctx context.Context) (*Token, error) {
+			<-release
+			return nil, fmt.Errorf("released")
+		})
+		pc, err := NewClient(NewClientParams{TokenSource: src, Host: srv.URL})
+		require.NoError(t, err, "creating the client should not wait for a token")
+
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		defer cancel()
+		_, err = pc.ListIndexes(ctx)
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+
+	t.Run("uses the given project", func(t *testing.T) {
+		srv, last := serviceAccountServer(t)
+		pc, err := NewClient(NewClientParams{TokenSource: StaticTokenSource("jwt"), ProjectId: "my-project", Host: srv.URL})
+		require.NoError(t, err)
+		_, err = pc.ListIndexes(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, "Bearer jwt", last.Get("Authorization"))
+		assert.Equal(t, "my-project", last.Get("X-Project-Id"))
+	})
+
+	t.Run("requires a project when there are several", func(t *testing.T) {
+		srv, _ := serviceAccountServer(t, projectId, "5e1c8a8e-3a8b-4f43-9d1e-8f6f0c0d2b7e")
+		pc, err := NewClient(NewClientParams{TokenSource: StaticTokenSource("jwt"), Host: srv.URL})
+		require.NoError(t, err)
+		_, err = pc.ListIndexes(context.Background())
+		assert.ErrorContains(t, err, "access to 2 projects")
+	})
+
+	t.Run("requires both client credentials", func(t *testing.T) {
+		_, err := NewClient(NewClientParams{ClientId: "id"})
+		assert.ErrorContains(t, err, "both ClientId and ClientSecret")
+	})
+
+	t.Run("exchanges client credentials", func(t *testing.T) {
+		getAuthTokenFunc = func(ctx context.Context, id, secret string, opts ...admin.ClientOption) (*Token, error) {
+			assert.Equal(t, "id", id)
+			assert.Equal(t, "secret", secret)
+			return &Token{AccessToken: "exchanged"}, nil
+		}
+		defer func() { getAuthTokenFunc = getAuthToken }()
+
+		srv, last := serviceAccountServer(t)
+		pc, err := NewClient(NewClientParams{ClientId: "id", ClientSecret: "secret", ProjectId: "my-project", Host: srv.URL})
+		require.NoError(t, err)
+		_, err = pc.ListIndexes(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, "Bearer exchanged", last.Get("Authorization"))
+	})
+}
+
+func TestTokenCredentialsUnit(t *testing.T) {
+	src := &countingTokenSource{}
+	tokens := newReuseTokenSource(src)
+
+	creds := &tokenCredentials{tokens: tokens, requireTLS: true}
+	md, err := creds.GetRequestMetadata(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"authorization": "Bearer token-1"}, md)
+	assert.True(t, creds.RequireTransportSecurity())
+
+	var calls int
+	invoker := func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		calls++
+		if calls == 1 {
+			return status.Error(codes.Unauthenticated, "token expired")
+		}
+		return nil
+	}
+	err = tokenInterceptor(tokens)(context.Background(), "/Upsert", nil, nil, nil, invoker)
+	require.NoError(t, err)
+	assert.Equal(t, 2, calls, "an unauthenticated call should be retried with a new token")
+	assert.Equal(t, int32(2), src.calls.Load())
+
+	calls = 0
+	err = tokenInterceptor(newReuseTokenSource(StaticTokenSource("revoked")))(context.Background(), "/Upsert", nil, nil, nil, invoker)
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+	assert.Equal(t, 1, calls)
+}
@@ -0,0 +1,214 @@
+package pinecone
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// [UsageAccumulator] aggregates the usage reported by Pinecone responses ([Usage], [SearchUsage],
+// [RerankUsage], and [EmbedResponse] usage) across many calls, so cost can be attributed per operation,
+// namespace, and caller-supplied label (e.g. a tenant ID). It is safe for concurrent use.
+//
+// Attach an accumulator to a context with [WithUsageAccumulator], or to an [IndexConnection] with
+// [IndexConnection.WithUsageAccumulator]. Label calls with [WithUsageLabel]. Usage is recorded for the
+// following operations: "Fetch", "FetchByMetadata", "List", "Query", "SearchRecords", "Embed", and "Rerank".
+// Pinecone does not report write units in data plane responses, so WriteUnits is only populated through
+// [UsageAccumulator.Add].
+//
+// Example:
+//
+//	    usage := pinecone.NewUsageAccumulator()
+//	    idxConnection = idxConnection.WithUsageAccumulator(usage)
+//
+//	    ctx := pinecone.WithUsageLabel(context.Background(), "tenant-a")
+//	    res, err := idxConnection.QueryByVectorValues(ctx, &pinecone.QueryByVectorValuesRequest{
+//		       Vector: []float32{0.1, 0.2, 0.3},
+//		       TopK:   10,
+//	    })
+//
+//	    for _, entry := range usage.Snapshot() {
+//		       fmt.Printf("%s %s %s: %d read units\n", entry.Operation, entry.Namespace, entry.Label, entry.ReadUnits)
+//	    }
+type UsageAccumulator struct {
+	mu      sync.Mutex
+	entries map[UsageKey]UsageTotals
+}
+
+// [UsageKey] identifies a bucket of accumulated usage.
+//
+// Fields:
+//   - Operation: The SDK operation that consumed the usage, e.g. "Query" or "Embed".
+//   - Namespace: The namespace targeted by the operation. Empty for inference operations.
+//   - Label: The label attached to the context with [WithUsageLabel], if any.
+type UsageKey struct {
+	Operation string `json:"operation"`
+	Namespace string `json:"namespace"`
+	Label     string `json:"label"`
+}
+
+// [UsageTotals] holds accumulated usage for a [UsageKey].
+//
+// Fields:
+//   - Requests: The number of calls recorded.
+//   - ReadUnits: The read units consumed.
+//   - WriteUnits: The write units consumed.
+//   - EmbedTokens: The embedding tokens consumed.
+//   - RerankUnits: The rerank units consumed.
+type UsageTotals struct {
+	Requests    int64 `json:"requests"`
+	ReadUnits   int64 `json:"read_units"`
+	WriteUnits  int64 `json:"write_units"`
+	EmbedTokens int64 `json:"embed_tokens"`
+	RerankUnits int64 `json:"rerank_units"`
+}
+
+// [UsageEntry] is a single row of a [UsageAccumulator.Snapshot].
+type UsageEntry struct {
+	UsageKey
+	UsageTotals
+}
+
+// [NewUsageAccumulator] returns an empty [UsageAccumulator].
+func NewUsageAccumulator() *UsageAccumulator {
+	return &UsageAccumulator{entries: make(map[UsageKey]UsageTotals)}
+}
+
+// [UsageAccumulator.Add] adds totals to the bucket for key. Use it to record usage the SDK does not observe
+// directly, such as write units.
+func (a *UsageAccumulator) Add(key UsageKey, totals UsageTotals) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.entries == nil {
+		a.entries = make(map[UsageKey]UsageTotals)
+	}
+	cur := a.entries[key]
+	cur.Requests += totals.Requests
+	cur.ReadUnits += totals.ReadUnits
+	cur.WriteUnits += totals.WriteUnits
+	cur.EmbedTokens += totals.EmbedTokens
+	cur.RerankUnits += totals.RerankUnits
+	a.entries[key] = cur
+}
+
+// [UsageAccumulator.Snapshot] returns a copy of the accumulated usage, sorted by operation, namespace, and label.
+func (a *UsageAccumulator) Snapshot() []UsageEntry {
+	a.mu.Lock()
+	entries := make([]UsageEntry, 0, len(a.entries))
+	for k, v := range a.entries {
+		entries = append(entries, UsageEntry{UsageKey: k, UsageTotals: v})
+	}
+	a.mu.Unlock()
+
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].Operation != entries[j].Operation {
+			return entries[i].Operation < entries[j].Operation
+		}
+		if entries[i].Namespace != entries[j].Namespace {
+			return entries[i].Namespace < entries[j].Namespace
+		}
+		return entries[i].Label < entries[j].Label
+	})
+	return entries
+}
+
+// [UsageAccumulator.Total] returns the usage summed across every bucket.
+func (a *UsageAccumulator) Total() UsageTotals {
+	var total UsageTotals
+	for _, e := range a.Snapshot() {
+		total.Requests += e.Requests
+		total.ReadUnits += e.ReadUnits
+		total.WriteUnits += e.WriteUnits
+		total.EmbedTokens += e.EmbedTokens
+		total.RerankUnits += e.RerankUnits
+	}
+	return total
+}
+
+// [UsageAccumulator.Reset] discards all accumulated usage.
+func (a *UsageAccumulator) Reset() {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.entries = make(map[UsageKey]UsageTotals)
+}
+
+// [UsageAccumulator.WritePrometheus] writes the accumulated usage in the Prometheus text exposition format,
+// as counters named pinecone_requests_total, pinecone_read_units_total, pinecone_write_units_total,
+// pinecone_embed_tokens_total, and pinecone_rerank_units_total, labeled by operation, namespace, and label.
+// Serve the output from a /metrics handler, or parse it into your own collector.
+func (a *UsageAccumulator) WritePrometheus(w io.Writer) error {
+	entries := a.Snapshot()
+	metrics := []struct {
+		name  string
+		help  string
+		value func(UsageTotals) int64
+	}{
+		{"pinecone_requests_total", "Pinecone requests that reported usage.", func(t UsageTotals) int64 { return t.Requests }},
+		{"pinecone_read_units_total", "Pinecone read units consumed.", func(t UsageTotals) int64 { return t.ReadUnits }},
+		{"pinecone_write_units_total", "Pinecone write units consumed.", func(t UsageTotals) int64 { return t.WriteUnits }},
+		{"pinecone_embed_tokens_total", "Pinecone embedding tokens consumed.", func(t UsageTotals) int64 { return t.EmbedTokens }},
+		{"pinecone_rerank_units_total", "Pinecone rerank units consumed.", func(t UsageTotals) int64 { return t.RerankUnits }},
+	}
+	for _, m := range metrics {
+		if _, err := fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s counter\n", m.name, m.help, m.name); err != nil {
+			return err
+		}
+		for _, e := range entries {
+			_, err := fmt.Fprintf(w, "%s{operation=\"%s\",namespace=\"%s\",label=\"%s\"} %d\n",
+				m.name, promEscape(e.Operation), promEscape(e.Namespace), promEscape(e.Label), m.value(e.UsageTotals))
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+var promLabelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+func promEscape(s string) string {
+	return promLabelEscaper.Replace(s)
+}
+
+type usageAccumulatorKey struct{}
+type usageLabelKey struct{}
+
+// [WithUsageAccumulator] returns a copy of ctx that records the usage of every call made with it into acc.
+// This applies to [IndexConnection] and [InferenceService] calls, in addition to any accumulator attached
+// with [IndexConnection.WithUsageAccumulator].
+func WithUsageAccumulator(ctx context.Context, acc *UsageAccumulator) context.Context {
+	return context.WithValue(ctx, usageAccumulatorKey{}, acc)
+}
+
+// [WithUsageLabel] returns a copy of ctx whose calls are accumulated under label, e.g. a tenant ID.
+func WithUsageLabel(ctx context.Context, label string) context.Context {
+	return context.WithValue(ctx, usageLabelKey{}, label)
+}
+
+// recordUsage adds one request's usage to the accumulator attached to ctx and, if different, to fallback.
+func recordUsage(ctx context.Context, fallback *UsageAccumulator, operation, namespace string, totals UsageTotals) {
+	fromCtx, _ := ctx.Value(usageAccumulatorKey{}).(*UsageAccumulator)
+	if fromCtx == nil && fallback == nil {
+		return
+	}
+	label, _ := ctx.Value(usageLabelKey{}).(string)
+	key := UsageKey{Operation: operation, Namespace: namespace, Label: label}
+	totals.Requests = 1
+	if fromCtx != nil {
+		fromCtx.Add(key, totals)
+	}
+	if fallback != nil && fallback != fromCtx {
+		fallback.Add(key, totals)
+	}
+}
+
+// readUnitsOf returns the read units in u, or 0 if u is nil.
+func readUnitsOf(u *Usage) UsageTotals {
+	if u == nil {
+		return UsageTotals{}
+	}
+	return UsageTotals{ReadUnits: int64(u.ReadUnits)}
+}
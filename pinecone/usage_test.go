@@ -0,0 +1,123 @@
+package pinecone
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Unit tests:
+func TestUsageAccumulatorAddSnapshotUnit(t *testing.T) {
+	acc := NewUsageAccumulator()
+	acc.Add(UsageKey{Operation: "Query", Namespace: "ns2"}, UsageTotals{Requests: 1, ReadUnits: 5})
+	acc.Add(UsageKey{Operation: "Query", Namespace: "ns1", Label: "b"}, UsageTotals{Requests: 1, ReadUnits: 2})
+	acc.Add(UsageKey{Operation: "Query", Namespace: "ns1", Label: "a"}, UsageTotals{Requests: 1, ReadUnits: 3})
+	acc.Add(UsageKey{Operation: "Query", Namespace: "ns1", Label: "a"}, UsageTotals{Requests: 1, ReadUnits: 4})
+	acc.Add(UsageKey{Operation: "Embed"}, UsageTotals{Requests: 1, EmbedTokens: 100})
+
+	snapshot := acc.Snapshot()
+	require.Len(t, snapshot, 4)
+	assert.Equal(t, UsageKey{Operation: "Embed"}, snapshot[0].UsageKey)
+	assert.Equal(t, UsageKey{Operation: "Query", Namespace: "ns1", Label: "a"}, snapshot[1].UsageKey)
+	assert.Equal(t, UsageTotals{Requests: 2, ReadUnits: 7}, snapshot[1].UsageTotals)
+	assert.Equal(t, UsageKey{Operation: "Query", Namespace: "ns2"}, snapshot[3].UsageKey)
+
+	assert.Equal(t, UsageTotals{Requests: 5, ReadUnits: 14, EmbedTokens: 100}, acc.Total())
+
+	acc.Reset()
+	assert.Empty(t, acc.Snapshot())
+}
+
+func TestUsageAccumulatorConcurrentAddUnit(t *testing.T) {
+	acc := NewUsageAccumulator()
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			acc.Add(UsageKey{Operation: "Fetch"}, UsageTotals{Requests: 1, ReadUnits: 1})
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, UsageTotals{Requests: 50, ReadUnits: 50}, acc.Total())
+}
+
+func TestUsageAccumulatorWritePrometheusUnit(t *testing.T) {
+	acc := NewUsageAccumulator()
+	acc.Add(UsageKey{Operation: "Query", Namespace: "ns", Label: `tenant "a"`}, UsageTotals{Requests: 1, ReadUnits: 6})
+
+	var sb strings.Builder
+	require.NoError(t, acc.WritePrometheus(&sb))
+	out := sb.String()
+
+	assert.Contains(t, out, "# TYPE pinecone_read_units_total counter\n")
+	assert.Contains(t, out, `pinecone_read_units_total{operation="Query",namespace="ns",label="tenant \"a\""} 6`)
+	assert.Contains(t, out, `pinecone_requests_total{operation="Query",namespace="ns",label="tenant \"a\""} 1`)
+	assert.Contains(t, out, `pinecone_write_units_total{operation="Query",namespace="ns",label="tenant \"a\""} 0`)
+}
+
+func TestRecordUsageContextAndConnectionUnit(t *testing.T) {
+	fromCtx := NewUsageAccumulator()
+	fromConn := NewUsageAccumulator()
+
+	// Nothing attached: a no-op.
+	recordUsage(context.Background(), nil, "Query", "ns", UsageTotals{ReadUnits: 1})
+
+	ctx := WithUsageLabel(WithUsageAccumulator(context.Background(), fromCtx), "tenant-a")
+	recordUsage(ctx, fromConn, "Query", "ns", UsageTotals{ReadUnits: 3})
+
+	want := []UsageEntry{{
+		UsageKey:    UsageKey{Operation: "Query", Namespace: "ns", Label: "tenant-a"},
+		UsageTotals: UsageTotals{Requests: 1, ReadUnits: 3},
+	}}
+	assert.Equal(t, want, fromCtx.Snapshot())
+	assert.Equal(t, want, fromConn.Snapshot())
+
+	// The same accumulator attached both ways is only counted once.
+	recordUsage(WithUsageAccumulator(context.Background(), fromConn), fromConn, "Query", "ns", UsageTotals{ReadUnits: 1})
+	assert.Equal(t, int64(2), fromConn.Total().Requests)
+}
+
+func TestInferenceUsageRecordedUnit(t *testing.T) {
+	pc, err := NewClient(NewClientParams{
+		ApiKey: "test-key",
+		RestClient: &http.Client{
+			Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+				if strings.HasSuffix(req.URL.Path, "/embed") {
+					return mockResponse(`{"model":"m","vector_type":"dense","data":[{"values":[0.1]}],"usage":{"total_tokens":7}}`, http.StatusOK), nil
+				}
+				return mockResponse(`{"model":"r","data":[{"index":0,"score":0.5}],"usage":{"rerank_units":1}}`, http.StatusOK), nil
+			}),
+		},
+	})
+	require.NoError(t, err)
+
+	acc := NewUsageAccumulator()
+	ctx := WithUsageLabel(WithUsageAccumulator(context.Background(), acc), "tenant-b")
+
+	_, err = pc.Inference.Embed(ctx, &EmbedRequest{Model: "m", TextInputs: []string{"hello"}})
+	require.NoError(t, err)
+	_, err = pc.Inference.Rerank(ctx, &RerankRequest{Model: "r", Query: "q", Documents: []Document{{"text": "doc"}}})
+	require.NoError(t, err)
+
+	snapshot := acc.Snapshot()
+	require.Len(t, snapshot, 2)
+	assert.Equal(t, UsageKey{Operation: "Embed", Label: "tenant-b"}, snapshot[0].UsageKey)
+	assert.Equal(t, int64(7), snapshot[0].EmbedTokens)
+	assert.Equal(t, UsageKey{Operation: "Rerank", Label: "tenant-b"}, snapshot[1].UsageKey)
+	assert.Equal(t, int64(1), snapshot[1].RerankUnits)
+}
+
+func TestIndexConnectionWithUsageAccumulatorUnit(t *testing.T) {
+	acc := NewUsageAccumulator()
+	idx := &IndexConnection{namespace: "ns"}
+	tracked := idx.WithUsageAccumulator(acc)
+	assert.Same(t, acc, tracked.usage)
+	assert.Nil(t, idx.usage, "the original connection should be unchanged")
+	assert.Same(t, acc, tracked.WithNamespace("other").usage, "WithNamespace should keep the accumulator")
+}
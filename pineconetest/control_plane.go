@@ -0,0 +1,211 @@
+package pineconetest
+
+import (
+	"context"
+
+	"github.com/pinecone-io/go-pinecone/v6/pinecone"
+)
+
+// [ControlPlane] is a stub [pinecone.ControlPlane]. Each method calls the function in the field of the same
+// name with a Func suffix, or returns an error wrapping [ErrNotStubbed] if the field is nil.
+type ControlPlane struct {
+	ListIndexesFunc           func(ctx context.Context, opts ...pinecone.CallOption) ([]*pinecone.Index, error)
+	CreatePodIndexFunc        func(ctx context.Context, in *pinecone.CreatePodIndexRequest, opts ...pinecone.CallOption) (*pinecone.Index, error)
+	CreateServerlessIndexFunc func(ctx context.Context, in *pinecone.CreateServerlessIndexRequest, opts ...pinecone.CallOption) (*pinecone.Index, error)
+	CreateIndexForModelFunc   func(ctx context.Context, in *pinecone.CreateIndexForModelRequest, opts ...pinecone.CallOption) (*pinecone.Index, error)
+	CreateBYOCIndexFunc       func(ctx context.Context, in *pinecone.CreateBYOCIndexRequest, opts ...pinecone.CallOption) (*pinecone.Index, error)
+	DescribeIndexFunc         func(ctx context.Context, idxName string, opts ...pinecone.CallOption) (*pinecone.Index, error)
+	DeleteIndexFunc           func(ctx context.Context, idxName string, opts ...pinecone.CallOption) error
+	ConfigureIndexFunc        func(ctx context.Context, name string, in pinecone.ConfigureIndexParams, opts ...pinecone.CallOption) (*pinecone.Index, error)
+	ListCollectionsFunc       func(ctx context.Context, opts ...pinecone.CallOption) ([]*pinecone.Collection, error)
+	DescribeCollectionFunc    func(ctx context.Context, collectionName string, opts ...pinecone.CallOption) (*pinecone.Collection, error)
+	CreateCollectionFunc      func(ctx context.Context, in *pinecone.CreateCollectionRequest, opts ...pinecone.CallOption) (*pinecone.Collection, error)
+	DeleteCollectionFunc      func(ctx context.Context, collectionName string, opts ...pinecone.CallOption) error
+	CreateBackupFunc          func(ctx context.Context, in *pinecone.CreateBackupParams, opts ...pinecone.CallOption) (*pinecone.Backup, error)
+	CreateIndexFromBackupFunc func(ctx context.Context, in *pinecone.CreateIndexFromBackupParams, opts ...pinecone.CallOption) (*pinecone.CreateIndexFromBackupResponse, error)
+	DescribeBackupFunc        func(ctx context.Context, backupId string, opts ...pinecone.CallOption) (*pinecone.Backup, error)
+	ListBackupsFunc           func(ctx context.Context, in *pinecone.ListBackupsParams, opts ...pinecone.CallOption) (*pinecone.BackupList, error)
+	DeleteBackupFunc          func(ctx context.Context, backupId string, opts ...pinecone.CallOption) error
+	DescribeRestoreJobFunc    func(ctx context.Context, restoreJobId string, opts ...pinecone.CallOption) (*pinecone.RestoreJob, error)
+	ListRestoreJobsFunc       func(ctx context.Context, in *pinecone.ListRestoreJobsParams, opts ...pinecone.CallOption) (*pinecone.RestoreJobList, error)
+
+	calls recorder
+}
+
+var _ pinecone.ControlPlane = (*ControlPlane)(nil)
+
+// [ControlPlane.ListIndexes] calls ListIndexesFunc, or returns [ErrNotStubbed] if it is nil.
+func (f *ControlPlane) ListIndexes(ctx context.Context, opts ...pinecone.CallOption) ([]*pinecone.Index, error) {
+	f.calls.record("ListIndexes")
+	if f.ListIndexesFunc == nil {
+		return nil, notStubbed("ControlPlane.ListIndexes")
+	}
+	return f.ListIndexesFunc(ctx, opts...)
+}
+
+// [ControlPlane.CreatePodIndex] calls CreatePodIndexFunc, or returns [ErrNotStubbed] if it is nil.
+func (f *ControlPlane) CreatePodIndex(ctx context.Context, in *pinecone.CreatePodIndexRequest, opts ...pinecone.CallOption) (*pinecone.Index, error) {
+	f.calls.record("CreatePodIndex")
+	if f.CreatePodIndexFunc == nil {
+		return nil, notStubbed("ControlPlane.CreatePodIndex")
+	}
+	return f.CreatePodIndexFunc(ctx, in, opts...)
+}
+
+// [ControlPlane.CreateServerlessIndex] calls CreateServerlessIndexFunc, or returns [ErrNotStubbed] if it is nil.
+func (f *ControlPlane) CreateServerlessIndex(ctx context.Context, in *pinecone.CreateServerlessIndexRequest, opts ...pinecone.CallOption) (*pinecone.Index, error) {
+	f.calls.record("CreateServerlessIndex")
+	if f.CreateServerlessIndexFunc == nil {
+		return nil, notStubbed("ControlPlane.CreateServerlessIndex")
+	}
+	return f.CreateServerlessIndexFunc(ctx, in, opts...)
+}
+
+// [ControlPlane.CreateIndexForModel] calls CreateIndexForModelFunc, or returns [ErrNotStubbed] if it is nil.
+func (f *ControlPlane) CreateIndexForModel(ctx context.Context, in *pinecone.CreateIndexForModelRequest, opts ...pinecone.CallOption) (*pinecone.Index, error) {
+	f.calls.record("CreateIndexForModel")
+	if f.CreateIndexForModelFunc == nil {
+		return nil, notStubbed("ControlPlane.CreateIndexForModel")
+	}
+	return f.CreateIndexForModelFunc(ctx, in, opts...)
+}
+
+// [ControlPlane.CreateBYOCIndex] calls CreateBYOCIndexFunc, or returns [ErrNotStubbed] if it is nil.
+func (f *ControlPlane) CreateBYOCIndex(ctx context.Context, in *pinecone.CreateBYOCIndexRequest, opts ...pinecone.CallOption) (*pinecone.Index, error) {
+	f.calls.record("CreateBYOCIndex")
+	if f.CreateBYOCIndexFunc == nil {
+		return nil, notStubbed("ControlPlane.CreateBYOCIndex")
+	}
+	return f.CreateBYOCIndexFunc(ctx, in, opts...)
+}
+
+// [ControlPlane.DescribeIndex] calls DescribeIndexFunc, or returns [ErrNotStubbed] if it is nil.
+func (f *ControlPlane) DescribeIndex(ctx context.Context, idxName string, opts ...pinecone.CallOption) (*pinecone.Index, error) {
+	f.calls.record("DescribeIndex")
+	if f.DescribeIndexFunc == nil {
+		return nil, notStubbed("ControlPlane.DescribeIndex")
+	}
+	return f.DescribeIndexFunc(ctx, idxName, opts...)
+}
+
+// [ControlPlane.DeleteIndex] calls DeleteIndexFunc, or returns [ErrNotStubbed] if it is nil.
+func (f *ControlPlane) DeleteIndex(ctx context.Context, idxName string, opts ...pinecone.CallOption) error {
+	f.calls.record("DeleteIndex")
+	if f.DeleteIndexFunc == nil {
+		return notStubbed("ControlPlane.DeleteIndex")
+	}
+	return f.DeleteIndexFunc(ctx, idxName, opts...)
+}
+
+// [ControlPlane.ConfigureIndex] calls ConfigureIndexFunc, or returns [ErrNotStubbed] if it is nil.
+func (f *ControlPlane) ConfigureIndex(ctx context.Context, name string, in pinecone.ConfigureIndexParams, opts ...pinecone.CallOption) (*pinecone.Index, error) {
+	f.calls.record("ConfigureIndex")
+	if f.ConfigureIndexFunc == nil {
+		return nil, notStubbed("ControlPlane.ConfigureIndex")
+	}
+	return f.ConfigureIndexFunc(ctx, name, in, opts...)
+}
+
+// [ControlPlane.ListCollections] calls ListCollectionsFunc, or returns [ErrNotStubbed] if it is nil.
+func (f *ControlPlane) ListCollections(ctx context.Context, opts ...pinecone.CallOption) ([]*pinecone.Collection, error) {
+	f.calls.record("ListCollections")
+	if f.ListCollectionsFunc == nil {
+		return nil, notStubbed("ControlPlane.ListCollections")
+	}
+	return f.ListCollectionsFunc(ctx, opts...)
+}
+
+// [ControlPlane.DescribeCollection] calls DescribeCollectionFunc, or returns [ErrNotStubbed] if it is nil.
+func (f *ControlPlane) DescribeCollection(ctx context.Context, collectionName string, opts ...pinecone.CallOption) (*pinecone.Collection, error) {
+	f.calls.record("DescribeCollection")
+	if f.DescribeCollectionFunc == nil {
+		return nil, notStubbed("ControlPlane.DescribeCollection")
+	}
+	return f.DescribeCollectionFunc(ctx, collectionName, opts...)
+}
+
+// [ControlPlane.CreateCollection] calls CreateCollectionFunc, or returns [ErrNotStubbed] if it is nil.
+func (f *ControlPlane) CreateCollection(ctx context.Context, in *pinecone.CreateCollectionRequest, opts ...pinecone.CallOption) (*pinecone.Collection, error) {
+	f.calls.record("CreateCollection")
+	if f.CreateCollectionFunc == nil {
+		return nil, notStubbed("ControlPlane.CreateCollection")
+	}
+	return f.CreateCollectionFunc(ctx, in, opts...)
+}
+
+// [ControlPlane.DeleteCollection] calls DeleteCollectionFunc, or returns [ErrNotStubbed] if it is nil.
+func (f *ControlPlane) DeleteCollection(ctx context.Context, collectionName string, opts ...pinecone.CallOption) error {
+	f.calls.record("DeleteCollection")
+	if f.DeleteCollectionFunc == nil {
+		return notStubbed("ControlPlane.DeleteCollection")
+	}
+	return f.DeleteCollectionFunc(ctx, collectionName, opts...)
+}
+
+// [ControlPlane.CreateBackup] calls CreateBackupFunc, or returns [ErrNotStubbed] if it is nil.
+func (f *ControlPlane) CreateBackup(ctx context.Context, in *pinecone.CreateBackupParams, opts ...pinecone.CallOption) (*pinecone.Backup, error) {
+	f.calls.record("CreateBackup")
+	if f.CreateBackupFunc == nil {
+		return nil, notStubbed("ControlPlane.CreateBackup")
+	}
+	return f.CreateBackupFunc(ctx, in, opts...)
+}
+
+// [ControlPlane.CreateIndexFromBackup] calls CreateIndexFromBackupFunc, or returns [ErrNotStubbed] if it is nil.
+func (f *ControlPlane) CreateIndexFromBackup(ctx context.Context, in *pinecone.CreateIndexFromBackupParams, opts ...pinecone.CallOption) (*pinecone.CreateIndexFromBackupResponse, error) {
+	f.calls.record("CreateIndexFromBackup")
+	if f.CreateIndexFromBackupFunc == nil {
+		return nil, notStubbed("ControlPlane.CreateIndexFromBackup")
+	}
+	return f.CreateIndexFromBackupFunc(ctx, in, opts...)
+}
+
+// [ControlPlane.DescribeBackup] calls DescribeBackupFunc, or returns [ErrNotStubbed] if it is nil.
+func (f *ControlPlane) DescribeBackup(ctx context.Context, backupId string, opts ...pinecone.CallOption) (*pinecone.Backup, error) {
+	f.calls.record("DescribeBackup")
+	if f.DescribeBackupFunc == nil {
+		return nil, notStubbed("ControlPlane.DescribeBackup")
+	}
+	return f.DescribeBackupFunc(ctx, backupId, opts...)
+}
+
+// [ControlPlane.ListBackups] calls ListBackupsFunc, or returns [ErrNotStubbed] if it is nil.
+func (f *ControlPlane) ListBackups(ctx context.Context, in *pinecone.ListBackupsParams, opts ...pinecone.CallOption) (*pinecone.BackupList, error) {
+	f.calls.record("ListBackups")
+	if f.ListBackupsFunc == nil {
+		return nil, notStubbed("ControlPlane.ListBackups")
+	}
+	return f.ListBackupsFunc(ctx, in, opts...)
+}
+
+// [ControlPlane.DeleteBackup] calls DeleteBackupFunc, or returns [ErrNotStubbed] if it is nil.
+func (f *ControlPlane) DeleteBackup(ctx context.Context, backupId string, opts ...pinecone.CallOption) error {
+	f.calls.record("DeleteBackup")
+	if f.DeleteBackupFunc == nil {
+		return notStubbed("ControlPlane.DeleteBackup")
+	}
+	return f.DeleteBackupFunc(ctx, backupId, opts...)
+}
+
+// [ControlPlane.DescribeRestoreJob] calls DescribeRestoreJobFunc, or returns [ErrNotStubbed] if it is nil.
+func (f *ControlPlane) DescribeRestoreJob(ctx context.Context, restoreJobId string, opts ...pinecone.CallOption) (*pinecone.RestoreJob, error) {
+	f.calls.record("DescribeRestoreJob")
+	if f.DescribeRestoreJobFunc == nil {
+		return nil, notStubbed("ControlPlane.DescribeRestoreJob")
+	}
+	return f.DescribeRestoreJobFunc(ctx, restoreJobId, opts...)
+}
+
+// [ControlPlane.ListRestoreJobs] calls ListRestoreJobsFunc, or returns [ErrNotStubbed] if it is nil.
+func (f *ControlPlane) ListRestoreJobs(ctx context.Context, in *pinecone.ListRestoreJobsParams, opts ...pinecone.CallOption) (*pinecone.RestoreJobList, error) {
+	f.calls.record("ListRestoreJobs")
+	if f.ListRestoreJobsFunc == nil {
+		return nil, notStubbed("ControlPlane.ListRestoreJobs")
+	}
+	return f.ListRestoreJobsFunc(ctx, in, opts...)
+}
+
+// [ControlPlane.Calls] returns the names of the methods called so far, in order.
+func (f *ControlPlane) Calls() []string {
+	return f.calls.list()
+}
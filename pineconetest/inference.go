@@ -0,0 +1,92 @@
+package pineconetest
+
+import (
+	"context"
+
+	"github.com/pinecone-io/go-pinecone/v6/pinecone"
+)
+
+// [Inference] is a stub [pinecone.Inference]. Each method calls the function in the field of the same name
+// with a Func suffix, or returns an error wrapping [ErrNotStubbed] if the field is nil. See [FakeEmbed]
+// and [FakeRerank] for deterministic implementations of EmbedFunc and RerankFunc.
+type Inference struct {
+	EmbedFunc              func(ctx context.Context, in *pinecone.EmbedRequest, opts ...pinecone.CallOption) (*pinecone.EmbedResponse, error)
+	EmbedAllFunc           func(ctx context.Context, in *pinecone.EmbedAllRequest, opts ...pinecone.CallOption) (*pinecone.EmbedResponse, error)
+	RerankFunc             func(ctx context.Context, in *pinecone.RerankRequest, opts ...pinecone.CallOption) (*pinecone.RerankResponse, error)
+	RerankAllFunc          func(ctx context.Context, in *pinecone.RerankAllRequest, opts ...pinecone.CallOption) (*pinecone.RerankResponse, error)
+	DescribeModelFunc      func(ctx context.Context, modelName string, opts ...pinecone.CallOption) (*pinecone.ModelInfo, error)
+	ListModelsFunc         func(ctx context.Context, in *pinecone.ListModelsParams, opts ...pinecone.CallOption) (*pinecone.ModelInfoList, error)
+	ValidateParametersFunc func(ctx context.Context, model string, params map[string]any) (map[string]any, error)
+
+	calls recorder
+}
+
+var _ pinecone.Inference = (*Inference)(nil)
+
+// [Inference.Embed] calls EmbedFunc, or returns [ErrNotStubbed] if it is nil.
+func (f *Inference) Embed(ctx context.Context, in *pinecone.EmbedRequest, opts ...pinecone.CallOption) (*pinecone.EmbedResponse, error) {
+	f.calls.record("Embed")
+	if f.EmbedFunc == nil {
+		return nil, notStubbed("Inference.Embed")
+	}
+	return f.EmbedFunc(ctx, in, opts...)
+}
+
+// [Inference.EmbedAll] calls EmbedAllFunc, or returns [ErrNotStubbed] if it is nil.
+func (f *Inference) EmbedAll(ctx context.Context, in *pinecone.EmbedAllRequest, opts ...pinecone.CallOption) (*pinecone.EmbedResponse, error) {
+	f.calls.record("EmbedAll")
+	if f.EmbedAllFunc == nil {
+		return nil, notStubbed("Inference.EmbedAll")
+	}
+	return f.EmbedAllFunc(ctx, in, opts...)
+}
+
+// [Inference.Rerank] calls RerankFunc, or returns [ErrNotStubbed] if it is nil.
+func (f *Inference) Rerank(ctx context.Context, in *pinecone.RerankRequest, opts ...pinecone.CallOption) (*pinecone.RerankResponse, error) {
+	f.calls.record("Rerank")
+	if f.RerankFunc == nil {
+		return nil, notStubbed("Inference.Rerank")
+	}
+	return f.RerankFunc(ctx, in, opts...)
+}
+
+// [Inference.RerankAll] calls RerankAllFunc, or returns [ErrNotStubbed] if it is nil.
+func (f *Inference) RerankAll(ctx context.Context, in *pinecone.RerankAllRequest, opts ...pinecone.CallOption) (*pinecone.RerankResponse, error) {
+	f.calls.record("RerankAll")
+	if f.RerankAllFunc == nil {
+		return nil, notStubbed("Inference.RerankAll")
+	}
+	return f.RerankAllFunc(ctx, in, opts...)
+}
+
+// [Inference.DescribeModel] calls DescribeModelFunc, or returns [ErrNotStubbed] if it is nil.
+func (f *Inference) DescribeModel(ctx context.Context, modelName string, opts ...pinecone.CallOption) (*pinecone.ModelInfo, error) {
+	f.calls.record("DescribeModel")
+	if f.DescribeModelFunc == nil {
+		return nil, notStubbed("Inference.DescribeModel")
+	}
+	return f.DescribeModelFunc(ctx, modelName, opts...)
+}
+
+// [Inference.ListModels] calls ListModelsFunc, or returns [ErrNotStubbed] if it is nil.
+func (f *Inference) ListModels(ctx context.Context, in *pinecone.ListModelsParams, opts ...pinecone.CallOption) (*pinecone.ModelInfoList, error) {
+	f.calls.record("ListModels")
+	if f.ListModelsFunc == nil {
+		return nil, notStubbed("Inference.ListModels")
+	}
+	return f.ListModelsFunc(ctx, in, opts...)
+}
+
+// [Inference.ValidateParameters] calls ValidateParametersFunc, or returns [ErrNotStubbed] if it is nil.
+func (f *Inference) ValidateParameters(ctx context.Context, model string, params map[string]any) (map[string]any, error) {
+	f.calls.record("ValidateParameters")
+	if f.ValidateParametersFunc == nil {
+		return nil, notStubbed("Inference.ValidateParameters")
+	}
+	return f.ValidateParametersFunc(ctx, model, params)
+}
+
+// [Inference.Calls] returns the names of the methods called so far, in order.
+func (f *Inference) Calls() []string {
+	return f.calls.list()
+}
@@ -0,0 +1,151 @@
+// Package pineconetest provides stubs of the [pinecone.VectorStore], [pinecone.ControlPlane], and
+// [pinecone.Inference] interfaces for testing code that uses the Pinecone SDK without network access.
+//
+// Each stub has a function field per method, named after the method with a Func suffix. Set the fields a test
+// needs; calling a method whose field is nil returns an error wrapping [ErrNotStubbed]. Every stub records the
+// names of the methods called on it, returned by its Calls method.
+//
+// Example:
+//
+//	    inference := &pineconetest.Inference{
+//		       EmbedFunc: pineconetest.FakeEmbed(3),
+//		       RerankFunc: func(ctx context.Context, in *pinecone.RerankRequest, opts ...pinecone.CallOption) (*pinecone.RerankResponse, error) {
+//			       return nil, &pinecone.PineconeError{Code: 429, Msg: errors.New("rate limited")}
+//		       },
+//	    }
+//	    svc := NewSearchService(inference) // accepts a pinecone.Inference
+//
+// For a working in-memory [pinecone.VectorStore], use the localindex package.
+package pineconetest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"math"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/pinecone-io/go-pinecone/v6/pinecone"
+)
+
+// [ErrNotStubbed] is wrapped by the error returned when a stub method is called without its Func field set.
+var ErrNotStubbed = errors.New("method not stubbed")
+
+func notStubbed(method string) error {
+	return fmt.Errorf("pineconetest: %s: %w", method, ErrNotStubbed)
+}
+
+// recorder records the names of the methods called on a stub.
+type recorder struct {
+	mu    sync.Mutex
+	calls []string
+}
+
+func (r *recorder) record(method string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.calls = append(r.calls, method)
+}
+
+func (r *recorder) list() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]string(nil), r.calls...)
+}
+
+// [FakeEmbed] returns an [Inference.EmbedFunc] producing deterministic dense embeddings of the given dimension:
+// each input's words are hashed into the dimensions and the result is normalized, so inputs sharing words
+// have a positive cosine similarity. Usage.TotalTokens is the total number of words.
+func FakeEmbed(dimension int) func(ctx context.Context, in *pinecone.EmbedRequest, opts ...pinecone.CallOption) (*pinecone.EmbedResponse, error) {
+	return func(ctx context.Context, in *pinecone.EmbedRequest, opts ...pinecone.CallOption) (*pinecone.EmbedResponse, error) {
+		if in == nil {
+			return nil, fmt.Errorf("in (*EmbedRequest) cannot be nil")
+		}
+		if dimension < 1 {
+			return nil, fmt.Errorf("dimension must be at least 1")
+		}
+		res := &pinecone.EmbedResponse{Model: in.Model, VectorType: "dense", Data: make([]pinecone.Embedding, len(in.TextInputs))}
+		var tokens int32
+		for n, text := range in.TextInputs {
+			values := make([]float32, dimension)
+			words := strings.Fields(strings.ToLower(text))
+			for _, word := range words {
+				values[hash(word)%uint32(dimension)]++
+			}
+			normalize(values)
+			tokens += int32(len(words))
+			res.Data[n] = pinecone.Embedding{DenseEmbedding: &pinecone.DenseEmbedding{VectorType: "dense", Values: values}}
+		}
+		res.Usage.TotalTokens = &tokens
+		return res, nil
+	}
+}
+
+// [FakeRerank] is an [Inference.RerankFunc] that scores each document by the fraction of the query's words
+// found in its rank fields (by default "text"), and returns the documents ordered by score, honoring TopN and
+// ReturnDocuments. Usage.RerankUnits is 1.
+func FakeRerank(ctx context.Context, in *pinecone.RerankRequest, opts ...pinecone.CallOption) (*pinecone.RerankResponse, error) {
+	if in == nil {
+		return nil, fmt.Errorf("in (*RerankRequest) cannot be nil")
+	}
+	rankFields := []string{"text"}
+	if in.RankFields != nil && len(*in.RankFields) > 0 {
+		rankFields = *in.RankFields
+	}
+	queryWords := strings.Fields(strings.ToLower(in.Query))
+
+	res := &pinecone.RerankResponse{Model: in.Model, Data: make([]pinecone.RankedDocument, len(in.Documents))}
+	for n, doc := range in.Documents {
+		words := make(map[string]bool)
+		for _, field := range rankFields {
+			for _, word := range strings.Fields(strings.ToLower(fmt.Sprint(doc[field]))) {
+				words[word] = true
+			}
+		}
+		var found int
+		for _, word := range queryWords {
+			if words[word] {
+				found++
+			}
+		}
+		ranked := pinecone.RankedDocument{Index: n}
+		if len(queryWords) > 0 {
+			ranked.Score = float32(found) / float32(len(queryWords))
+		}
+		if in.ReturnDocuments == nil || *in.ReturnDocuments {
+			document := doc
+			ranked.Document = &document
+		}
+		res.Data[n] = ranked
+	}
+	sort.SliceStable(res.Data, func(a, b int) bool { return res.Data[a].Score > res.Data[b].Score })
+	if in.TopN != nil && *in.TopN >= 0 && *in.TopN < len(res.Data) {
+		res.Data = res.Data[:*in.TopN]
+	}
+	units := 1
+	res.Usage.RerankUnits = &units
+	return res, nil
+}
+
+func hash(word string) uint32 {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(word))
+	return h.Sum32()
+}
+
+func normalize(values []float32) {
+	var sum float64
+	for _, value := range values {
+		sum += float64(value) * float64(value)
+	}
+	if sum == 0 {
+		return
+	}
+	norm := float32(math.Sqrt(sum))
+	for n := range values {
+		values[n] /= norm
+	}
+}
@@ -0,0 +1,82 @@
+package pineconetest
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pinecone-io/go-pinecone/v6/pinecone"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// describeIndexName is application code that depends only on the ControlPlane interface.
+func describeIndexName(ctx context.Context, cp pinecone.ControlPlane, name string) (string, error) {
+	idx, err := cp.DescribeIndex(ctx, name)
+	if err != nil {
+		return "", err
+	}
+	return idx.Host, nil
+}
+
+// Unit tests:
+func TestStubsUnit(t *testing.T) {
+	ctx := context.Background()
+	cp := &ControlPlane{
+		DescribeIndexFunc: func(ctx context.Context, idxName string, opts ...pinecone.CallOption) (*pinecone.Index, error) {
+			return &pinecone.Index{Name: idxName, Host: idxName + ".example.com"}, nil
+		},
+	}
+	host, err := describeIndexName(ctx, cp, "movies")
+	require.NoError(t, err)
+	assert.Equal(t, "movies.example.com", host)
+
+	_, err = cp.ListIndexes(ctx)
+	require.ErrorIs(t, err, ErrNotStubbed)
+	assert.ErrorContains(t, err, "ControlPlane.ListIndexes")
+	assert.Equal(t, []string{"DescribeIndex", "ListIndexes"}, cp.Calls())
+
+	store := &VectorStore{}
+	_, err = store.UpsertVectors(ctx, nil)
+	require.ErrorIs(t, err, ErrNotStubbed)
+	assert.NoError(t, store.Close())
+	assert.Equal(t, "", store.Namespace())
+}
+
+func TestFakeEmbedUnit(t *testing.T) {
+	inference := &Inference{EmbedFunc: FakeEmbed(8)}
+	res, err := inference.Embed(context.Background(), &pinecone.EmbedRequest{
+		Model:      "fake",
+		TextInputs: []string{"red apple", "Red apple", "blue sky"},
+	})
+	require.NoError(t, err)
+	require.Len(t, res.Data, 3)
+	assert.Len(t, res.Data[0].DenseEmbedding.Values, 8)
+	assert.Equal(t, res.Data[0].DenseEmbedding.Values, res.Data[1].DenseEmbedding.Values, "embeddings should be deterministic")
+	assert.Equal(t, int32(6), *res.Usage.TotalTokens)
+
+	_, err = inference.Rerank(context.Background(), &pinecone.RerankRequest{})
+	require.ErrorIs(t, err, ErrNotStubbed)
+}
+
+func TestFakeRerankUnit(t *testing.T) {
+	inference := &Inference{RerankFunc: FakeRerank}
+	topN := 2
+	returnDocuments := false
+	res, err := inference.Rerank(context.Background(), &pinecone.RerankRequest{
+		Model: "fake",
+		Query: "red apple pie",
+		Documents: []pinecone.Document{
+			{"text": "a blue sky"},
+			{"text": "apple pie recipe"},
+			{"text": "red apple"},
+		},
+		TopN:            &topN,
+		ReturnDocuments: &returnDocuments,
+	})
+	require.NoError(t, err)
+	require.Len(t, res.Data, 2)
+	assert.Equal(t, 1, res.Data[0].Index, "ties keep input order")
+	assert.Equal(t, 2, res.Data[1].Index)
+	assert.InDelta(t, 2.0/3.0, res.Data[0].Score, 1e-6)
+	assert.Nil(t, res.Data[0].Document)
+}
@@ -0,0 +1,212 @@
+package pineconetest
+
+import (
+	"context"
+
+	"github.com/pinecone-io/go-pinecone/v6/pinecone"
+)
+
+// [VectorStore] is a stub [pinecone.VectorStore]. Each method calls the function in the field of the same name
+// with a Func suffix, or returns an error wrapping [ErrNotStubbed] if the field is nil. Namespace and Close
+// return "" and nil when not stubbed. For a working in-memory store, use the localindex package instead.
+type VectorStore struct {
+	UpsertVectorsFunc               func(ctx context.Context, in []*pinecone.Vector, opts ...pinecone.CallOption) (uint32, error)
+	FetchVectorsFunc                func(ctx context.Context, ids []string, opts ...pinecone.CallOption) (*pinecone.FetchVectorsResponse, error)
+	FetchVectorsByMetadataFunc      func(ctx context.Context, in *pinecone.FetchVectorsByMetadataRequest, opts ...pinecone.CallOption) (*pinecone.FetchVectorsByMetadataResponse, error)
+	ListVectorsFunc                 func(ctx context.Context, in *pinecone.ListVectorsRequest, opts ...pinecone.CallOption) (*pinecone.ListVectorsResponse, error)
+	QueryByVectorValuesFunc         func(ctx context.Context, in *pinecone.QueryByVectorValuesRequest, opts ...pinecone.CallOption) (*pinecone.QueryVectorsResponse, error)
+	QueryByVectorIdFunc             func(ctx context.Context, in *pinecone.QueryByVectorIdRequest, opts ...pinecone.CallOption) (*pinecone.QueryVectorsResponse, error)
+	UpdateVectorFunc                func(ctx context.Context, in *pinecone.UpdateVectorRequest, opts ...pinecone.CallOption) error
+	UpdateVectorsByMetadataFunc     func(ctx context.Context, in *pinecone.UpdateVectorsByMetadataRequest, opts ...pinecone.CallOption) (*pinecone.UpdateVectorsByMetadataResponse, error)
+	DeleteVectorsByIdFunc           func(ctx context.Context, ids []string, opts ...pinecone.CallOption) error
+	DeleteVectorsByFilterFunc       func(ctx context.Context, metadataFilter *pinecone.MetadataFilter, opts ...pinecone.CallOption) error
+	DeleteAllVectorsInNamespaceFunc func(ctx context.Context, opts ...pinecone.CallOption) error
+	DescribeIndexStatsFunc          func(ctx context.Context, opts ...pinecone.CallOption) (*pinecone.DescribeIndexStatsResponse, error)
+	DescribeIndexStatsFilteredFunc  func(ctx context.Context, metadataFilter *pinecone.MetadataFilter, opts ...pinecone.CallOption) (*pinecone.DescribeIndexStatsResponse, error)
+	CreateNamespaceFunc             func(ctx context.Context, in *pinecone.CreateNamespaceParams, opts ...pinecone.CallOption) (*pinecone.NamespaceDescription, error)
+	DescribeNamespaceFunc           func(ctx context.Context, namespace string, opts ...pinecone.CallOption) (*pinecone.NamespaceDescription, error)
+	ListNamespacesFunc              func(ctx context.Context, in *pinecone.ListNamespacesParams, opts ...pinecone.CallOption) (*pinecone.ListNamespacesResponse, error)
+	DeleteNamespaceFunc             func(ctx context.Context, namespace string, opts ...pinecone.CallOption) error
+	NamespaceFunc                   func() string
+	CloseFunc                       func() error
+
+	calls recorder
+}
+
+var _ pinecone.VectorStore = (*VectorStore)(nil)
+
+// [VectorStore.UpsertVectors] calls UpsertVectorsFunc, or returns [ErrNotStubbed] if it is nil.
+func (f *VectorStore) UpsertVectors(ctx context.Context, in []*pinecone.Vector, opts ...pinecone.CallOption) (uint32, error) {
+	f.calls.record("UpsertVectors")
+	if f.UpsertVectorsFunc == nil {
+		return 0, notStubbed("VectorStore.UpsertVectors")
+	}
+	return f.UpsertVectorsFunc(ctx, in, opts...)
+}
+
+// [VectorStore.FetchVectors] calls FetchVectorsFunc, or returns [ErrNotStubbed] if it is nil.
+func (f *VectorStore) FetchVectors(ctx context.Context, ids []string, opts ...pinecone.CallOption) (*pinecone.FetchVectorsResponse, error) {
+	f.calls.record("FetchVectors")
+	if f.FetchVectorsFunc == nil {
+		return nil, notStubbed("VectorStore.FetchVectors")
+	}
+	return f.FetchVectorsFunc(ctx, ids, opts...)
+}
+
+// [VectorStore.FetchVectorsByMetadata] calls FetchVectorsByMetadataFunc, or returns [ErrNotStubbed] if it is nil.
+func (f *VectorStore) FetchVectorsByMetadata(ctx context.Context, in *pinecone.FetchVectorsByMetadataRequest, opts ...pinecone.CallOption) (*pinecone.FetchVectorsByMetadataResponse, error) {
+	f.calls.record("FetchVectorsByMetadata")
+	if f.FetchVectorsByMetadataFunc == nil {
+		return nil, notStubbed("VectorStore.FetchVectorsByMetadata")
+	}
+	return f.FetchVectorsByMetadataFunc(ctx, in, opts...)
+}
+
+// [VectorStore.ListVectors] calls ListVectorsFunc, or returns [ErrNotStubbed] if it is nil.
+func (f *VectorStore) ListVectors(ctx context.Context, in *pinecone.ListVectorsRequest, opts ...pinecone.CallOption) (*pinecone.ListVectorsResponse, error) {
+	f.calls.record("ListVectors")
+	if f.ListVectorsFunc == nil {
+		return nil, notStubbed("VectorStore.ListVectors")
+	}
+	return f.ListVectorsFunc(ctx, in, opts...)
+}
+
+// [VectorStore.QueryByVectorValues] calls QueryByVectorValuesFunc, or returns [ErrNotStubbed] if it is nil.
+func (f *VectorStore) QueryByVectorValues(ctx context.Context, in *pinecone.QueryByVectorValuesRequest, opts ...pinecone.CallOption) (*pinecone.QueryVectorsResponse, error) {
+	f.calls.record("QueryByVectorValues")
+	if f.QueryByVectorValuesFunc == nil {
+		return nil, notStubbed("VectorStore.QueryByVectorValues")
+	}
+	return f.QueryByVectorValuesFunc(ctx, in, opts...)
+}
+
+// [VectorStore.QueryByVectorId] calls QueryByVectorIdFunc, or returns [ErrNotStubbed] if it is nil.
+func (f *VectorStore) QueryByVectorId(ctx context.Context, in *pinecone.QueryByVectorIdRequest, opts ...pinecone.CallOption) (*pinecone.QueryVectorsResponse, error) {
+	f.calls.record("QueryByVectorId")
+	if f.QueryByVectorIdFunc == nil {
+		return nil, notStubbed("VectorStore.QueryByVectorId")
+	}
+	return f.QueryByVectorIdFunc(ctx, in, opts...)
+}
+
+// [VectorStore.UpdateVector] calls UpdateVectorFunc, or returns [ErrNotStubbed] if it is nil.
+func (f *VectorStore) UpdateVector(ctx context.Context, in *pinecone.UpdateVectorRequest, opts ...pinecone.CallOption) error {
+	f.calls.record("UpdateVector")
+	if f.UpdateVectorFunc == nil {
+		return notStubbed("VectorStore.UpdateVector")
+	}
+	return f.UpdateVectorFunc(ctx, in, opts...)
+}
+
+// [VectorStore.UpdateVectorsByMetadata] calls UpdateVectorsByMetadataFunc, or returns [ErrNotStubbed] if it is nil.
+func (f *VectorStore) UpdateVectorsByMetadata(ctx context.Context, in *pinecone.UpdateVectorsByMetadataRequest, opts ...pinecone.CallOption) (*pinecone.UpdateVectorsByMetadataResponse, error) {
+	f.calls.record("UpdateVectorsByMetadata")
+	if f.UpdateVectorsByMetadataFunc == nil {
+		return nil, notStubbed("VectorStore.UpdateVectorsByMetadata")
+	}
+	return f.UpdateVectorsByMetadataFunc(ctx, in, opts...)
+}
+
+// [VectorStore.DeleteVectorsById] calls DeleteVectorsByIdFunc, or returns [ErrNotStubbed] if it is nil.
+func (f *VectorStore) DeleteVectorsById(ctx context.Context, ids []string, opts ...pinecone.CallOption) error {
+	f.calls.record("DeleteVectorsById")
+	if f.DeleteVectorsByIdFunc == nil {
+		return notStubbed("VectorStore.DeleteVectorsById")
+	}
+	return f.DeleteVectorsByIdFunc(ctx, ids, opts...)
+}
+
+// [VectorStore.DeleteVectorsByFilter] calls DeleteVectorsByFilterFunc, or returns [ErrNotStubbed] if it is nil.
+func (f *VectorStore) DeleteVectorsByFilter(ctx context.Context, metadataFilter *pinecone.MetadataFilter, opts ...pinecone.CallOption) error {
+	f.calls.record("DeleteVectorsByFilter")
+	if f.DeleteVectorsByFilterFunc == nil {
+		return notStubbed("VectorStore.DeleteVectorsByFilter")
+	}
+	return f.DeleteVectorsByFilterFunc(ctx, metadataFilter, opts...)
+}
+
+// [VectorStore.DeleteAllVectorsInNamespace] calls DeleteAllVectorsInNamespaceFunc, or returns [ErrNotStubbed] if it is nil.
+func (f *VectorStore) DeleteAllVectorsInNamespace(ctx context.Context, opts ...pinecone.CallOption) error {
+	f.calls.record("DeleteAllVectorsInNamespace")
+	if f.DeleteAllVectorsInNamespaceFunc == nil {
+		return notStubbed("VectorStore.DeleteAllVectorsInNamespace")
+	}
+	return f.DeleteAllVectorsInNamespaceFunc(ctx, opts...)
+}
+
+// [VectorStore.DescribeIndexStats] calls DescribeIndexStatsFunc, or returns [ErrNotStubbed] if it is nil.
+func (f *VectorStore) DescribeIndexStats(ctx context.Context, opts ...pinecone.CallOption) (*pinecone.DescribeIndexStatsResponse, error) {
+	f.calls.record("DescribeIndexStats")
+	if f.DescribeIndexStatsFunc == nil {
+		return nil, notStubbed("VectorStore.DescribeIndexStats")
+	}
+	return f.DescribeIndexStatsFunc(ctx, opts...)
+}
+
+// [VectorStore.DescribeIndexStatsFiltered] calls DescribeIndexStatsFilteredFunc, or returns [ErrNotStubbed] if it is nil.
+func (f *VectorStore) DescribeIndexStatsFiltered(ctx context.Context, metadataFilter *pinecone.MetadataFilter, opts ...pinecone.CallOption) (*pinecone.DescribeIndexStatsResponse, error) {
+	f.calls.record("DescribeIndexStatsFiltered")
+	if f.DescribeIndexStatsFilteredFunc == nil {
+		return nil, notStubbed("VectorStore.DescribeIndexStatsFiltered")
+	}
+	return f.DescribeIndexStatsFilteredFunc(ctx, metadataFilter, opts...)
+}
+
+// [VectorStore.CreateNamespace] calls CreateNamespaceFunc, or returns [ErrNotStubbed] if it is nil.
+func (f *VectorStore) CreateNamespace(ctx context.Context, in *pinecone.CreateNamespaceParams, opts ...pinecone.CallOption) (*pinecone.NamespaceDescription, error) {
+	f.calls.record("CreateNamespace")
+	if f.CreateNamespaceFunc == nil {
+		return nil, notStubbed("VectorStore.CreateNamespace")
+	}
+	return f.CreateNamespaceFunc(ctx, in, opts...)
+}
+
+// [VectorStore.DescribeNamespace] calls DescribeNamespaceFunc, or returns [ErrNotStubbed] if it is nil.
+func (f *VectorStore) DescribeNamespace(ctx context.Context, namespace string, opts ...pinecone.CallOption) (*pinecone.NamespaceDescription, error) {
+	f.calls.record("DescribeNamespace")
+	if f.DescribeNamespaceFunc == nil {
+		return nil, notStubbed("VectorStore.DescribeNamespace")
+	}
+	return f.DescribeNamespaceFunc(ctx, namespace, opts...)
+}
+
+// [VectorStore.ListNamespaces] calls ListNamespacesFunc, or returns [ErrNotStubbed] if it is nil.
+func (f *VectorStore) ListNamespaces(ctx context.Context, in *pinecone.ListNamespacesParams, opts ...pinecone.CallOption) (*pinecone.ListNamespacesResponse, error) {
+	f.calls.record("ListNamespaces")
+	if f.ListNamespacesFunc == nil {
+		return nil, notStubbed("VectorStore.ListNamespaces")
+	}
+	return f.ListNamespacesFunc(ctx, in, opts...)
+}
+
+// [VectorStore.DeleteNamespace] calls DeleteNamespaceFunc, or returns [ErrNotStubbed] if it is nil.
+func (f *VectorStore) DeleteNamespace(ctx context.Context, namespace string, opts ...pinecone.CallOption) error {
+	f.calls.record("DeleteNamespace")
+	if f.DeleteNamespaceFunc == nil {
+		return notStubbed("VectorStore.DeleteNamespace")
+	}
+	return f.DeleteNamespaceFunc(ctx, namespace, opts...)
+}
+
+// [VectorStore.Namespace] calls NamespaceFunc, or returns "" if it is nil.
+func (f *VectorStore) Namespace() string {
+	f.calls.record("Namespace")
+	if f.NamespaceFunc == nil {
+		return ""
+	}
+	return f.NamespaceFunc()
+}
+
+// [VectorStore.Close] calls CloseFunc, or returns nil if it is nil.
+func (f *VectorStore) Close() error {
+	f.calls.record("Close")
+	if f.CloseFunc == nil {
+		return nil
+	}
+	return f.CloseFunc()
+}
+
+// [VectorStore.Calls] returns the names of the methods called so far, in order.
+func (f *VectorStore) Calls() []string {
+	return f.calls.list()
+}
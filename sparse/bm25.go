@@ -0,0 +1,157 @@
+package sparse
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/pinecone-io/go-pinecone/v6/pinecone"
+)
+
+const (
+	// DefaultK1 is the default [BM25Encoder.K1], controlling how quickly repeated terms stop adding weight.
+	DefaultK1 = 1.2
+	// DefaultB is the default [BM25Encoder.B], controlling how much document length normalizes term weights.
+	DefaultB = 0.75
+)
+
+// [BM25Encoder] encodes text as BM25 sparse vectors. Documents are encoded with their term frequency
+// saturation and length normalization, and queries with the inverse document frequency of their terms, so the
+// dot product Pinecone computes between the two is the BM25 score.
+//
+// A BM25Encoder must be fitted with [BM25Encoder.Fit] before encoding. All fields are exported with JSON tags,
+// so a fitted encoder can be saved with json.Marshal and restored with json.Unmarshal.
+//
+// Fields:
+//   - K1: Term frequency saturation. Defaults to [DefaultK1] with [NewBM25Encoder].
+//   - B: Document length normalization, between 0 and 1. Defaults to [DefaultB] with [NewBM25Encoder].
+//   - Tokenizer: The [Tokenizer] used for documents and queries.
+//   - DocumentCount: The number of documents the encoder was fitted on.
+//   - AverageLength: The average number of tokens per fitted document.
+//   - DocumentFrequency: The number of fitted documents containing each token, keyed by [TokenIndex].
+type BM25Encoder struct {
+	K1                float64           `json:"k1"`
+	B                 float64           `json:"b"`
+	Tokenizer         Tokenizer         `json:"tokenizer"`
+	DocumentCount     int               `json:"document_count"`
+	AverageLength     float64           `json:"average_length"`
+	DocumentFrequency map[uint32]uint32 `json:"document_frequency"`
+}
+
+// [NewBM25Encoder] returns an unfitted [BM25Encoder] with the default parameters and [EnglishStopWords].
+//
+// Example:
+//
+//	    encoder := sparse.NewBM25Encoder()
+//	    if err := encoder.Fit(corpus); err != nil {
+//		       log.Fatalf("Failed to fit encoder: %v", err)
+//	    }
+//
+//	    docs, err := encoder.EncodeDocuments(corpus)
+//	    if err != nil {
+//		       log.Fatalf("Failed to encode documents: %v", err)
+//	    }
+//
+//	    saved, err := json.Marshal(encoder)
+//	    if err != nil {
+//		       log.Fatalf("Failed to save encoder: %v", err)
+//	    }
+func NewBM25Encoder() *BM25Encoder {
+	return &BM25Encoder{
+		K1:        DefaultK1,
+		B:         DefaultB,
+		Tokenizer: Tokenizer{StopWords: EnglishStopWords},
+	}
+}
+
+// [BM25Encoder.Fit] computes the document frequencies and average document length of corpus, replacing any
+// previous fit.
+func (e *BM25Encoder) Fit(corpus []string) error {
+	if len(corpus) == 0 {
+		return fmt.Errorf("corpus must contain at least one document")
+	}
+	frequency := make(map[uint32]uint32)
+	totalLength := 0
+	for _, doc := range corpus {
+		tokens := e.Tokenizer.Tokenize(doc)
+		totalLength += len(tokens)
+		seen := make(map[uint32]bool, len(tokens))
+		for _, token := range tokens {
+			index := TokenIndex(token)
+			if !seen[index] {
+				seen[index] = true
+				frequency[index]++
+			}
+		}
+	}
+	e.DocumentCount = len(corpus)
+	e.AverageLength = float64(totalLength) / float64(len(corpus))
+	e.DocumentFrequency = frequency
+	return nil
+}
+
+// [BM25Encoder.EncodeDocuments] encodes texts for upsert, returning one sparse vector per text, sorted by
+// index. A text without tokens gives an empty vector.
+func (e *BM25Encoder) EncodeDocuments(texts []string) ([]*pinecone.SparseValues, error) {
+	if err := e.checkFitted(); err != nil {
+		return nil, err
+	}
+	out := make([]*pinecone.SparseValues, len(texts))
+	for n, text := range texts {
+		out[n] = e.encodeDocument(text)
+	}
+	return out, nil
+}
+
+// [BM25Encoder.EncodeQuery] encodes text for querying, returning a sparse vector sorted by index whose values
+// are the normalized inverse document frequencies of its tokens.
+func (e *BM25Encoder) EncodeQuery(text string) (*pinecone.SparseValues, error) {
+	if err := e.checkFitted(); err != nil {
+		return nil, err
+	}
+	counts := e.termCounts(text)
+	weights := make(map[uint32]float32, len(counts))
+	var total float64
+	for index := range counts {
+		idf := math.Log((float64(e.DocumentCount) + 1) / (float64(e.DocumentFrequency[index]) + 0.5))
+		weights[index] = float32(idf)
+		total += idf
+	}
+	if total > 0 {
+		for index, weight := range weights {
+			weights[index] = float32(float64(weight) / total)
+		}
+	}
+	return fromMap(weights), nil
+}
+
+func (e *BM25Encoder) encodeDocument(text string) *pinecone.SparseValues {
+	counts := e.termCounts(text)
+	length := 0
+	for _, count := range counts {
+		length += count
+	}
+	norm := e.K1 * (1 - e.B + e.B*float64(length)/e.AverageLength)
+	weights := make(map[uint32]float32, len(counts))
+	for index, count := range counts {
+		weights[index] = float32(float64(count) / (norm + float64(count)))
+	}
+	return fromMap(weights)
+}
+
+func (e *BM25Encoder) termCounts(text string) map[uint32]int {
+	counts := make(map[uint32]int)
+	for _, token := range e.Tokenizer.Tokenize(text) {
+		counts[TokenIndex(token)]++
+	}
+	return counts
+}
+
+func (e *BM25Encoder) checkFitted() error {
+	if e.DocumentCount == 0 || e.AverageLength == 0 {
+		return fmt.Errorf("BM25Encoder must be fitted before encoding")
+	}
+	if e.K1 < 0 || e.B < 0 || e.B > 1 {
+		return fmt.Errorf("BM25Encoder requires K1 >= 0 and B between 0 and 1")
+	}
+	return nil
+}
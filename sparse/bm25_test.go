@@ -0,0 +1,88 @@
+package sparse
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/pinecone-io/go-pinecone/v6/pinecone"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var bm25Corpus = []string{
+	"The quick brown fox jumps over the lazy dog",
+	"A quick brown dog outpaces a quick fox",
+	"Lazy afternoons are for sleeping",
+}
+
+// dot returns the dot product of two sparse vectors, as Pinecone computes it.
+func dot(a, b *pinecone.SparseValues) float32 {
+	values := make(map[uint32]float32, len(a.Indices))
+	for n, index := range a.Indices {
+		values[index] = a.Values[n]
+	}
+	var sum float32
+	for n, index := range b.Indices {
+		sum += values[index] * b.Values[n]
+	}
+	return sum
+}
+
+// Unit tests:
+func TestBM25EncoderUnit(t *testing.T) {
+	encoder := NewBM25Encoder()
+	_, err := encoder.EncodeQuery("fox")
+	require.ErrorContains(t, err, "must be fitted")
+
+	require.NoError(t, encoder.Fit(bm25Corpus))
+	assert.Equal(t, 3, encoder.DocumentCount)
+	assert.Equal(t, uint32(2), encoder.DocumentFrequency[TokenIndex("fox")])
+
+	docs, err := encoder.EncodeDocuments(bm25Corpus)
+	require.NoError(t, err)
+	require.Len(t, docs, 3)
+	for _, doc := range docs {
+		require.NoError(t, Validate(doc))
+		assert.IsIncreasing(t, doc.Indices, "indices should be sorted and unique")
+		_, err := ToSearchRecordsVector(doc)
+		require.NoError(t, err)
+	}
+
+	query, err := encoder.EncodeQuery("lazy sleeping")
+	require.NoError(t, err)
+	var total float32
+	for _, value := range query.Values {
+		total += value
+	}
+	assert.InDelta(t, 1, total, 1e-6, "query weights should be normalized")
+	assert.Greater(t, dot(query, docs[2]), dot(query, docs[0]), "the document containing both terms should score highest")
+	assert.Zero(t, dot(query, docs[1]))
+
+	// The rarer term outweighs the more common one.
+	query, err = encoder.EncodeQuery("quick afternoons")
+	require.NoError(t, err)
+	assert.Greater(t, dot(query, docs[2]), dot(query, docs[0]))
+
+	empty, err := encoder.EncodeQuery("the a")
+	require.NoError(t, err)
+	assert.Empty(t, empty.Indices)
+}
+
+func TestBM25EncoderJSONUnit(t *testing.T) {
+	encoder := NewBM25Encoder()
+	require.NoError(t, encoder.Fit(bm25Corpus))
+
+	saved, err := json.Marshal(encoder)
+	require.NoError(t, err)
+	var loaded BM25Encoder
+	require.NoError(t, json.Unmarshal(saved, &loaded))
+	assert.Equal(t, *encoder, loaded)
+
+	want, err := encoder.EncodeDocuments(bm25Corpus)
+	require.NoError(t, err)
+	got, err := loaded.EncodeDocuments(bm25Corpus)
+	require.NoError(t, err)
+	assert.Equal(t, want, got, "a loaded encoder should encode identically")
+
+	require.Error(t, encoder.Fit(nil))
+}
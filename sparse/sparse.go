@@ -0,0 +1,246 @@
+// Package sparse builds and manipulates sparse vectors for Pinecone indexes with the "sparse" vector type, and
+// for hybrid dense-sparse search.
+//
+// A [BM25Encoder] turns text into [pinecone.SparseValues] offline: fit it on a corpus with [BM25Encoder.Fit],
+// encode records with [BM25Encoder.EncodeDocuments] for upsert, and encode queries with
+// [BM25Encoder.EncodeQuery]. The encoder is plain JSON, so a fitted encoder can be saved with json.Marshal and
+// loaded again where queries are served. Tokens are mapped to sparse indices with [TokenIndex], a stable hash,
+// so no vocabulary has to be stored.
+//
+// The remaining functions operate on [pinecone.SparseValues] without modifying their input:
+//   - [Validate]: Checks that indices and values line up.
+//   - [Dedupe]: Sorts by index and sums the values of duplicate indices.
+//   - [Normalize]: Scales to unit length.
+//   - [Scale]: Multiplies every value by a factor.
+//   - [Merge]: Adds sparse vectors together.
+//   - [HybridScale]: Weights a dense and a sparse query vector for hybrid search.
+//   - [ToSearchRecordsVector] and [FromSearchRecordsVector]: Convert to and from [pinecone.SearchRecordsVector].
+package sparse
+
+import (
+	"fmt"
+	"hash/fnv"
+	"math"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/pinecone-io/go-pinecone/v6/pinecone"
+)
+
+// [EnglishStopWords] is a list of common English words that carry little meaning for keyword search. Use it
+// as [Tokenizer.StopWords].
+var EnglishStopWords = []string{
+	"a", "an", "and", "are", "as", "at", "be", "but", "by", "for", "from", "has", "have", "he", "her", "his",
+	"i", "if", "in", "into", "is", "it", "its", "of", "on", "or", "our", "she", "so", "that", "the", "their",
+	"them", "then", "there", "these", "they", "this", "to", "was", "we", "were", "what", "when", "which",
+	"who", "will", "with", "you", "your",
+}
+
+// [Tokenizer] splits text into tokens. The zero value lowercases text, splits it on every character that is
+// not a letter or digit, and keeps every token.
+//
+// Fields:
+//   - StopWords: (Optional) Tokens to drop, compared after lowercasing, e.g. [EnglishStopWords].
+//   - MinLength: (Optional) Tokens with fewer characters are dropped.
+//   - KeepCase: (Optional) Whether to keep the case of the text instead of lowercasing it.
+type Tokenizer struct {
+	StopWords []string `json:"stop_words,omitempty"`
+	MinLength int      `json:"min_length,omitempty"`
+	KeepCase  bool     `json:"keep_case,omitempty"`
+}
+
+// [Tokenizer.Tokenize] returns the tokens of text, in order. The same text always gives the same tokens.
+func (t Tokenizer) Tokenize(text string) []string {
+	stopWords := make(map[string]bool, len(t.StopWords))
+	for _, word := range t.StopWords {
+		stopWords[strings.ToLower(word)] = true
+	}
+
+	fields := strings.FieldsFunc(text, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	tokens := make([]string, 0, len(fields))
+	for _, field := range fields {
+		lower := strings.ToLower(field)
+		if stopWords[lower] || len([]rune(field)) < t.MinLength {
+			continue
+		}
+		if t.KeepCase {
+			tokens = append(tokens, field)
+		} else {
+			tokens = append(tokens, lower)
+		}
+	}
+	return tokens
+}
+
+// [TokenIndex] returns the sparse index of token: the 32-bit FNV-1a hash of its UTF-8 bytes with the top bit
+// cleared, so that the index also fits in a [pinecone.SearchRecordsVector]. Distinct tokens may rarely share an
+// index; their values are then summed.
+func TokenIndex(token string) uint32 {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(token))
+	return h.Sum32() & math.MaxInt32
+}
+
+// [Validate] returns an error if sv has a different number of indices and values, or contains a value that
+// is NaN or infinite.
+func Validate(sv *pinecone.SparseValues) error {
+	if sv == nil {
+		return fmt.Errorf("sparse values cannot be nil")
+	}
+	if len(sv.Indices) != len(sv.Values) {
+		return fmt.Errorf("sparse values have %d indices but %d values", len(sv.Indices), len(sv.Values))
+	}
+	for n, value := range sv.Values {
+		if math.IsNaN(float64(value)) || math.IsInf(float64(value), 0) {
+			return fmt.Errorf("sparse value at position %d is %v", n, value)
+		}
+	}
+	return nil
+}
+
+// [Dedupe] returns a copy of sv sorted by index, with the values of duplicate indices summed. Pinecone
+// rejects sparse vectors with duplicate indices.
+func Dedupe(sv *pinecone.SparseValues) *pinecone.SparseValues {
+	if sv == nil {
+		return nil
+	}
+	sums := make(map[uint32]float32, len(sv.Indices))
+	for n, index := range sv.Indices {
+		if n < len(sv.Values) {
+			sums[index] += sv.Values[n]
+		}
+	}
+	return fromMap(sums)
+}
+
+// [Normalize] returns a copy of sv scaled to unit L2 norm. A vector whose values are all zero is returned
+// unchanged.
+func Normalize(sv *pinecone.SparseValues) *pinecone.SparseValues {
+	if sv == nil {
+		return nil
+	}
+	var sum float64
+	for _, value := range sv.Values {
+		sum += float64(value) * float64(value)
+	}
+	if sum == 0 {
+		return Scale(sv, 1)
+	}
+	return Scale(sv, float32(1/math.Sqrt(sum)))
+}
+
+// [Scale] returns a copy of sv with every value multiplied by factor.
+func Scale(sv *pinecone.SparseValues, factor float32) *pinecone.SparseValues {
+	if sv == nil {
+		return nil
+	}
+	out := &pinecone.SparseValues{
+		Indices: append([]uint32(nil), sv.Indices...),
+		Values:  make([]float32, len(sv.Values)),
+	}
+	for n, value := range sv.Values {
+		out.Values[n] = value * factor
+	}
+	return out
+}
+
+// [Merge] returns the sum of vectors, sorted by index. Nil vectors are skipped.
+func Merge(vectors ...*pinecone.SparseValues) *pinecone.SparseValues {
+	sums := make(map[uint32]float32)
+	for _, sv := range vectors {
+		if sv == nil {
+			continue
+		}
+		for n, index := range sv.Indices {
+			if n < len(sv.Values) {
+				sums[index] += sv.Values[n]
+			}
+		}
+	}
+	return fromMap(sums)
+}
+
+// [HybridScale] weights a dense and a sparse query vector for hybrid search with a convex combination: dense
+// values are multiplied by alpha and sparse values by 1 - alpha. An alpha of 1 is a pure dense search and an
+// alpha of 0 a pure keyword search.
+//
+// Example:
+//
+//	    sparseQuery, err := encoder.EncodeQuery(query)
+//	    if err != nil {
+//		       log.Fatalf("Failed to encode query: %v", err)
+//	    }
+//	    dense, sparseValues, err := sparse.HybridScale(denseQuery, sparseQuery, 0.75)
+//	    if err != nil {
+//		       log.Fatalf("Failed to scale query: %v", err)
+//	    }
+//	    res, err := idxConnection.QueryByVectorValues(ctx, &pinecone.QueryByVectorValuesRequest{
+//		       Vector:       dense,
+//		       SparseValues: sparseValues,
+//		       TopK:         10,
+//	    })
+func HybridScale(dense []float32, sv *pinecone.SparseValues, alpha float32) ([]float32, *pinecone.SparseValues, error) {
+	if alpha < 0 || alpha > 1 {
+		return nil, nil, fmt.Errorf("alpha must be between 0 and 1, got %v", alpha)
+	}
+	scaledDense := make([]float32, len(dense))
+	for n, value := range dense {
+		scaledDense[n] = value * alpha
+	}
+	return scaledDense, Scale(sv, 1-alpha), nil
+}
+
+// [ToSearchRecordsVector] converts sv to a [pinecone.SearchRecordsVector] for
+// [pinecone.SearchRecordsQuery.Vector]. It returns an error if an index does not fit in an int32.
+func ToSearchRecordsVector(sv *pinecone.SparseValues) (*pinecone.SearchRecordsVector, error) {
+	if err := Validate(sv); err != nil {
+		return nil, err
+	}
+	indices := make([]int32, len(sv.Indices))
+	for n, index := range sv.Indices {
+		if index > math.MaxInt32 {
+			return nil, fmt.Errorf("sparse index %d does not fit in a SearchRecordsVector", index)
+		}
+		indices[n] = int32(index)
+	}
+	values := append([]float32(nil), sv.Values...)
+	return &pinecone.SearchRecordsVector{SparseIndices: &indices, SparseValues: &values}, nil
+}
+
+// [FromSearchRecordsVector] returns the sparse part of v as [pinecone.SparseValues], or nil if v has no
+// sparse indices. It returns an error if an index is negative.
+func FromSearchRecordsVector(v *pinecone.SearchRecordsVector) (*pinecone.SparseValues, error) {
+	if v == nil || v.SparseIndices == nil {
+		return nil, nil
+	}
+	var values []float32
+	if v.SparseValues != nil {
+		values = *v.SparseValues
+	}
+	out := &pinecone.SparseValues{Indices: make([]uint32, len(*v.SparseIndices)), Values: append([]float32(nil), values...)}
+	for n, index := range *v.SparseIndices {
+		if index < 0 {
+			return nil, fmt.Errorf("sparse index %d cannot be negative", index)
+		}
+		out.Indices[n] = uint32(index)
+	}
+	if err := Validate(out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func fromMap(sums map[uint32]float32) *pinecone.SparseValues {
+	out := &pinecone.SparseValues{Indices: make([]uint32, 0, len(sums)), Values: make([]float32, 0, len(sums))}
+	for index := range sums {
+		out.Indices = append(out.Indices, index)
+	}
+	sort.Slice(out.Indices, func(a, b int) bool { return out.Indices[a] < out.Indices[b] })
+	for _, index := range out.Indices {
+		out.Values = append(out.Values, sums[index])
+	}
+	return out
+}
@@ -0,0 +1,76 @@
+package sparse
+
+import (
+	"math"
+	"testing"
+
+	"github.com/pinecone-io/go-pinecone/v6/pinecone"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Unit tests:
+func TestTokenizerUnit(t *testing.T) {
+	assert.Equal(t, []string{"the", "café", "opens", "at", "9am"}, Tokenizer{}.Tokenize("The café opens at 9am!"))
+	assert.Equal(t, []string{"café", "opens", "9am"}, Tokenizer{StopWords: EnglishStopWords}.Tokenize("The café opens at 9am!"))
+	assert.Equal(t, []string{"Hello", "World"}, Tokenizer{KeepCase: true, MinLength: 2}.Tokenize("Hello, a World"))
+	assert.Empty(t, Tokenizer{}.Tokenize(" ... "))
+
+	assert.Equal(t, TokenIndex("cafe"), TokenIndex("cafe"))
+	assert.NotEqual(t, TokenIndex("cafe"), TokenIndex("café"))
+}
+
+func TestDedupeAndMergeUnit(t *testing.T) {
+	in := &pinecone.SparseValues{Indices: []uint32{5, 1, 5}, Values: []float32{1, 2, 3}}
+	assert.Equal(t, &pinecone.SparseValues{Indices: []uint32{1, 5}, Values: []float32{2, 4}}, Dedupe(in))
+	assert.Equal(t, []uint32{5, 1, 5}, in.Indices, "the input should not be modified")
+
+	merged := Merge(
+		&pinecone.SparseValues{Indices: []uint32{1, 3}, Values: []float32{1, 1}},
+		nil,
+		&pinecone.SparseValues{Indices: []uint32{3, 2}, Values: []float32{2, 5}},
+	)
+	assert.Equal(t, &pinecone.SparseValues{Indices: []uint32{1, 2, 3}, Values: []float32{1, 5, 3}}, merged)
+	assert.Nil(t, Dedupe(nil))
+}
+
+func TestNormalizeAndScaleUnit(t *testing.T) {
+	in := &pinecone.SparseValues{Indices: []uint32{1, 2}, Values: []float32{3, 4}}
+	assert.Equal(t, []float32{0.6, 0.8}, Normalize(in).Values)
+	assert.Equal(t, []float32{3, 4}, in.Values, "the input should not be modified")
+	assert.Equal(t, []float32{6, 8}, Scale(in, 2).Values)
+
+	zero := &pinecone.SparseValues{Indices: []uint32{1}, Values: []float32{0}}
+	assert.Equal(t, zero, Normalize(zero))
+
+	dense, sv, err := HybridScale([]float32{1, 2}, in, 0.25)
+	require.NoError(t, err)
+	assert.Equal(t, []float32{0.25, 0.5}, dense)
+	assert.Equal(t, []float32{2.25, 3}, sv.Values)
+	_, _, err = HybridScale(nil, in, 1.5)
+	require.Error(t, err)
+}
+
+func TestSearchRecordsVectorConversionUnit(t *testing.T) {
+	in := &pinecone.SparseValues{Indices: []uint32{7, 9}, Values: []float32{0.5, 0.25}}
+	v, err := ToSearchRecordsVector(in)
+	require.NoError(t, err)
+	assert.Equal(t, []int32{7, 9}, *v.SparseIndices)
+	assert.Equal(t, []float32{0.5, 0.25}, *v.SparseValues)
+	assert.Nil(t, v.Values)
+
+	back, err := FromSearchRecordsVector(v)
+	require.NoError(t, err)
+	assert.Equal(t, in, back)
+
+	_, err = ToSearchRecordsVector(&pinecone.SparseValues{Indices: []uint32{math.MaxUint32}, Values: []float32{1}})
+	require.ErrorContains(t, err, "does not fit")
+	_, err = FromSearchRecordsVector(&pinecone.SearchRecordsVector{SparseIndices: &[]int32{-1}, SparseValues: &[]float32{1}})
+	require.ErrorContains(t, err, "negative")
+	_, err = ToSearchRecordsVector(&pinecone.SparseValues{Indices: []uint32{1}})
+	require.ErrorContains(t, err, "1 indices but 0 values")
+
+	empty, err := FromSearchRecordsVector(&pinecone.SearchRecordsVector{Values: &[]float32{1}})
+	require.NoError(t, err)
+	assert.Nil(t, empty)
+}